@@ -113,7 +113,7 @@ func appsCmdAddShell() *cobra.Command {
 // appsCmdList cria um comando Cobra para listar aplicativos.
 // Retorna um ponteiro para o comando Cobra configurado.
 func appsCmdList() *cobra.Command {
-	var name, status, method string
+	var name, status, method, profile string
 
 	cmd := &cobra.Command{
 		Use: "list",
@@ -126,7 +126,7 @@ func appsCmdList() *cobra.Command {
 		),
 		Run: func(cmd *cobra.Command, args []string) {
 			startTime := time.Now()
-			if err := p.ShowInstalledAppsTable(name, status, method); err != nil {
+			if err := p.ShowInstalledAppsTable(name, status, method, profile); err != nil {
 				gl.Log("error", "Error listing installed apps: "+err.Error())
 			} else {
 				gl.Log("success", "Apps listed successfully")
@@ -145,6 +145,7 @@ func appsCmdList() *cobra.Command {
 	cmd.Flags().StringArrayP("name", "n", []string{}, "App name")
 	cmd.Flags().StringP("status", "s", "", "App status")
 	cmd.Flags().StringP("method", "m", "", "App method")
+	cmd.Flags().StringVarP(&profile, "profile", "p", "", "Table view profile to load")
 
 	return cmd
 }
@@ -4,20 +4,19 @@ package cli
 import (
 	"bytes"
 	"encoding/csv"
-	"encoding/xml"
 	"fmt"
 	"io"
 	"os"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
-	"github.com/charmbracelet/lipgloss"
 	gl "github.com/kubex-ecosystem/logz"
 	c "github.com/kubex-ecosystem/xtui/components"
 	t "github.com/kubex-ecosystem/xtui/types"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
-	"gopkg.in/yaml.v3"
 )
 
 func ViewsCmdsList() []*cobra.Command {
@@ -31,6 +30,11 @@ func ViewsCmdsList() []*cobra.Command {
 func tableViewCmd() *cobra.Command {
 	var jsonFile, xmlFile, yamlFile, csvFile string
 	var delimiter, quote, comment string
+	var lazy bool
+	var filter string
+	var profile, tableID string
+	var output, outputFormat string
+	var formatRules string
 
 	cmd := &cobra.Command{
 		Use:     "table",
@@ -59,7 +63,30 @@ func tableViewCmd() *cobra.Command {
 				}
 			} else {
 				// Process file inputs
-				if csvFile != "" {
+				if csvFile != "" && lazy {
+					// a CSV file source neither filters nor sorts, and reads only the standard quote
+					if filter != "" || profile != "" {
+						return fmt.Errorf("--lazy cannot be combined with --filter or --profile: CSV file sources are not filtered or sorted")
+					}
+					if quote != "\"" {
+						return fmt.Errorf("--lazy only supports the default --quote (\")")
+					}
+					source, err := t.NewCSVFileSource(csvFile, []rune(delimiter)[0], []rune(comment)[0])
+					if err != nil {
+						return err
+					}
+					defer func() {
+						_ = source.Close()
+					}()
+					tbC := c.NewTableRendererFromSource(source, nil, nil)
+					if err := applyViewOptions(tbC, tableID, profile, filter, formatRules); err != nil {
+						return err
+					}
+					if output != "" {
+						return exportView(tbC, output, outputFormat)
+					}
+					return c.StartTableScreenFromRenderer(tbC)
+				} else if csvFile != "" {
 					data, err := os.ReadFile(csvFile)
 					if err != nil {
 						return err
@@ -73,12 +100,10 @@ func tableViewCmd() *cobra.Command {
 					if err != nil {
 						return err
 					}
-					mapper := t.NewMapperTypeWithObject[[][]string](&inputData, "/tmp")
-					resB, err := mapper.Deserialize(data, "json")
-					if err != nil {
-						return err
+					inputData, inputDataErr = parseJSON(data)
+					if inputDataErr != nil {
+						return inputDataErr
 					}
-					inputData = resB
 				} else if xmlFile != "" {
 					data, err := os.ReadFile(xmlFile)
 					if err != nil {
@@ -105,17 +130,16 @@ func tableViewCmd() *cobra.Command {
 				}
 			}
 
-			customStyles := map[string]lipgloss.Color{
-				"Info":    lipgloss.Color("#75FBAB"),
-				"Warning": lipgloss.Color("#FDFF90"),
-				"Error":   lipgloss.Color("#FF7698"),
-				"Debug":   lipgloss.Color("#929292"),
-			}
-
 			headers := inputData[0]
 			rows := inputData[1:]
 
-			tbC := c.NewTableRenderer(&t.TableHandler{Headers: headers, Rows: rows}, customStyles, nil)
+			tbC := c.NewTableRenderer(&t.TableHandler{Headers: headers, Rows: rows}, nil, nil)
+			if err := applyViewOptions(tbC, tableID, profile, filter, formatRules); err != nil {
+				return err
+			}
+			if output != "" {
+				return exportView(tbC, output, outputFormat)
+			}
 
 			return c.StartTableScreenFromRenderer(tbC)
 		},
@@ -128,10 +152,58 @@ func tableViewCmd() *cobra.Command {
 	cmd.Flags().StringVarP(&delimiter, "delimiter", "d", ",", "CSV delimiter")
 	cmd.Flags().StringVarP(&quote, "quote", "q", "\"", "CSV quote")
 	cmd.Flags().StringVarP(&comment, "comment", "m", "#", "CSV comment")
+	cmd.Flags().StringVarP(&filter, "filter", "f", "", "Initial filter expression (e.g. 'status=installed and version>=1.2')")
+	cmd.Flags().StringVarP(&profile, "profile", "p", "", "View profile to load (columns, sort, filter, page size)")
+	cmd.Flags().StringVar(&tableID, "table-id", "", "Identity under which view profiles are stored (defaults to one derived from the headers)")
+	cmd.Flags().BoolVar(&lazy, "lazy", false, "Index the CSV file and read only the visible rows (for very large files; not with --filter, --profile or --quote)")
+	cmd.Flags().StringVar(&formatRules, "format-rules", "", "YAML, JSON or TOML file of conditional formatting rules for the cells")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Export the view to this file ('-' for stdout) instead of opening the table")
+	cmd.Flags().StringVar(&outputFormat, "output-format", "", "Export format, by default taken from the --output extension ("+strings.Join(t.ExporterNames(), ", ")+")")
 
 	return cmd
 }
 
+// applyViewOptions sets the table identity, loads the conditional formatting rules and the view profile
+// and applies the filter given on the command line. An explicit filter takes precedence over the
+// profile's one.
+func applyViewOptions(tbC *c.TableRenderer, tableID, profile, filter, formatRules string) error {
+	if formatRules != "" {
+		if err := tbC.LoadFormatRules(formatRules); err != nil {
+			return err
+		}
+	}
+	if tableID != "" {
+		tbC.SetTableID(tableID)
+	}
+	if profile != "" {
+		if err := tbC.LoadProfile(profile); err != nil {
+			return err
+		}
+	}
+	if filter != "" || profile == "" {
+		return tbC.SetFilter(filter)
+	}
+	return nil
+}
+
+// exportView writes the filtered and sorted view to output, or to stdout when output is "-", with the
+// registered exporter of format or of the output extension.
+func exportView(tbC *c.TableRenderer, output, format string) error {
+	if output == "-" {
+		if format == "" {
+			return fmt.Errorf("--output-format is required when exporting to stdout")
+		}
+		_, err := tbC.ExportTo(os.Stdout, format, c.ExportFiltered)
+		return err
+	}
+	count, err := tbC.Export(output, format, c.ExportFiltered)
+	if err != nil {
+		return err
+	}
+	gl.Log("info", fmt.Sprintf("Exported %d rows to %s", count, output))
+	return nil
+}
+
 func parseCSV(data []byte, delimiter, quote, comment string) ([][]string, error) {
 	reader := csv.NewReader(bytes.NewReader(data))
 	reader.Comma = []rune(delimiter)[0]
@@ -146,30 +218,30 @@ func parseCSV(data []byte, delimiter, quote, comment string) ([][]string, error)
 	return records, nil
 }
 
+// parseJSON reads a JSON array of records (as exported by the table) or of rows, headers first.
+func parseJSON(data []byte) ([][]string, error) {
+	return recordsWithHeaders(t.ReadJSONRecords(bytes.NewReader(data)))
+}
+
+// parseXML reads the records exported by the table, whatever the root and row element names.
 func parseXML(data []byte) ([][]string, error) {
-	var result map[string]string
-	err := xml.Unmarshal(data, &result)
-	if err != nil {
-		return nil, err
-	}
-	var records [][]string
-	for key, value := range result {
-		records = append(records, []string{key, value})
-	}
-	return records, nil
+	return recordsWithHeaders(t.ReadXMLRecords(bytes.NewReader(data)))
 }
 
+// parseYAML reads a YAML list of records (as exported by the table) or of rows, headers first.
 func parseYAML(data []byte) ([][]string, error) {
-	var result map[string]string
-	err := yaml.Unmarshal(data, &result)
+	return recordsWithHeaders(t.ReadYAMLRecords(bytes.NewReader(data)))
+}
+
+// recordsWithHeaders returns headers and rows as one slice with the headers first.
+func recordsWithHeaders(headers []string, rows [][]string, err error) ([][]string, error) {
 	if err != nil {
 		return nil, err
 	}
-	var records [][]string
-	for key, value := range result {
-		records = append(records, []string{key, value})
+	if len(headers) == 0 {
+		return nil, fmt.Errorf("no records found")
 	}
-	return records, nil
+	return append([][]string{headers}, rows...), nil
 }
 
 func parseArgs(args []string) ([][]string, error) {
@@ -197,62 +269,77 @@ func TestTableViewCmd(t *testing.T) {
 	}
 }
 
+// NavigateAndExecuteViewCommand lists the flags of cmd in an editable table, applies the values
+// changed there and runs the command.
 func NavigateAndExecuteViewCommand(cmd *cobra.Command, args []string) error {
-	// Detect command and its flags
-	commandName := cmd.Name()
 	flags := cmd.Flags()
-
-	// Display command selection and flag definition in a table view
-	tableConfig := createTableConfig(commandName, flags)
-	customStyles := map[string]lipgloss.Color{
-		"Info":    lipgloss.Color("#75FBAB"),
-		"Warning": lipgloss.Color("#FDFF90"),
-		"Error":   lipgloss.Color("#FF7698"),
-		"Debug":   lipgloss.Color("#929292"),
-	}
-	if err := c.StartTableScreen(tableConfig, customStyles); err != nil {
+	tableConfig := createTableConfig(cmd.Name(), flags)
+	diff, err := c.StartTableEditorFromRenderer(tableConfig)
+	if err != nil {
 		return err
 	}
 
-	var tableValues = make(map[string]string)
-	for _, header := range tableConfig.GetHeaders() {
-		tableValues[header] = ""
-	}
-	for _, row := range tableConfig.GetRows() {
-		var i = 0
-		for key := range tableValues {
-			tableValues[key] = row[i]
-			i++
-		}
-	}
 	// Set flag values based on table input
-	flags.VisitAll(func(flag *pflag.Flag) {
-		if value, ok := tableValues[flag.Name]; ok {
-			if err := flag.Value.Set(value); err != nil {
-				gl.Log("fatal", err.Error())
-				return
-			}
+	for _, change := range diff.Changed {
+		if err := flags.Set(change.After[0], change.After[1]); err != nil {
+			gl.Log("error", fmt.Sprintf("Error setting flag %s: %v", change.After[0], err))
+			return err
 		}
-	})
+	}
 
 	// Execute the command
 	return cmd.Execute()
 }
 
+// createTableConfig returns a table with one row per flag (name, current value, usage) in which only
+// the values can be edited, each checked against the type of its flag.
 func createTableConfig(commandName string, flags *pflag.FlagSet) *c.TableRenderer {
-	var tableFields *c.TableRenderer
-	var tableHeaders []string
 	var tableRows [][]string
-
 	flags.VisitAll(func(flag *pflag.Flag) {
-		tableHeaders = append(tableHeaders, flag.Name)
-		tableRows = append(tableRows, []string{flag.Name, flag.Usage})
+		tableRows = append(tableRows, []string{flag.Name, flag.Value.String(), flag.Usage})
 	})
 
-	tableFields = c.NewTableRenderer(&t.TableHandler{
-		Headers: tableHeaders,
+	tableFields := c.NewTableRenderer(&t.TableHandler{
+		Headers: []string{"Flag", "Value", "Usage"},
 		Rows:    tableRows,
-	}, make(map[string]lipgloss.Color), nil)
+	}, nil, nil)
+	tableFields.SetTableID("flags-" + commandName)
+	tableFields.SetColumnTypes(t.ColumnString, t.ColumnString, t.ColumnString)
+	tableFields.SetColumnEditor(0, c.ColumnEditor{ReadOnly: true})
+	tableFields.SetColumnEditor(2, c.ColumnEditor{ReadOnly: true})
+	// The flags differ in type from row to row, while the Rules of a ColumnEditor apply to the whole
+	// column, so the values are checked per row by Check.
+	tableFields.SetColumnEditor(1, c.ColumnEditor{
+		Check: func(value string, row []string) error {
+			if flag := flags.Lookup(row[0]); flag != nil {
+				return checkFlagValue(flag.Value.Type(), value)
+			}
+			return nil
+		},
+	})
+	tableFields.SetEditable(true)
 
 	return tableFields
 }
+
+// checkFlagValue reports whether value parses as a flag of type flagType. Types it does not know are
+// accepted as they are and checked when the flag is set.
+func checkFlagValue(flagType, value string) error {
+	var err error
+	switch flagType {
+	case "bool":
+		_, err = strconv.ParseBool(value)
+	case "int", "int8", "int16", "int32", "int64", "count":
+		_, err = strconv.ParseInt(value, 0, 64)
+	case "uint", "uint8", "uint16", "uint32", "uint64":
+		_, err = strconv.ParseUint(value, 0, 64)
+	case "float32", "float64":
+		_, err = strconv.ParseFloat(value, 64)
+	case "duration":
+		_, err = time.ParseDuration(value)
+	}
+	if err != nil {
+		return fmt.Errorf("%q is not a valid %s", value, flagType)
+	}
+	return nil
+}
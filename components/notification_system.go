@@ -19,20 +19,21 @@ type Notification struct {
 }
 
 func ShowNotification(notification Notification) {
-	var style lipgloss.Style
+	fmt.Println(notificationStyle(notification.Type).Render(notification.Message))
+}
 
-	switch notification.Type {
+// notificationStyle returns the style used to render notifications of the given type.
+func notificationStyle(notificationType NotificationType) lipgloss.Style {
+	switch notificationType {
 	case Info:
-		style = lipgloss.NewStyle().Foreground(lipgloss.Color("#75FBAB"))
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("#75FBAB"))
 	case Warning:
-		style = lipgloss.NewStyle().Foreground(lipgloss.Color("#FDFF90"))
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("#FDFF90"))
 	case Error:
-		style = lipgloss.NewStyle().Foreground(lipgloss.Color("#FF7698"))
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("#FF7698"))
 	default:
-		style = lipgloss.NewStyle()
+		return lipgloss.NewStyle()
 	}
-
-	fmt.Println(style.Render(notification.Message))
 }
 
 func DisplayInfoNotification(message string) {
@@ -0,0 +1,283 @@
+package components
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// RowAction is an action on the row under the cursor, registered by the embedding application. It
+// runs from its Key in the table view or from the context menu (m). Run and Exec receive a copy of
+// the full source row and of the headers, regardless of the column layout. Run returns the command to
+// run. Exec, used instead of Run, returns an external program to hand the terminal to and the
+// callback producing the message sent when it exits; a nil callback reports failures in the status
+// line. Prefer Exec to a tea.ExecProcess returned by Run: the table tags the message of its callback,
+// so it comes back to this table when the screen holds several (tabs, linked tables).
+type RowAction struct {
+	Name    string                  // label in the context menu
+	Key     string                  // optional shortcut in the table view
+	Confirm bool                    // ask before running
+	Enabled func(row []string) bool // optional; hides the action for rows it does not apply to
+	Run     func(row []string, headers []string) tea.Cmd
+	Exec    func(row []string, headers []string) (*exec.Cmd, tea.ExecCallback)
+}
+
+// ActionResultMsg reports the outcome of an action in the status line. Commands returned by row
+// actions can produce it; Reload re-reads the rows from the source (see Reload).
+type ActionResultMsg struct {
+	Message string
+	Err     error
+	Reload  bool
+}
+
+// RegisterRowAction adds action to the context menu, replacing an action with the same name. Its
+// Key may not be one of the built-in table keys.
+func (k *TableRenderer) RegisterRowAction(action RowAction) error {
+	if err := checkActionKey(action.Name, action.Key); err != nil {
+		return err
+	}
+	for i, registered := range k.rowActions {
+		if registered.Name == action.Name {
+			k.rowActions[i] = action
+			return nil
+		}
+	}
+	k.rowActions = append(k.rowActions, action)
+	return nil
+}
+
+// UnregisterRowAction removes the row action name.
+func (k *TableRenderer) UnregisterRowAction(name string) {
+	for i, registered := range k.rowActions {
+		if registered.Name == name {
+			k.rowActions = append(k.rowActions[:i], k.rowActions[i+1:]...)
+			return
+		}
+	}
+}
+
+// RowActions returns the registered row actions.
+func (k *TableRenderer) RowActions() []RowAction { return append([]RowAction(nil), k.rowActions...) }
+
+// SelectedRow returns the row under the cursor, or nil when no row is selected.
+func (k *TableRenderer) SelectedRow() []string {
+	if k.selectedRow < 0 {
+		return nil
+	}
+	if row := k.rowAt(k.selectedRow); k.groupOf(row) == nil {
+		return row
+	}
+	return nil // group header
+}
+
+// actionEnabled reports whether action applies to row.
+func actionEnabled(action RowAction, row []string) bool {
+	return action.Enabled == nil || action.Enabled(row)
+}
+
+// RunRowAction runs the row action name on the row under the cursor and returns its command.
+func (k *TableRenderer) RunRowAction(name string) tea.Cmd {
+	for _, action := range k.rowActions {
+		if action.Name == name {
+			return k.runRowAction(action)
+		}
+	}
+	return k.notify(Error, "Unknown row action: "+name)
+}
+
+// runRowAction calls action with copies of the current row and of the headers.
+func (k *TableRenderer) runRowAction(action RowAction) tea.Cmd {
+	row := k.SelectedRow()
+	if row == nil {
+		return k.notify(Warning, "No row selected")
+	}
+	if !actionEnabled(action, row) {
+		return k.notify(Warning, action.Name+" does not apply to this row")
+	}
+	row, headers := append([]string(nil), row...), append([]string(nil), k.headers...)
+	if action.Exec != nil {
+		c, fn := action.Exec(row, headers)
+		if c == nil {
+			return nil
+		}
+		return k.execProcess(action.Name, c, fn)
+	}
+	if action.Run == nil {
+		return nil
+	}
+	return action.Run(row, headers)
+}
+
+// execProcess hands the terminal to c and calls fn when it exits (see execCallback).
+func (k *TableRenderer) execProcess(name string, c *exec.Cmd, fn tea.ExecCallback) tea.Cmd {
+	return tea.ExecProcess(c, execCallback(name, fn, k.msgRoute))
+}
+
+// execCallback returns the callback of the row action name. The message of fn, or the failure of the
+// process when fn is nil, is wrapped with wrap for the screen holding the table: the runtime sends it
+// without going through the commands the screen routes.
+func execCallback(name string, fn tea.ExecCallback, wrap func(tea.Msg) tea.Msg) tea.ExecCallback {
+	return func(err error) tea.Msg {
+		var msg tea.Msg
+		if fn != nil {
+			msg = fn(err)
+		} else if err != nil {
+			msg = ActionResultMsg{Message: name, Err: err}
+		}
+		if msg == nil {
+			return nil
+		}
+		return routeTableMsg(msg, wrap)
+	}
+}
+
+// rowActionForKey returns the row action bound to key.
+func (k *TableRenderer) rowActionForKey(key string) (int, bool) {
+	for i, action := range k.rowActions {
+		if action.Key != "" && action.Key == key {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+// triggerRowAction runs the action at index i, asking first when it needs confirmation.
+func (k *TableRenderer) triggerRowAction(i int) tea.Cmd {
+	action := k.rowActions[i]
+	if action.Confirm {
+		if cmd := k.openActionMenu(); !k.actionMenu {
+			return cmd
+		}
+		if pos := k.actionChoiceOf(i); pos >= 0 {
+			k.actionCursor, k.actionConfirm = pos, true
+			return nil
+		}
+	}
+	k.actionMenu = false
+	return k.runRowAction(action)
+}
+
+// openActionMenu opens the context menu with the actions that apply to the row under the cursor,
+// putting the cursor on the first row of the page when no row is selected.
+func (k *TableRenderer) openActionMenu() tea.Cmd {
+	if len(k.rowActions) == 0 {
+		return k.notify(Warning, "No row actions registered")
+	}
+	if k.selectedRow < 0 && k.rowCount() > 0 {
+		k.selectedRow = k.page * k.pageSize
+	}
+	row := k.SelectedRow()
+	if row == nil {
+		return k.notify(Warning, "No row selected")
+	}
+	k.actionChoices = k.actionChoices[:0]
+	for i, action := range k.rowActions {
+		if actionEnabled(action, row) {
+			k.actionChoices = append(k.actionChoices, i)
+		}
+	}
+	if len(k.actionChoices) == 0 {
+		return k.notify(Warning, "No actions for this row")
+	}
+	k.actionMenu = true
+	k.actionCursor = 0
+	k.actionConfirm = false
+	return nil
+}
+
+// actionChoiceOf returns the position in the context menu of the action at index i, or -1.
+func (k *TableRenderer) actionChoiceOf(i int) int {
+	for pos, choice := range k.actionChoices {
+		if choice == i {
+			return pos
+		}
+	}
+	return -1
+}
+
+// updateActionMenu handles the keys of the context menu.
+func (k *TableRenderer) updateActionMenu(message tea.KeyMsg) tea.Cmd {
+	if k.actionConfirm {
+		k.actionConfirm = false
+		switch message.String() {
+		case "y", "Y", "enter":
+			k.actionMenu = false
+			return k.runRowAction(k.rowActions[k.actionChoices[k.actionCursor]])
+		}
+		return nil
+	}
+	switch message.String() {
+	case "esc", "m", "q":
+		k.actionMenu = false
+	case "up", "k":
+		if k.actionCursor > 0 {
+			k.actionCursor--
+		}
+	case "down", "j":
+		if k.actionCursor < len(k.actionChoices)-1 {
+			k.actionCursor++
+		}
+	case "enter":
+		return k.triggerRowAction(k.actionChoices[k.actionCursor])
+	default:
+		if i, ok := k.rowActionForKey(message.String()); ok && k.actionChoiceOf(i) >= 0 {
+			return k.triggerRowAction(i)
+		}
+	}
+	return nil
+}
+
+// actionMenuView renders the context menu.
+func (k *TableRenderer) actionMenuView() string {
+	var b strings.Builder
+	b.WriteString(k.styles.header.Render("Actions: "+cellAt(k.SelectedRow(), k.firstVisibleColumn())) + "\n\n")
+	for pos, i := range k.actionChoices {
+		action := k.rowActions[i]
+		line := "  " + action.Name
+		if pos == k.actionCursor {
+			line = focusedStyle.Render("> " + action.Name)
+		}
+		if action.Key != "" {
+			line += blurredStyle.Render("  " + action.Key)
+		}
+		b.WriteString(line + "\n")
+	}
+	if k.actionConfirm {
+		prompt := fmt.Sprintf("Run %q? (y/n)", k.rowActions[k.actionChoices[k.actionCursor]].Name)
+		b.WriteString("\n" + notificationStyle(Warning).Render(prompt) + "\n")
+	} else {
+		b.WriteString("\n" + blurredStyle.Render("up/down choose, enter run, esc close"))
+	}
+	return lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(lipgloss.Color("238")).Padding(0, 1).Render(b.String())
+}
+
+// firstVisibleColumn returns the source index of the first displayed column.
+func (k *TableRenderer) firstVisibleColumn() int {
+	if cols := k.visibleColumns(); len(cols) > 0 {
+		return cols[0]
+	}
+	return 0
+}
+
+// actionResult shows the outcome of an action and reloads the rows when asked to.
+func (k *TableRenderer) actionResult(msg ActionResultMsg) tea.Cmd {
+	if msg.Reload {
+		if err := k.Reload(); err != nil && msg.Err == nil {
+			msg.Err = err
+		}
+	}
+	if msg.Err != nil {
+		text := msg.Err.Error()
+		if msg.Message != "" {
+			text = msg.Message + ": " + text
+		}
+		return k.notify(Error, text)
+	}
+	if msg.Message == "" {
+		return nil
+	}
+	return k.notify(Info, msg.Message)
+}
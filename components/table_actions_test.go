@@ -0,0 +1,144 @@
+package components
+
+import (
+	"errors"
+	"os/exec"
+	"reflect"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	tp "github.com/kubex-ecosystem/xtui/types"
+)
+
+// actionDone is the message of the test row actions.
+type actionDone struct{ row, headers []string }
+
+// newActionTable returns a table of packages with an Open action on every row, a Purge action asking
+// for confirmation on amd64 rows only and a Log action handing the terminal to a program.
+func newActionTable(t *testing.T) *TableRenderer {
+	t.Helper()
+	k := newSortTable()
+	actions := []RowAction{
+		{Name: "Open", Key: "o", Run: func(row, headers []string) tea.Cmd {
+			row[0] = "changed"
+			return func() tea.Msg { return actionDone{row, headers} }
+		}},
+		{Name: "Purge", Key: "p", Confirm: true, Enabled: func(row []string) bool { return row[2] == "amd64" },
+			Run: func(row, _ []string) tea.Cmd {
+				return func() tea.Msg { return ActionResultMsg{Message: "Purged " + row[0]} }
+			}},
+		{Name: "Log", Key: "L", Exec: func(row, _ []string) (*exec.Cmd, tea.ExecCallback) {
+			return exec.Command("true", row[0]), nil
+		}},
+	}
+	for _, action := range actions {
+		if err := k.RegisterRowAction(action); err != nil {
+			t.Fatalf("RegisterRowAction(%s): %v", action.Name, err)
+		}
+	}
+	return k
+}
+
+func TestRowActionKey(t *testing.T) {
+	k := newActionTable(t)
+	typeKeys(k, "o")
+	if k.toast == nil || k.toast.Type != Warning {
+		t.Fatalf("o without a row under the cursor: toast %+v", k.toast)
+	}
+	k.selectedRow = 2
+	_, cmd := k.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("o")})
+	msg, ok := runCmd(cmd).(actionDone)
+	if !ok {
+		t.Fatalf("o returned %T, want the message of the action", runCmd(cmd))
+	}
+	if want := []string{"changed", "30", "arm64"}; !reflect.DeepEqual(msg.row, want) || !reflect.DeepEqual(msg.headers, k.headers) {
+		t.Errorf("Run received %q, %q", msg.row, msg.headers)
+	}
+	if k.rows[2][0] != "git" {
+		t.Errorf("the action changed the table row to %q: it must receive a copy", k.rows[2][0])
+	}
+}
+
+func TestRowActionMenu(t *testing.T) {
+	k := newActionTable(t)
+	typeKeys(k, "m")
+	if !k.actionMenu || k.selectedRow != 0 {
+		t.Fatalf("m: menu open %v, cursor %d, want the menu on the first row", k.actionMenu, k.selectedRow)
+	}
+	if got, want := k.actionChoices, []int{0, 1, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("actions offered on vim = %v, want %v", got, want)
+	}
+	pressKey(k, tea.KeyDown)
+	pressKey(k, tea.KeyEnter)
+	if !k.actionMenu || !k.actionConfirm {
+		t.Fatal("enter on Purge did not ask for confirmation")
+	}
+	_, cmd := k.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	k.Update(runCmd(cmd))
+	if k.actionMenu || k.toast == nil || k.toast.Message != "Purged vim" {
+		t.Errorf("after y: menu open %v, toast %+v", k.actionMenu, k.toast)
+	}
+
+	k.selectedRow = 1 // nano, arm64
+	typeKeys(k, "m")
+	if got, want := k.actionChoices, []int{0, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("actions offered on nano = %v, want %v: Purge does not apply", got, want)
+	}
+	typeKeys(k, "p")
+	if k.actionConfirm {
+		t.Error("p ran Purge from the menu of a row it does not apply to")
+	}
+	pressKey(k, tea.KeyEsc)
+	typeKeys(k, "p")
+	if k.actionMenu || k.toast == nil || k.toast.Type != Warning {
+		t.Errorf("p on nano: menu open %v, toast %+v, want a warning", k.actionMenu, k.toast)
+	}
+}
+
+func TestRowActionExec(t *testing.T) {
+	k := newActionTable(t)
+	k.selectedRow = 0
+	if cmd := k.RunRowAction("Log"); cmd == nil {
+		t.Error("RunRowAction(Log) returned no command")
+	}
+	if cmd := k.RunRowAction("Missing"); cmd == nil || k.toast == nil || k.toast.Type != Error {
+		t.Errorf("RunRowAction(Missing): toast %+v", k.toast)
+	}
+}
+
+func TestRegisterRowAction(t *testing.T) {
+	k := newActionTable(t)
+	if err := k.RegisterRowAction(RowAction{Name: "Open", Key: "O"}); err != nil {
+		t.Fatalf("RegisterRowAction: %v", err)
+	}
+	k.UnregisterRowAction("Log")
+	var names []string
+	for _, action := range k.RowActions() {
+		names = append(names, action.Name+":"+action.Key)
+	}
+	if want := []string{"Open:O", "Purge:p"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("RowActions() = %q, want %q", names, want)
+	}
+	if err := k.RegisterRowAction(RowAction{Name: "Find", Key: "ctrl+f"}); err == nil {
+		t.Error("RegisterRowAction accepted the search key")
+	}
+}
+
+func TestActionResult(t *testing.T) {
+	handler := &tp.TableHandler{Headers: []string{"Name"}, Rows: [][]string{{"vim"}}}
+	k := NewTableRenderer(handler, nil, nil)
+	handler.Rows = append(handler.Rows, []string{"nano"})
+	k.Update(ActionResultMsg{Message: "Installed nano", Reload: true})
+	if got := viewNames(k); !reflect.DeepEqual(got, []string{"vim", "nano"}) || k.toast.Message != "Installed nano" {
+		t.Errorf("after a reloading result: rows %v, toast %+v", got, k.toast)
+	}
+	k.Update(ActionResultMsg{Message: "Remove vim", Err: errors.New("permission denied")})
+	if k.toast.Type != Error || k.toast.Message != "Remove vim: permission denied" {
+		t.Errorf("toast = %+v, want the error after the action name", k.toast)
+	}
+	toast := k.toast
+	k.Update(ActionResultMsg{})
+	if k.toast != toast {
+		t.Error("an empty result replaced the toast")
+	}
+}
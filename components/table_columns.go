@@ -0,0 +1,240 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	tp "github.com/kubex-ecosystem/xtui/types"
+)
+
+// columnLayout is the display state of one column. The layout slice is kept in display order.
+type columnLayout struct {
+	Index    int  // index of the column in the source rows
+	Hidden   bool // column is not rendered nor exported
+	Width    int  // pinned content width, 0 to size automatically
+	MinWidth int  // lower bound of the automatic width, 0 for none
+	MaxWidth int  // upper bound of the automatic width, 0 for none
+}
+
+func newColumnLayout(columns int) []columnLayout {
+	layout := make([]columnLayout, columns)
+	for i := range layout {
+		layout[i].Index = i
+	}
+	return layout
+}
+
+// visibleColumns returns the source indexes of the visible columns, in display order.
+func (k *TableRenderer) visibleColumns() []int {
+	cols := make([]int, 0, len(k.layout))
+	for _, c := range k.layout {
+		if !c.Hidden {
+			cols = append(cols, c.Index)
+		}
+	}
+	return cols
+}
+
+// exportRow returns the cells of row for the visible columns, in display order, without truncation.
+func (k *TableRenderer) exportRow(row []string) []string { return projectRow(k.visibleColumns(), row) }
+
+// projectRow returns the cells of row for the source columns cols, in that order.
+func projectRow(cols []int, row []string) []string {
+	projected := make([]string, len(cols))
+	for i, col := range cols {
+		projected[i] = cellAt(row, col)
+	}
+	return projected
+}
+
+// exportHeaders returns the headers of the visible columns, in display order.
+func (k *TableRenderer) exportHeaders() []string { return k.exportRow(k.headers) }
+
+// exportColumnTypes returns the types of the visible columns, in display order.
+func (k *TableRenderer) exportColumnTypes() []tp.ColumnType {
+	cols := k.visibleColumns()
+	columnTypes := make([]tp.ColumnType, len(cols))
+	for i, col := range cols {
+		columnTypes[i] = k.columnType(col)
+	}
+	return columnTypes
+}
+
+// layoutPosition returns the position of source column col in the layout, or -1.
+func (k *TableRenderer) layoutPosition(col int) int {
+	for i, c := range k.layout {
+		if c.Index == col {
+			return i
+		}
+	}
+	return -1
+}
+
+// columnWidth returns the pinned width of source column col, or 0.
+func (k *TableRenderer) columnWidth(col int) int {
+	if i := k.layoutPosition(col); i >= 0 {
+		return k.layout[i].Width
+	}
+	return 0
+}
+
+// SetColumnVisible shows or hides source column col. The last visible column cannot be hidden.
+func (k *TableRenderer) SetColumnVisible(col int, visible bool) {
+	i := k.layoutPosition(col)
+	if i < 0 || (!visible && len(k.visibleColumns()) <= 1 && !k.layout[i].Hidden) {
+		return
+	}
+	k.layout[i].Hidden = !visible
+	k.refreshTable()
+}
+
+// IsColumnVisible reports whether source column col is rendered.
+func (k *TableRenderer) IsColumnVisible(col int) bool {
+	i := k.layoutPosition(col)
+	return i >= 0 && !k.layout[i].Hidden
+}
+
+// SetColumnOrder sets the display order from a list of source column indexes. Columns missing from
+// order keep their relative order after the listed ones.
+func (k *TableRenderer) SetColumnOrder(order ...int) {
+	reordered := make([]columnLayout, 0, len(k.layout))
+	used := make(map[int]bool, len(order))
+	for _, col := range order {
+		if i := k.layoutPosition(col); i >= 0 && !used[col] {
+			reordered = append(reordered, k.layout[i])
+			used[col] = true
+		}
+	}
+	for _, c := range k.layout {
+		if !used[c.Index] {
+			reordered = append(reordered, c)
+		}
+	}
+	k.layout = reordered
+	k.refreshTable()
+}
+
+// GetColumnOrder returns the source column indexes in display order, hidden columns included.
+func (k *TableRenderer) GetColumnOrder() []int {
+	order := make([]int, len(k.layout))
+	for i, c := range k.layout {
+		order[i] = c.Index
+	}
+	return order
+}
+
+// MoveColumn moves source column col by delta positions in the display order.
+func (k *TableRenderer) MoveColumn(col, delta int) {
+	i := k.layoutPosition(col)
+	j := i + delta
+	if i < 0 || j < 0 || j >= len(k.layout) {
+		return
+	}
+	moved := k.layout[i]
+	k.layout = append(k.layout[:i], k.layout[i+1:]...)
+	k.layout = append(k.layout[:j], append([]columnLayout{moved}, k.layout[j:]...)...)
+	k.refreshTable()
+}
+
+// SetColumnWidth pins the content width of source column col; 0 sizes it automatically.
+func (k *TableRenderer) SetColumnWidth(col, width int) {
+	if i := k.layoutPosition(col); i >= 0 && width >= 0 {
+		k.layout[i].Width = width
+		k.refreshTable()
+	}
+}
+
+// ToggleColumnVisibility opens or closes the column picker.
+func (k *TableRenderer) ToggleColumnVisibility() {
+	k.pickingCols = !k.pickingCols
+	k.pickerCursor = 0
+}
+
+// updateColumnPicker handles keys while the column picker is open: up/down move the cursor, space
+// shows/hides the column, shift+up/shift+down (or K/J) reorder it, [ and ] shrink/grow its pinned
+// width, 0 unpins it, a shows every column and enter/esc close the picker.
+func (k *TableRenderer) updateColumnPicker(message tea.KeyMsg) {
+	if len(k.layout) == 0 {
+		k.pickingCols = false
+		return
+	}
+	current := k.layout[k.pickerCursor]
+	switch message.String() {
+	case "up", "k":
+		k.pickerCursor = (k.pickerCursor - 1 + len(k.layout)) % len(k.layout)
+	case "down", "j":
+		k.pickerCursor = (k.pickerCursor + 1) % len(k.layout)
+	case " ", "space", "x":
+		k.SetColumnVisible(current.Index, current.Hidden)
+	case "shift+up", "K":
+		if k.pickerCursor > 0 {
+			k.MoveColumn(current.Index, -1)
+			k.pickerCursor--
+		}
+	case "shift+down", "J":
+		if k.pickerCursor < len(k.layout)-1 {
+			k.MoveColumn(current.Index, 1)
+			k.pickerCursor++
+		}
+	case "[":
+		width := current.Width
+		if width == 0 {
+			width = k.naturalWidth(current.Index)
+		}
+		if width > 1 {
+			k.SetColumnWidth(current.Index, width-1)
+		}
+	case "]":
+		width := current.Width
+		if width == 0 {
+			width = k.naturalWidth(current.Index)
+		}
+		k.SetColumnWidth(current.Index, width+1)
+	case "0":
+		k.SetColumnWidth(current.Index, 0)
+	case "a":
+		for i := range k.layout {
+			k.layout[i].Hidden = false
+		}
+	case "enter", "esc", "q", "ctrl+k":
+		k.pickingCols = false
+	}
+}
+
+// naturalWidth returns the widest cell of source column col on the current page, header included.
+func (k *TableRenderer) naturalWidth(col int) int {
+	width := lipgloss.Width(cellAt(k.headers, col))
+	for _, row := range k.pageRows {
+		width = max(width, lipgloss.Width(cellAt(row, col)))
+	}
+	return width
+}
+
+// columnPickerView renders the column picker modal.
+func (k *TableRenderer) columnPickerView() string {
+	var b strings.Builder
+	b.WriteString(k.styles.header.Render("Columns") + "\n\n")
+	for i, c := range k.layout {
+		check := "[x]"
+		if c.Hidden {
+			check = "[ ]"
+		}
+		width := "auto"
+		if c.Width > 0 {
+			width = fmt.Sprintf("%d", c.Width)
+		} else if c.MinWidth > 0 || c.MaxWidth > 0 {
+			width = fmt.Sprintf("auto (%d-%d)", c.MinWidth, c.MaxWidth)
+		}
+		line := fmt.Sprintf("%s %-24s %-8s width: %s", check, cellAt(k.headers, c.Index), k.columnType(c.Index), width)
+		if i == k.pickerCursor {
+			line = k.styles.selected.Render(line)
+		} else {
+			line = k.styles.base.Render(line)
+		}
+		b.WriteString(line + "\n")
+	}
+	b.WriteString("\n" + blurredStyle.Render("space show/hide, shift+up/down (K/J) move, [ ] width, 0 auto width, a show all, enter close"))
+	return lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(lipgloss.Color("238")).Padding(0, 1).Render(b.String())
+}
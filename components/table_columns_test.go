@@ -0,0 +1,86 @@
+package components
+
+import (
+	"reflect"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestColumnLayout(t *testing.T) {
+	k := newSortTable()
+	steps := []struct {
+		name    string
+		do      func()
+		order   []int
+		visible []int
+	}{
+		{"initial", func() {}, []int{0, 1, 2}, []int{0, 1, 2}},
+		{"reordered", func() { k.SetColumnOrder(2, 2, 5, 0) }, []int{2, 0, 1}, []int{2, 0, 1}},
+		{"moved right", func() { k.MoveColumn(2, 2) }, []int{0, 1, 2}, []int{0, 1, 2}},
+		{"moved past the end", func() { k.MoveColumn(2, 1) }, []int{0, 1, 2}, []int{0, 1, 2}},
+		{"hidden", func() { k.SetColumnVisible(1, false) }, []int{0, 1, 2}, []int{0, 2}},
+		{"hidden again", func() { k.SetColumnVisible(0, false) }, []int{0, 1, 2}, []int{2}},
+		{"last visible column kept", func() { k.SetColumnVisible(2, false) }, []int{0, 1, 2}, []int{2}},
+		{"shown", func() { k.SetColumnVisible(1, true) }, []int{0, 1, 2}, []int{1, 2}},
+	}
+	for _, step := range steps {
+		step.do()
+		if got := k.GetColumnOrder(); !reflect.DeepEqual(got, step.order) {
+			t.Errorf("%s: GetColumnOrder() = %v, want %v", step.name, got, step.order)
+		}
+		if got := k.visibleColumns(); !reflect.DeepEqual(got, step.visible) {
+			t.Errorf("%s: visibleColumns() = %v, want %v", step.name, got, step.visible)
+		}
+	}
+}
+
+func TestColumnLayoutExport(t *testing.T) {
+	k := newSortTable()
+	k.SetColumnOrder(2, 0, 1)
+	k.SetColumnVisible(1, false)
+	if got, want := k.exportHeaders(), []string{"Arch", "Name"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("exportHeaders() = %q, want %q", got, want)
+	}
+	if got, want := k.exportRow([]string{"vim", "30"}), []string{"", "vim"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("exportRow() of a short row = %q, want %q", got, want)
+	}
+}
+
+func TestColumnPicker(t *testing.T) {
+	k := newSortTable()
+	k.refreshTable()
+	press := func(keys ...string) {
+		for _, key := range keys {
+			msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+			if key == " " {
+				msg = tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(key)}
+			}
+			k.updateColumnPicker(msg)
+		}
+	}
+	k.ToggleColumnVisibility()
+	press("j", "J", " ")
+	if got, want := k.GetColumnOrder(), []int{0, 2, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GetColumnOrder() = %v, want %v", got, want)
+	}
+	if k.IsColumnVisible(1) || k.pickerCursor != 2 {
+		t.Errorf("Size visible %v, cursor %d, want hidden under the cursor at 2", k.IsColumnVisible(1), k.pickerCursor)
+	}
+
+	press("k", "k", "]", "]", "[")
+	if got, want := k.columnWidth(0), k.naturalWidth(0)+1; got != want {
+		t.Errorf("columnWidth(0) = %d, want %d", got, want)
+	}
+	press("0", "a")
+	if got := k.columnWidth(0); got != 0 {
+		t.Errorf("columnWidth(0) after 0 = %d, want 0", got)
+	}
+	if got, want := k.visibleColumns(), []int{0, 2, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("visibleColumns() after a = %v, want %v", got, want)
+	}
+	press("q")
+	if k.pickingCols {
+		t.Error("q did not close the column picker")
+	}
+}
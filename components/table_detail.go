@@ -0,0 +1,229 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	tp "github.com/kubex-ecosystem/xtui/types"
+)
+
+// DetailFormat is how the detail pane shows the row under the cursor.
+type DetailFormat string
+
+const (
+	DetailFields DetailFormat = "fields"
+	DetailJSON   DetailFormat = "json"
+	DetailYAML   DetailFormat = "yaml"
+)
+
+func (f DetailFormat) Description() string {
+	switch f {
+	case DetailJSON:
+		return "JSON object"
+	case DetailYAML:
+		return "YAML mapping"
+	default:
+		return "One field per line"
+	}
+}
+func (f DetailFormat) String() string { return string(f) }
+
+// detailFormats is the order in which D cycles the formats.
+var detailFormats = []DetailFormat{DetailFields, DetailJSON, DetailYAML}
+
+// DetailRenderer renders the detail pane for a row in the fields format. It receives the full source
+// row and the headers, and width is the space available inside the pane.
+type DetailRenderer func(row []string, headers []string, width int) string
+
+// Detail pane layout: side by side with the table from detailSplitWidth columns, below it otherwise.
+const (
+	detailSplitWidth    = 100
+	detailMinWidth      = 30
+	detailDefaultWidth  = 48
+	detailStackedHeight = 12
+)
+
+// ToggleDetailPane shows or hides the detail pane.
+func (k *TableRenderer) ToggleDetailPane() { k.SetDetailPaneVisible(!k.showDetail) }
+
+// SetDetailPaneVisible shows or hides the detail pane. Showing it selects the first row of the page
+// when no row is selected.
+func (k *TableRenderer) SetDetailPaneVisible(visible bool) {
+	k.showDetail = visible
+	k.detailFocus = false
+	if visible && k.selectedRow < 0 && k.rowCount() > 0 {
+		k.selectedRow = k.page * k.pageSize
+	}
+	k.applyWidth()
+	k.resizePage()
+}
+
+// IsDetailPaneVisible reports whether the detail pane is shown.
+func (k *TableRenderer) IsDetailPaneVisible() bool { return k.showDetail }
+
+// SetDetailFormat sets how the detail pane shows the row.
+func (k *TableRenderer) SetDetailFormat(format DetailFormat) {
+	k.detailFormat = format
+	k.detailKey = ""
+}
+
+// GetDetailFormat returns the format of the detail pane.
+func (k *TableRenderer) GetDetailFormat() DetailFormat {
+	if k.detailFormat == "" {
+		return DetailFields
+	}
+	return k.detailFormat
+}
+
+// SetDetailRenderer replaces the fields view of the detail pane for this table; nil restores the
+// default "header: value" list. The JSON and YAML formats are not affected.
+func (k *TableRenderer) SetDetailRenderer(renderer DetailRenderer) {
+	k.detailRenderer = renderer
+	k.detailKey = ""
+}
+
+// cycleDetailFormat switches the detail pane to the next format.
+func (k *TableRenderer) cycleDetailFormat() {
+	current := k.GetDetailFormat()
+	for i, format := range detailFormats {
+		if format == current {
+			k.SetDetailFormat(detailFormats[(i+1)%len(detailFormats)])
+			return
+		}
+	}
+	k.SetDetailFormat(DetailFields)
+}
+
+// detailSplit reports whether the pane is shown beside the table rather than below it.
+func (k *TableRenderer) detailSplit() bool { return k.width == 0 || k.width >= detailSplitWidth }
+
+// detailWidth returns the outer width of the detail pane.
+func (k *TableRenderer) detailWidth() int {
+	switch {
+	case k.width == 0:
+		return detailDefaultWidth
+	case k.detailSplit():
+		return max(k.width*2/5, detailMinWidth)
+	default:
+		return k.width
+	}
+}
+
+// applyWidth sets the room left to the table by the window, minus the detail pane when it is beside
+// it. Columns that do not fit scroll horizontally (see scrollWindow).
+func (k *TableRenderer) applyWidth() {
+	if k.width == 0 {
+		return
+	}
+	width := k.width
+	if k.showDetail && k.detailSplit() {
+		width -= k.detailWidth() + 1
+	}
+	k.tableWidth = width
+	k.refreshTable()
+}
+
+// updateDetailPane handles the keys sent to the focused detail pane: scrolling, D to change the
+// format, and tab/esc to give the focus back to the table.
+func (k *TableRenderer) updateDetailPane(message tea.KeyMsg) tea.Cmd {
+	switch message.String() {
+	case "tab", "esc":
+		k.detailFocus = false
+		return nil
+	case "d":
+		k.SetDetailPaneVisible(false)
+		return nil
+	case "D":
+		k.cycleDetailFormat()
+		return nil
+	case "q", "ctrl+c":
+		return tea.Quit
+	case "home", "g":
+		k.detail.GotoTop()
+		return nil
+	case "end", "G":
+		k.detail.GotoBottom()
+		return nil
+	}
+	var cmd tea.Cmd
+	k.detail, cmd = k.detail.Update(message)
+	return cmd
+}
+
+// detailContent renders the row under the cursor in the current format, wrapped to width.
+func (k *TableRenderer) detailContent(row []string, width int) string {
+	if row == nil {
+		return blurredStyle.Render("No row selected: use up/down to pick one.")
+	}
+	switch k.GetDetailFormat() {
+	case DetailJSON:
+		return lipgloss.NewStyle().Width(width).Render(tp.RecordJSON(k.headers, row))
+	case DetailYAML:
+		return lipgloss.NewStyle().Width(width).Render(tp.RecordYAML(k.headers, row))
+	}
+	if k.detailRenderer != nil {
+		return k.detailRenderer(append([]string(nil), row...), append([]string(nil), k.headers...), width)
+	}
+	// Fields follow the display order, hidden columns included, with the labels in one column.
+	labelWidth := 0
+	for _, c := range k.layout {
+		labelWidth = max(labelWidth, ansi.StringWidth(cellAt(k.headers, c.Index)))
+	}
+	labelWidth = min(labelWidth+1, width/3)
+	valueWidth := max(width-labelWidth-1, 1)
+	label := k.styles.header.Padding(0).Width(labelWidth)
+	value := lipgloss.NewStyle().Width(valueWidth)
+	lines := make([]string, 0, len(k.layout))
+	for _, c := range k.layout {
+		header := ansi.Truncate(cellAt(k.headers, c.Index)+":", labelWidth, "…")
+		lines = append(lines, lipgloss.JoinHorizontal(lipgloss.Top, label.Render(header), " ", value.Render(cellAt(row, c.Index))))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// detailView renders the detail pane next to a table of tableHeight lines.
+func (k *TableRenderer) detailView(tableHeight int) string {
+	width := k.detailWidth()
+	height := detailStackedHeight
+	if k.detailSplit() {
+		height = max(tableHeight-2, 3)
+	}
+	innerWidth := max(width-4, 1) // border and padding
+	row := k.SelectedRow()
+	key := fmt.Sprint(k.selectedRow, "\x1f", k.GetDetailFormat(), "\x1f", k.keyOf(row))
+	k.detail.Width, k.detail.Height = innerWidth, max(height-1, 1)
+	k.detail.SetContent(k.detailContent(row, innerWidth))
+	if key != k.detailKey {
+		k.detail.GotoTop()
+		k.detailKey = key
+	}
+
+	title := fmt.Sprintf("Row %d/%d · %s", k.selectedRow+1, k.rowCount(), k.GetDetailFormat())
+	if row == nil {
+		title = "Details · " + k.GetDetailFormat().String()
+	}
+	if !k.detail.AtTop() || !k.detail.AtBottom() {
+		title += fmt.Sprintf(" · %d%%", int(k.detail.ScrollPercent()*100))
+	}
+	border := lipgloss.Color("238")
+	if k.detailFocus {
+		border = lipgloss.Color("#01BE85")
+	}
+	body := k.styles.header.Padding(0).Render(ansi.Truncate(title, innerWidth, "…")) + "\n" + k.detail.View()
+	return lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(border).Padding(0, 1).Width(width - 2).Render(body)
+}
+
+// withDetailPane lays the detail pane out beside or below the rendered table.
+func (k *TableRenderer) withDetailPane(table string) string {
+	if !k.showDetail {
+		return table
+	}
+	pane := k.detailView(lipgloss.Height(table))
+	if k.detailSplit() {
+		return lipgloss.JoinHorizontal(lipgloss.Top, table, " ", pane)
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, table, pane)
+}
@@ -0,0 +1,93 @@
+package components
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestDetailPaneKeys(t *testing.T) {
+	k := newSortTable()
+	typeKeys(k, "d")
+	if !k.IsDetailPaneVisible() || k.selectedRow != 0 {
+		t.Fatalf("d: visible %v, cursor %d, want the pane on the first row", k.IsDetailPaneVisible(), k.selectedRow)
+	}
+	typeKeys(k, "D")
+	pressKey(k, tea.KeyTab)
+	if k.GetDetailFormat() != DetailJSON || !k.detailFocus {
+		t.Fatalf("format %s, focus %v, want JSON in the focused pane", k.GetDetailFormat(), k.detailFocus)
+	}
+	typeKeys(k, "D")
+	pressKey(k, tea.KeyDown) // scrolls the pane, not the table
+	if k.GetDetailFormat() != DetailYAML || k.selectedRow != 0 {
+		t.Errorf("format %s, cursor %d, want YAML with the cursor left alone", k.GetDetailFormat(), k.selectedRow)
+	}
+	pressKey(k, tea.KeyEsc)
+	pressKey(k, tea.KeyDown)
+	typeKeys(k, "D")
+	if k.detailFocus || k.selectedRow != 1 || k.GetDetailFormat() != DetailFields {
+		t.Errorf("after esc: focus %v, cursor %d, format %s", k.detailFocus, k.selectedRow, k.GetDetailFormat())
+	}
+	pressKey(k, tea.KeyTab)
+	typeKeys(k, "d")
+	if k.IsDetailPaneVisible() || k.detailFocus {
+		t.Error("d in the focused pane did not hide it")
+	}
+}
+
+func TestDetailContent(t *testing.T) {
+	k := newSortTable()
+	k.SetColumnOrder(2, 0, 1)
+	k.SetColumnVisible(1, false)
+	row := k.rows[0]
+	var lines []string
+	for _, line := range strings.Split(ansi.Strip(k.detailContent(row, 40)), "\n") {
+		lines = append(lines, strings.Join(strings.Fields(line), " "))
+	}
+	if want := []string{"Arch: amd64", "Name: vim", "Size: 30"}; !reflect.DeepEqual(lines, want) {
+		t.Errorf("fields = %q, want %q: display order, hidden columns included", lines, want)
+	}
+
+	k.SetDetailFormat(DetailJSON)
+	if got := ansi.Strip(k.detailContent(row, 80)); !strings.Contains(got, `"Name": "vim"`) || !strings.Contains(got, `"Size": "30"`) {
+		t.Errorf("JSON detail = %q", got)
+	}
+	if got := ansi.Strip(k.detailContent(nil, 80)); !strings.Contains(got, "No row selected") {
+		t.Errorf("detail without a row = %q", got)
+	}
+
+	k.SetDetailFormat(DetailFields)
+	k.SetDetailRenderer(func(row, headers []string, width int) string {
+		row[0], headers[0] = "changed", "changed"
+		return "custom"
+	})
+	if got := k.detailContent(row, 40); got != "custom" || k.rows[0][0] != "vim" || k.headers[0] != "Name" {
+		t.Errorf("custom renderer: %q, row %q, headers %q: it must receive copies", got, k.rows[0], k.headers)
+	}
+}
+
+func TestDetailPaneLayout(t *testing.T) {
+	tests := []struct {
+		width      int
+		split      bool
+		tableWidth int
+	}{
+		{120, true, 120 - 48 - 1},
+		{200, true, 200 - 80 - 1},
+		{80, false, 80},
+	}
+	for _, tt := range tests {
+		k := newSortTable()
+		k.Update(tea.WindowSizeMsg{Width: tt.width, Height: 40})
+		k.SetDetailPaneVisible(true)
+		if k.detailSplit() != tt.split || k.tableWidth != tt.tableWidth {
+			t.Errorf("width %d: split %v, table width %d, want %v, %d", tt.width, k.detailSplit(), k.tableWidth, tt.split, tt.tableWidth)
+		}
+		if view := k.View(); !strings.Contains(view, "Row 1/5") {
+			t.Errorf("width %d: the view does not show the detail pane", tt.width)
+		}
+	}
+}
@@ -0,0 +1,514 @@
+package components
+
+import (
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"slices"
+	"strconv"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	gl "github.com/kubex-ecosystem/logz"
+	tp "github.com/kubex-ecosystem/xtui/types"
+)
+
+// ColumnEditor configures how a column is edited. Values must pass every rule (see
+// types.ValidationRule), parse as the column type when not empty, and then pass Check, which also
+// receives the row being edited.
+type ColumnEditor struct {
+	ReadOnly bool
+	Rules    []tp.ValidationRule
+	Check    func(value string, row []string) error
+}
+
+// editKind is the kind of change recorded for undo.
+type editKind int
+
+const (
+	editCell editKind = iota
+	editAdd
+	editDelete
+)
+
+// editStep is one undoable change. row is the edited row itself; source and view are the positions
+// of added and deleted rows, and added tells whether a deleted row had been added.
+type editStep struct {
+	kind   editKind
+	row    []string
+	col    int
+	value  string
+	source int
+	view   int
+	added  bool
+}
+
+// tableEdits tracks the changes made in edit mode. Rows are identified by the address of their first
+// cell: edits change cells in place, so the identity survives them.
+type tableEdits struct {
+	original     map[*string][]string // values of edited rows before their first change
+	changed      map[*string]bool     // edited rows, not deleted, that differ from their original
+	added        map[*string]bool
+	deleted      map[*string][]string // original values of deleted rows
+	deletedOrder []*string
+	undo         []editStep
+}
+
+// rowID returns the identity of row for edit tracking.
+func rowID(row []string) *string {
+	if len(row) == 0 {
+		return nil
+	}
+	return &row[0]
+}
+
+// SetEditable turns edit mode on or off. Editing works on a copy of the rows, so the data behind the
+// table is never changed; read the changes with Diff. Lazy sources cannot be edited. Live updates
+// received in edit mode are applied when it is turned off.
+func (k *TableRenderer) SetEditable(editable bool) {
+	if editable && k.lazy {
+		gl.Log("warn", "Table source is read-only: lazy sources cannot be edited")
+		return
+	}
+	k.editable = editable
+	k.editing = false
+	k.edits = tableEdits{}
+	if editable {
+		k.warnUngrouped()
+		width := max(len(k.headers), 1)
+		rows := make([][]string, len(k.rows))
+		for i, row := range k.rows {
+			rows[i] = make([]string, width)
+			copy(rows[i], row)
+		}
+		k.rows = rows
+		k.ApplyFilter()
+		k.refreshTable()
+	} else if pending := k.livePending; len(pending) > 0 {
+		k.livePending = nil
+		_ = k.ApplyUpdates(pending...)
+	}
+}
+
+// IsEditable reports whether edit mode is on.
+func (k *TableRenderer) IsEditable() bool { return k.editable }
+
+// SetColumnEditor sets how column col is edited.
+func (k *TableRenderer) SetColumnEditor(col int, editor ColumnEditor) {
+	if k.columnEditors == nil {
+		k.columnEditors = make(map[int]ColumnEditor)
+	}
+	k.columnEditors[col] = editor
+}
+
+// ValidateCell checks value for column col of row against the column editor and the column type.
+func (k *TableRenderer) ValidateCell(col int, value string, row []string) error {
+	editor := k.columnEditors[col]
+	for _, rule := range editor.Rules {
+		if err := rule.Validate(value, func(any) error { return checkCellRule(rule, value) }); err != nil {
+			return fmt.Errorf("%s: %w", cellAt(k.headers, col), err)
+		}
+	}
+	if ct := k.columnType(col); value != "" && ct != tp.ColumnString {
+		if _, ok := ct.Parse(value); !ok {
+			return fmt.Errorf("%s: %q is not a valid %s", cellAt(k.headers, col), value, ct)
+		}
+	}
+	if editor.Check != nil {
+		if err := editor.Check(value, row); err != nil {
+			return fmt.Errorf("%s: %w", cellAt(k.headers, col), err)
+		}
+	}
+	return nil
+}
+
+// SetCell validates value and stores it in column col of the row at index i of the current view.
+func (k *TableRenderer) SetCell(i, col int, value string) error {
+	if !k.editable {
+		return fmt.Errorf("table is not editable")
+	}
+	row := k.rowAt(i)
+	if row == nil || col < 0 || col >= len(row) {
+		return fmt.Errorf("no cell at row %d, column %d", i, col)
+	}
+	if k.columnEditors[col].ReadOnly {
+		return fmt.Errorf("%s is read-only", cellAt(k.headers, col))
+	}
+	if err := k.ValidateCell(col, value, row); err != nil {
+		return err
+	}
+	k.setCell(row, col, value)
+	return nil
+}
+
+// checkCellRule checks the rules ValidationRule.Validate leaves to its custom check: email, url, ip
+// and port. Empty values pass them, and rules taking a parameter (min, max, min_len, max_len, regexp,
+// pattern) are not checked.
+func checkCellRule(rule tp.ValidationRule, value string) error {
+	if value == "" {
+		return nil
+	}
+	switch rule {
+	case tp.Email:
+		if _, err := mail.ParseAddress(value); err != nil {
+			return tp.ErrInvalidEmail
+		}
+	case tp.URL:
+		if u, err := url.ParseRequestURI(value); err != nil || u.Scheme == "" {
+			return tp.ErrInvalidURL
+		}
+	case tp.IP:
+		if net.ParseIP(value) == nil {
+			return tp.ErrInvalidIP
+		}
+	case tp.Port:
+		if port, err := strconv.Atoi(value); err != nil || port < 1 || port > 65535 {
+			return tp.ErrInvalidPort
+		}
+	}
+	return nil
+}
+
+// setCell stores value in row, recording the change.
+func (k *TableRenderer) setCell(row []string, col int, value string) {
+	if row[col] == value {
+		return
+	}
+	id := rowID(row)
+	if k.edits.original == nil {
+		k.edits.original = make(map[*string][]string)
+	}
+	if _, ok := k.edits.original[id]; !ok && !k.edits.added[id] {
+		k.edits.original[id] = slices.Clone(row)
+	}
+	k.edits.undo = append(k.edits.undo, editStep{kind: editCell, row: row, col: col, value: row[col]})
+	row[col] = value
+	k.markChanged(row)
+}
+
+// markChanged records whether the edited row differs from its original values, keeping the counts of
+// the status line up to date without comparing every row on each render.
+func (k *TableRenderer) markChanged(row []string) {
+	id := rowID(row)
+	original, ok := k.edits.original[id]
+	if !ok || slices.Equal(original, row) {
+		delete(k.edits.changed, id)
+		return
+	}
+	if k.edits.changed == nil {
+		k.edits.changed = make(map[*string]bool)
+	}
+	k.edits.changed[id] = true
+}
+
+// AddRow inserts a row with values below the row under the cursor (or at the end) and moves the
+// cursor to it. It returns the view index of the new row.
+func (k *TableRenderer) AddRow(values ...string) (int, error) {
+	if !k.editable {
+		return -1, fmt.Errorf("table is not editable")
+	}
+	row := make([]string, max(len(k.headers), 1))
+	copy(row, values)
+	view := len(k.filteredRows)
+	source := len(k.rows)
+	if current := k.SelectedRow(); current != nil {
+		view = k.selectedRow + 1
+		source = k.sourceIndex(current) + 1
+	}
+	k.rows = slices.Insert(k.rows, source, row)
+	k.filteredRows = slices.Insert(k.filteredRows, view, row)
+	if k.edits.added == nil {
+		k.edits.added = make(map[*string]bool)
+	}
+	k.edits.added[rowID(row)] = true
+	k.edits.undo = append(k.edits.undo, editStep{kind: editAdd, row: row, source: source, view: view})
+	k.selectedRow = view
+	k.page = view / k.pageSize
+	k.refreshTable()
+	return view, nil
+}
+
+// DeleteRow removes the row at index i of the current view.
+func (k *TableRenderer) DeleteRow(i int) error {
+	if !k.editable {
+		return fmt.Errorf("table is not editable")
+	}
+	row := k.rowAt(i)
+	if row == nil {
+		return fmt.Errorf("no row at %d", i)
+	}
+	source := k.sourceIndex(row)
+	k.rows = slices.Delete(k.rows, source, source+1)
+	k.filteredRows = slices.Delete(k.filteredRows, i, i+1)
+	id := rowID(row)
+	added := k.edits.added[id]
+	if added {
+		delete(k.edits.added, id)
+	} else {
+		if k.edits.deleted == nil {
+			k.edits.deleted = make(map[*string][]string)
+		}
+		original, ok := k.edits.original[id]
+		if !ok {
+			original = slices.Clone(row)
+		}
+		k.edits.deleted[id] = original
+		k.edits.deletedOrder = append(k.edits.deletedOrder, id)
+		delete(k.edits.changed, id)
+	}
+	k.edits.undo = append(k.edits.undo, editStep{kind: editDelete, row: row, source: source, view: i, added: added})
+	k.clampPage()
+	k.refreshTable()
+	return nil
+}
+
+// Undo reverts the last edit, addition or deletion. It returns false when there is nothing to undo.
+func (k *TableRenderer) Undo() bool {
+	if len(k.edits.undo) == 0 {
+		return false
+	}
+	step := k.edits.undo[len(k.edits.undo)-1]
+	k.edits.undo = k.edits.undo[:len(k.edits.undo)-1]
+	id := rowID(step.row)
+	switch step.kind {
+	case editCell:
+		step.row[step.col] = step.value
+		if original, ok := k.edits.original[id]; ok && slices.Equal(original, step.row) {
+			delete(k.edits.original, id)
+		}
+		k.markChanged(step.row)
+		k.moveCursorTo(step.row)
+	case editAdd:
+		if i := k.sourceIndex(step.row); i >= 0 {
+			k.rows = slices.Delete(k.rows, i, i+1)
+		}
+		if i := slices.IndexFunc(k.filteredRows, func(r []string) bool { return rowID(r) == id }); i >= 0 {
+			k.filteredRows = slices.Delete(k.filteredRows, i, i+1)
+		}
+		delete(k.edits.added, id)
+		k.clampPage()
+	case editDelete:
+		k.rows = slices.Insert(k.rows, min(step.source, len(k.rows)), step.row)
+		k.filteredRows = slices.Insert(k.filteredRows, min(step.view, len(k.filteredRows)), step.row)
+		if step.added {
+			k.edits.added[id] = true
+		}
+		if _, ok := k.edits.deleted[id]; ok {
+			delete(k.edits.deleted, id)
+			k.edits.deletedOrder = slices.DeleteFunc(k.edits.deletedOrder, func(d *string) bool { return d == id })
+			k.markChanged(step.row)
+		}
+		k.moveCursorTo(step.row)
+	}
+	k.refreshTable()
+	return true
+}
+
+// moveCursorTo puts the cursor on row when it is in the current view.
+func (k *TableRenderer) moveCursorTo(row []string) {
+	id := rowID(row)
+	if i := slices.IndexFunc(k.filteredRows, func(r []string) bool { return rowID(r) == id }); i >= 0 {
+		k.selectedRow = i
+		k.page = i / k.pageSize
+	}
+}
+
+// sourceIndex returns the position of row in the unfiltered rows, or -1.
+func (k *TableRenderer) sourceIndex(row []string) int {
+	id := rowID(row)
+	return slices.IndexFunc(k.rows, func(r []string) bool { return rowID(r) == id })
+}
+
+// DiscardEdits reverts every change made since edit mode was turned on.
+func (k *TableRenderer) DiscardEdits() {
+	k.editing = false
+	for k.Undo() {
+	}
+}
+
+// IsDirty reports whether the table has changes since edit mode was turned on.
+func (k *TableRenderer) IsDirty() bool {
+	return len(k.edits.changed)+len(k.edits.added)+len(k.edits.deletedOrder) > 0
+}
+
+// Diff returns the rows changed, added and deleted since edit mode was turned on.
+func (k *TableRenderer) Diff() tp.TableDiff {
+	diff := tp.TableDiff{Headers: append([]string(nil), k.headers...)}
+	for _, row := range k.rows {
+		id := rowID(row)
+		if k.edits.added[id] {
+			diff.Added = append(diff.Added, slices.Clone(row))
+			continue
+		}
+		original, ok := k.edits.original[id]
+		if !ok {
+			continue
+		}
+		change := tp.TableRowChange{Before: slices.Clone(original), After: slices.Clone(row)}
+		for col := range row {
+			if cellAt(original, col) != row[col] {
+				change.Columns = append(change.Columns, col)
+			}
+		}
+		if len(change.Columns) > 0 {
+			diff.Changed = append(diff.Changed, change)
+		}
+	}
+	for _, id := range k.edits.deletedOrder {
+		diff.Deleted = append(diff.Deleted, slices.Clone(k.edits.deleted[id]))
+	}
+	return diff
+}
+
+// diffSummary describes the pending changes for the status line. It is called on every render, so it
+// counts the tracked changes rather than building the Diff.
+func (k *TableRenderer) diffSummary() string {
+	if !k.IsDirty() {
+		return ""
+	}
+	return fmt.Sprintf("Edited: %d changed, %d added, %d deleted", len(k.edits.changed), len(k.edits.added), len(k.edits.deletedOrder))
+}
+
+// editableColumns returns the visible columns that are not read-only, in display order.
+func (k *TableRenderer) editableColumns() []int {
+	var cols []int
+	for _, col := range k.visibleColumns() {
+		if !k.columnEditors[col].ReadOnly {
+			cols = append(cols, col)
+		}
+	}
+	return cols
+}
+
+// StartEdit opens the cell editor on column col of the row under the cursor (the first editable
+// column when col is not editable).
+func (k *TableRenderer) StartEdit(col int) tea.Cmd {
+	if !k.editable {
+		return nil
+	}
+	cols := k.editableColumns()
+	if len(cols) == 0 {
+		return k.notify(Warning, "No editable columns")
+	}
+	if k.selectedRow < 0 && k.rowCount() > 0 {
+		k.selectedRow = k.page * k.pageSize
+	}
+	row := k.SelectedRow()
+	if row == nil {
+		return k.notify(Warning, "No row selected")
+	}
+	if !slices.Contains(cols, col) {
+		col = cols[0]
+	}
+	input := textinput.New()
+	input.Prompt = ""
+	input.Cursor.Style = cursorStyle
+	input.SetValue(cellAt(row, col))
+	input.CursorEnd()
+	if width := k.columnWidth(col); width > 0 {
+		input.Width = width
+	}
+	k.editInput = input
+	k.editRow = row
+	k.editCol = col
+	k.editErr = nil
+	k.editing = true
+	return k.editInput.Focus()
+}
+
+// commitEdit validates the edited value and stores it. It returns false, keeping the editor open,
+// when the value is invalid.
+func (k *TableRenderer) commitEdit() bool {
+	value := k.editInput.Value()
+	if err := k.ValidateCell(k.editCol, value, k.editRow); err != nil {
+		k.editErr = err
+		return false
+	}
+	k.setCell(k.editRow, k.editCol, value)
+	k.editing = false
+	k.editErr = nil
+	return true
+}
+
+// updateEditMode handles the keys of the cell editor: enter saves, esc cancels, tab/shift+tab save
+// and move to the next/previous column, up/down save and move to the same column of the next row.
+func (k *TableRenderer) updateEditMode(msg tea.Msg) tea.Cmd {
+	message, ok := msg.(tea.KeyMsg)
+	if !ok {
+		var cmd tea.Cmd
+		k.editInput, cmd = k.editInput.Update(msg)
+		return cmd
+	}
+	switch message.String() {
+	case "esc":
+		k.editing = false
+		k.editErr = nil
+		return nil
+	case "enter":
+		k.commitEdit()
+		return nil
+	case "tab", "shift+tab":
+		if !k.commitEdit() {
+			return nil
+		}
+		cols := k.editableColumns()
+		step := 1
+		if message.String() == "shift+tab" {
+			step = -1
+		}
+		next := (slices.Index(cols, k.editCol) + step + len(cols)) % len(cols)
+		return k.StartEdit(cols[next])
+	case "up", "down":
+		if !k.commitEdit() {
+			return nil
+		}
+		_ = k.RowsNavigate(message.String())
+		return k.StartEdit(k.editCol)
+	}
+	var cmd tea.Cmd
+	k.editInput, cmd = k.editInput.Update(message)
+	k.editErr = nil
+	return cmd
+}
+
+// addRowAndEdit adds a row below the cursor and opens the editor on it.
+func (k *TableRenderer) addRowAndEdit() tea.Cmd {
+	if _, err := k.AddRow(); err != nil {
+		return k.notify(Error, err.Error())
+	}
+	return k.StartEdit(-1)
+}
+
+// deleteCurrentRow deletes the row under the cursor.
+func (k *TableRenderer) deleteCurrentRow() tea.Cmd {
+	if err := k.DeleteRow(k.selectedRow); err != nil {
+		return k.notify(Warning, "No row selected")
+	}
+	return nil
+}
+
+// undoEdit reverts the last change, or tells there is none.
+func (k *TableRenderer) undoEdit() tea.Cmd {
+	if !k.Undo() {
+		return k.notify(Info, "Nothing to undo")
+	}
+	return nil
+}
+
+// editedCellStyle returns style adjusted for the edit state of column col of record: added rows and
+// changed cells are coloured.
+func (k *TableRenderer) editedCellStyle(style lipgloss.Style, record []string, col int) lipgloss.Style {
+	if !k.editable || record == nil {
+		return style
+	}
+	id := rowID(record)
+	if k.edits.added[id] {
+		return style.Foreground(lipgloss.Color("#75FBAB"))
+	}
+	if original, ok := k.edits.original[id]; ok && cellAt(original, col) != cellAt(record, col) {
+		return style.Foreground(lipgloss.Color("#FDFF90")).Italic(true)
+	}
+	return style
+}
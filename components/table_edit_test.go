@@ -0,0 +1,165 @@
+package components
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	tp "github.com/kubex-ecosystem/xtui/types"
+)
+
+// newEditableTable returns an editable table of packages.
+func newEditableTable() *TableRenderer {
+	k := NewTableRenderer(tp.NewTableHandler([]string{"Name", "Version"}, [][]string{{"vim", "8.2"}, {"nano", "6.2"}, {"git", "2.34"}}), nil, nil)
+	k.SetColumnTypes(tp.ColumnString, tp.ColumnString)
+	k.SetEditable(true)
+	return k
+}
+
+// checkDiff compares the diff of k with want and the status line counts with the diff.
+func checkDiff(t *testing.T, k *TableRenderer, want tp.TableDiff) {
+	t.Helper()
+	want.Headers = []string{"Name", "Version"}
+	diff := k.Diff()
+	if !reflect.DeepEqual(diff, want) {
+		t.Errorf("Diff() = %+v, want %+v", diff, want)
+	}
+	if got := k.IsDirty(); got != !want.IsEmpty() {
+		t.Errorf("IsDirty() = %v with the diff %+v", got, diff)
+	}
+	wantSummary := ""
+	if !want.IsEmpty() {
+		wantSummary = fmt.Sprintf("Edited: %d changed, %d added, %d deleted", len(want.Changed), len(want.Added), len(want.Deleted))
+	}
+	if got := k.diffSummary(); got != wantSummary {
+		t.Errorf("diffSummary() = %q, want %q", got, wantSummary)
+	}
+}
+
+func TestEditDiff(t *testing.T) {
+	k := newEditableTable()
+	checkDiff(t, k, tp.TableDiff{})
+
+	if err := k.SetCell(0, 1, "9.1"); err != nil {
+		t.Fatalf("SetCell: %v", err)
+	}
+	vim := tp.TableRowChange{Before: []string{"vim", "8.2"}, After: []string{"vim", "9.1"}, Columns: []int{1}}
+	checkDiff(t, k, tp.TableDiff{Changed: []tp.TableRowChange{vim}})
+
+	k.selectedRow = 1
+	if _, err := k.AddRow("curl", "7.81"); err != nil {
+		t.Fatalf("AddRow: %v", err)
+	}
+	checkDiff(t, k, tp.TableDiff{Changed: []tp.TableRowChange{vim}, Added: [][]string{{"curl", "7.81"}}})
+
+	if err := k.DeleteRow(0); err != nil { // the edited vim row
+		t.Fatalf("DeleteRow: %v", err)
+	}
+	checkDiff(t, k, tp.TableDiff{Added: [][]string{{"curl", "7.81"}}, Deleted: [][]string{{"vim", "8.2"}}})
+
+	k.Undo() // the deletion
+	checkDiff(t, k, tp.TableDiff{Changed: []tp.TableRowChange{vim}, Added: [][]string{{"curl", "7.81"}}})
+	if got := k.GetRows(); !reflect.DeepEqual(got, [][]string{{"vim", "9.1"}, {"nano", "6.2"}, {"curl", "7.81"}, {"git", "2.34"}}) {
+		t.Errorf("rows after undoing the deletion = %q", got)
+	}
+
+	k.Undo() // the addition
+	checkDiff(t, k, tp.TableDiff{Changed: []tp.TableRowChange{vim}})
+
+	k.Undo() // the edit
+	checkDiff(t, k, tp.TableDiff{})
+	if k.Undo() {
+		t.Error("Undo() = true with nothing left to undo")
+	}
+}
+
+func TestEditDiffRevertedByHand(t *testing.T) {
+	k := newEditableTable()
+	_ = k.SetCell(1, 1, "7.0")
+	_ = k.SetCell(1, 1, "6.2")
+	checkDiff(t, k, tp.TableDiff{})
+
+	k.Undo()
+	nano := tp.TableRowChange{Before: []string{"nano", "6.2"}, After: []string{"nano", "7.0"}, Columns: []int{1}}
+	checkDiff(t, k, tp.TableDiff{Changed: []tp.TableRowChange{nano}})
+	k.Undo()
+	checkDiff(t, k, tp.TableDiff{})
+}
+
+func TestEditDiffOfAddedRows(t *testing.T) {
+	k := newEditableTable()
+	view, _ := k.AddRow()
+	_ = k.SetCell(view, 0, "curl")
+	checkDiff(t, k, tp.TableDiff{Added: [][]string{{"curl", ""}}})
+
+	_ = k.DeleteRow(view)
+	checkDiff(t, k, tp.TableDiff{})
+	k.Undo()
+	checkDiff(t, k, tp.TableDiff{Added: [][]string{{"curl", ""}}})
+
+	k.DiscardEdits()
+	checkDiff(t, k, tp.TableDiff{})
+	if got := k.GetRows(); len(got) != 3 {
+		t.Errorf("rows after discarding = %q", got)
+	}
+}
+
+func TestValidateCell(t *testing.T) {
+	k := NewTableRenderer(tp.NewTableHandler([]string{"Name", "Mail", "Site", "Host", "Port", "Size"}, nil), nil, nil)
+	k.SetColumnTypes(tp.ColumnString, tp.ColumnString, tp.ColumnString, tp.ColumnString, tp.ColumnString, tp.ColumnInt)
+	k.SetColumnEditor(0, ColumnEditor{
+		Rules: []tp.ValidationRule{tp.Required},
+		Check: func(value string, row []string) error {
+			if value == row[0] {
+				return errors.New("unchanged")
+			}
+			return nil
+		},
+	})
+	k.SetColumnEditor(1, ColumnEditor{Rules: []tp.ValidationRule{tp.Email}})
+	k.SetColumnEditor(2, ColumnEditor{Rules: []tp.ValidationRule{tp.URL}})
+	k.SetColumnEditor(3, ColumnEditor{Rules: []tp.ValidationRule{tp.IP}})
+	k.SetColumnEditor(4, ColumnEditor{Rules: []tp.ValidationRule{tp.Port, tp.MinLen}})
+	row := []string{"vim", "", "", "", "", ""}
+	tests := []struct {
+		col   int
+		value string
+		want  error
+	}{
+		{0, "", tp.ErrRequired},
+		{0, "vim", errors.New("Name: unchanged")},
+		{0, "neovim", nil},
+		{1, "dev@example.com", nil},
+		{1, "dev at example", tp.ErrInvalidEmail},
+		{1, "", nil},
+		{2, "https://example.com/vim", nil},
+		{2, "example.com", tp.ErrInvalidURL},
+		{3, "::1", nil},
+		{3, "10.0.0.256", tp.ErrInvalidIP},
+		{4, "8080", nil},
+		{4, "70000", tp.ErrInvalidPort},
+		{5, "12", nil},
+		{5, "12 KiB", errors.New(`Size: "12 KiB" is not a valid int`)},
+	}
+	for _, tt := range tests {
+		err := k.ValidateCell(tt.col, tt.value, row)
+		switch {
+		case tt.want == nil && err != nil:
+			t.Errorf("ValidateCell(%d, %q) = %v, want nil", tt.col, tt.value, err)
+		case tt.want != nil && err == nil:
+			t.Errorf("ValidateCell(%d, %q) = nil, want %v", tt.col, tt.value, tt.want)
+		case tt.want != nil && !errors.Is(err, tt.want) && err.Error() != tt.want.Error():
+			t.Errorf("ValidateCell(%d, %q) = %v, want %v", tt.col, tt.value, err, tt.want)
+		}
+	}
+}
+
+func TestSetCellReadOnly(t *testing.T) {
+	k := newEditableTable()
+	k.SetColumnEditor(0, ColumnEditor{ReadOnly: true})
+	if err := k.SetCell(0, 0, "neovim"); err == nil {
+		t.Error("SetCell on a read-only column succeeded")
+	}
+	checkDiff(t, k, tp.TableDiff{})
+}
@@ -0,0 +1,603 @@
+package components
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	tp "github.com/kubex-ecosystem/xtui/types"
+)
+
+// ExportScope selects the rows written by an export.
+type ExportScope string
+
+const (
+	ExportFiltered ExportScope = "filtered"
+	ExportAll      ExportScope = "all"
+	ExportPage     ExportScope = "page"
+	ExportSelected ExportScope = "selected"
+)
+
+func (s ExportScope) Description() string {
+	switch s {
+	case ExportAll:
+		return "All rows, ignoring the filter"
+	case ExportPage:
+		return "Rows of the current page"
+	case ExportSelected:
+		return "Selected rows"
+	default:
+		return "Rows matching the filter"
+	}
+}
+func (s ExportScope) String() string { return string(s) }
+
+// exportScopes is the order in which the export dialog offers the scopes.
+var exportScopes = []ExportScope{ExportFiltered, ExportAll, ExportPage, ExportSelected}
+
+// toastDuration is how long an export notification stays in the status line.
+const toastDuration = 4 * time.Second
+
+// Export writes the rows of scope to filename and returns the number of rows written. format is the
+// name, title, extension or MIME type of a registered exporter (see types.RegisterExporter); when
+// empty it is taken from the file extension. Existing files are overwritten.
+func (k *TableRenderer) Export(filename, format string, scope ExportScope) (int, error) {
+	exporter, err := tp.ResolveExporter(filename, format)
+	if err != nil {
+		return 0, err
+	}
+	t, err := k.exportTable(scope)
+	if err != nil {
+		return 0, err
+	}
+	count, err := writeExportFile(expandHome(filename), exporter, t)
+	if k.lazy {
+		k.resetWindow() // the source filter may have been cleared meanwhile
+	}
+	return count, err
+}
+
+// writeExportFile writes t to filename with exporter through tp.WriteFileAtomic and returns the number
+// of rows written.
+func writeExportFile(filename string, exporter tp.Exporter, t *tp.TableExport) (int, error) {
+	count := 0
+	err := tp.WriteFileAtomic(filename, func(w io.Writer) error {
+		var err error
+		count, err = writeExport(w, exporter, t)
+		return err
+	})
+	return count, err
+}
+
+// ExportTo writes the rows of scope to w with the registered exporter format and returns the number
+// of rows written.
+func (k *TableRenderer) ExportTo(w io.Writer, format string, scope ExportScope) (int, error) {
+	exporter, ok := tp.LookupExporter(format)
+	if !ok {
+		return 0, fmt.Errorf("unsupported export format %q; use one of: %s", format, strings.Join(tp.ExporterNames(), ", "))
+	}
+	t, err := k.exportTable(scope)
+	if err != nil {
+		return 0, err
+	}
+	return writeExport(w, exporter, t)
+}
+
+// writeExport writes t to w with exporter and returns the number of rows written.
+func writeExport(w io.Writer, exporter tp.Exporter, t *tp.TableExport) (int, error) {
+	count := 0
+	each := t.EachRow
+	t.EachRow = func(fn func(row []string) error) error {
+		return each(func(row []string) error {
+			count++
+			return fn(row)
+		})
+	}
+	return count, exporter.Write(w, t)
+}
+
+// exportTable returns the rows of scope, sorted and projected on the column layout, for the exporters.
+// With footer aggregates, the export footer summarises the rows of scope. The export does not refer
+// to k, so it can be written outside of the UI loop: in-memory rows are copied, and lazy sources are
+// read when the rows are written.
+func (k *TableRenderer) exportTable(scope ExportScope) (*tp.TableExport, error) {
+	var each func(fn func(row []string) error) error
+	switch scope {
+	case ExportFiltered, "":
+		if k.lazy {
+			each = eachSourceRow(k.source, nil, "")
+		} else {
+			each = eachOf(k.collectViewRows())
+		}
+	case ExportAll:
+		if k.lazy {
+			filterer, _ := k.source.(tp.TableSourceFilterer)
+			if k.filter == "" {
+				filterer = nil
+			}
+			each = eachSourceRow(k.source, filterer, k.filter)
+		} else {
+			rows := cloneRows(k.rows)
+			k.sortSlice(rows)
+			each = eachOf(rows)
+		}
+	case ExportPage:
+		var rows [][]string
+		for _, row := range k.GetCurrentPageRows() {
+			if k.groupOf(row) == nil {
+				rows = append(rows, slices.Clone(row))
+			}
+		}
+		each = eachOf(rows)
+	case ExportSelected:
+		rows := cloneRows(k.actionRows())
+		if len(rows) == 0 {
+			return nil, fmt.Errorf("no row selected")
+		}
+		each = eachOf(rows)
+	default:
+		return nil, fmt.Errorf("unknown export scope: %q", scope)
+	}
+	aggregators := k.newAggregators()
+	cols := k.visibleColumns()
+	project := func(row []string) []string { return projectRow(cols, row) }
+	return &tp.TableExport{
+		Title:       k.tableID,
+		Headers:     k.exportHeaders(),
+		ColumnTypes: k.exportColumnTypes(),
+		EachRow: func(fn func(row []string) error) error {
+			return each(func(row []string) error {
+				for col, aggregator := range aggregators {
+					aggregator.Add(cellAt(row, col))
+				}
+				return fn(project(row))
+			})
+		},
+		Footer:  exportFooter(aggregators, len(k.headers), project),
+		Options: tp.ExportOptions{XMLRoot: k.xmlRoot, XMLRow: k.xmlRow, HTMLTheme: k.htmlTheme, CSVFooter: k.csvFooter, PDF: k.pdfExportOptions()},
+	}, nil
+}
+
+// pdfExportOptions returns the PDF options with the colours not set explicitly taken from the theme.
+func (k *TableRenderer) pdfExportOptions() tp.PDFOptions {
+	opts := k.pdfOptions
+	if opts.HeaderBackground == "" {
+		opts.HeaderBackground = themeColor(k.styles.selected.GetBackground())
+	}
+	if opts.HeaderForeground == "" {
+		opts.HeaderForeground = themeColor(k.styles.selected.GetForeground())
+	}
+	return opts
+}
+
+// themeColor returns the hex or ANSI value of a lipgloss colour, or an empty string for adaptive and
+// unset colours.
+func themeColor(c lipgloss.TerminalColor) string {
+	if color, ok := c.(lipgloss.Color); ok {
+		return string(color)
+	}
+	return ""
+}
+
+// eachOf returns an iterator over rows.
+func eachOf(rows [][]string) func(fn func(row []string) error) error {
+	return func(fn func(row []string) error) error {
+		for _, row := range rows {
+			if err := fn(row); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// cloneRows returns a deep copy of rows, for code running outside of the UI loop: edit mode changes
+// cells in place.
+func cloneRows(rows [][]string) [][]string {
+	clones := make([][]string, len(rows))
+	for i, row := range rows {
+		clones[i] = slices.Clone(row)
+	}
+	return clones
+}
+
+// collectViewRows returns a copy of the rows of the current view, without group headers.
+func (k *TableRenderer) collectViewRows() [][]string {
+	var rows [][]string
+	_ = k.eachViewRow(func(row []string) error {
+		rows = append(rows, slices.Clone(row))
+		return nil
+	})
+	return rows
+}
+
+// eachSourceRow returns an iterator over the rows of a lazy source, read in chunks. With filterer,
+// the source filter is cleared while iterating and set back to filter afterwards; the caller resets
+// its window once done.
+func eachSourceRow(source tp.TableDataSource, filterer tp.TableSourceFilterer, filter string) func(fn func(row []string) error) error {
+	return func(fn func(row []string) error) error {
+		if filterer != nil {
+			if err := filterer.SetFilter(""); err != nil {
+				return err
+			}
+			defer func() { _ = filterer.SetFilter(filter) }()
+		}
+		const chunk = 256
+		total := source.RowCount()
+		for start := 0; start < total; start += chunk {
+			rows, err := source.FetchRows(start, chunk)
+			if err != nil {
+				return err
+			}
+			for _, row := range rows {
+				if err := fn(row); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+}
+
+// scopeRowCount returns the number of rows exported by scope, or -1 when unknown.
+func (k *TableRenderer) scopeRowCount(scope ExportScope) int {
+	switch scope {
+	case ExportAll:
+		if k.lazy {
+			return -1
+		}
+		return len(k.rows)
+	case ExportPage:
+		return len(k.GetCurrentPageRows())
+	case ExportSelected:
+		return len(k.actionRows())
+	}
+	return k.rowCount()
+}
+
+// expandHome replaces a leading "~/" with the user's home directory.
+func expandHome(path string) string {
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, path[1:])
+		}
+	}
+	return path
+}
+
+// completePath returns the files and directories starting with input, directories ending with "/".
+// Hidden entries are only listed when the typed name starts with a dot.
+func completePath(input string) []string {
+	dir, prefix := filepath.Split(input)
+	readDir := dir
+	if readDir == "" {
+		readDir = "."
+	}
+	entries, err := os.ReadDir(expandHome(readDir))
+	if err != nil {
+		return nil
+	}
+	var matches []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if !strings.HasPrefix(name, prefix) || (strings.HasPrefix(name, ".") && !strings.HasPrefix(prefix, ".")) {
+			continue
+		}
+		if entry.IsDir() {
+			name += string(filepath.Separator)
+		}
+		matches = append(matches, dir+name)
+	}
+	sort.Strings(matches)
+	return matches
+}
+
+// commonPrefix returns the longest common prefix of values.
+func commonPrefix(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	prefix := values[0]
+	for _, v := range values[1:] {
+		for !strings.HasPrefix(v, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	for !utf8.ValidString(prefix) {
+		prefix = prefix[:len(prefix)-1]
+	}
+	return prefix
+}
+
+// Export dialog
+
+// exportForm is the state of the export dialog.
+type exportForm struct {
+	formats []tp.Exporter // registered exporters when the dialog was opened
+	format  int
+	scope   int
+	focus   int // 0 format, 1 scope, 2 path
+	path    textinput.Model
+	confirm bool // waiting for the overwrite confirmation
+	running bool // the export is being written
+	matches []string
+	match   int
+	err     error
+}
+
+// toastExpiredMsg clears the toast it was scheduled for.
+type toastExpiredMsg struct{ id int }
+
+// exportDoneMsg reports the outcome of an export started from the dialog.
+type exportDoneMsg struct {
+	path  string
+	title string
+	count int
+	err   error
+}
+
+// OpenExportDialog opens the export dialog, offering every registered exporter, with format (a name
+// or extension) preselected. An empty format keeps the last one used.
+func (k *TableRenderer) OpenExportDialog(format string) tea.Cmd {
+	form := &exportForm{path: textinput.New(), formats: tp.Exporters()}
+	if len(form.formats) == 0 {
+		return k.notify(Error, "No exporter registered")
+	}
+	form.path.Prompt = ""
+	form.path.Cursor.Style = cursorStyle
+	if k.lastExport != "" {
+		form.format = max(form.formatIndex(filepath.Ext(k.lastExport)), 0)
+	}
+	if i := form.formatIndex(format); i >= 0 {
+		form.format = i
+	}
+	if len(k.actionRows()) > 0 {
+		form.scope = 3
+	}
+	path := k.lastExport
+	if path == "" {
+		path = "exported_data.csv"
+	}
+	form.path.SetValue(strings.TrimSuffix(path, filepath.Ext(path)) + "." + form.formats[form.format].Extension())
+	form.path.CursorEnd()
+	form.focus = 2
+	k.exportDialog = form
+	return form.path.Focus()
+}
+
+// formatIndex returns the position in the dialog of the exporter matching format, or -1.
+func (f *exportForm) formatIndex(format string) int {
+	if format == "" {
+		return -1
+	}
+	exporter, ok := tp.LookupExporter(format)
+	if !ok {
+		return -1
+	}
+	for i, candidate := range f.formats {
+		if candidate.Name == exporter.Name {
+			return i
+		}
+	}
+	return -1
+}
+
+// exportKeysHelp lists the shortcuts of the registered exporters for the help text.
+func exportKeysHelp() string {
+	var b strings.Builder
+	for _, exporter := range tp.Exporters() {
+		if exporter.Key != "" {
+			b.WriteString(fmt.Sprintf("  - %s: Export to %s\n", exporter.Key, exporter.Title))
+		}
+	}
+	return b.String()
+}
+
+// notify shows a toast in the status line and schedules its removal.
+func (k *TableRenderer) notify(notificationType NotificationType, message string) tea.Cmd {
+	k.toastID++
+	k.toast = &Notification{Message: message, Type: notificationType}
+	id := k.toastID
+	return tea.Tick(toastDuration, func(time.Time) tea.Msg { return toastExpiredMsg{id: id} })
+}
+
+// updateExportDialog handles keys while the export dialog is open: up/down (or shift+tab/tab outside
+// of the path) move between fields, left/right change the format and scope, tab completes the path,
+// enter exports (asking before overwriting) and esc cancels.
+func (k *TableRenderer) updateExportDialog(message tea.KeyMsg) tea.Cmd {
+	form := k.exportDialog
+	if form.running {
+		return nil
+	}
+	if form.confirm {
+		switch message.String() {
+		case "y", "Y", "enter":
+			return k.runExport()
+		case "n", "N", "esc":
+			form.confirm = false
+		}
+		return nil
+	}
+	key := message.String()
+	if key != "tab" {
+		form.matches = nil
+	}
+	switch key {
+	case "esc":
+		k.exportDialog = nil
+		return nil
+	case "enter":
+		path := strings.TrimSpace(form.path.Value())
+		if path == "" {
+			form.err = fmt.Errorf("enter a file name")
+			return nil
+		}
+		if info, err := os.Stat(expandHome(path)); err == nil {
+			if info.IsDir() {
+				form.err = fmt.Errorf("%s is a directory", path)
+				return nil
+			}
+			form.confirm = true
+			return nil
+		}
+		return k.runExport()
+	case "up", "shift+tab":
+		form.focus = (form.focus + 2) % 3
+	case "down":
+		form.focus = (form.focus + 1) % 3
+	case "tab":
+		if form.focus != 2 {
+			form.focus = (form.focus + 1) % 3
+		} else {
+			form.complete()
+		}
+	case "left", "right":
+		if form.focus == 2 {
+			break
+		}
+		delta := 1
+		if key == "left" {
+			delta = -1
+		}
+		if form.focus == 0 {
+			previous := form.formats[form.format].Extension()
+			form.format = (form.format + delta + len(form.formats)) % len(form.formats)
+			if path := form.path.Value(); strings.HasSuffix(path, "."+previous) {
+				form.path.SetValue(strings.TrimSuffix(path, previous) + form.formats[form.format].Extension())
+				form.path.CursorEnd()
+			}
+		} else {
+			form.scope = (form.scope + delta + len(exportScopes)) % len(exportScopes)
+		}
+		form.err = nil
+		return nil
+	}
+	if form.focus == 2 {
+		if key == "tab" || key == "up" || key == "down" || key == "shift+tab" {
+			return form.path.Focus()
+		}
+		var cmd tea.Cmd
+		form.path, cmd = form.path.Update(message)
+		form.err = nil
+		return cmd
+	}
+	form.path.Blur()
+	return nil
+}
+
+// complete completes the path: a single candidate is taken as is, several candidates are first reduced
+// to their common prefix, then cycled on each further tab.
+func (f *exportForm) complete() {
+	value := f.path.Value()
+	if f.matches == nil {
+		f.matches = completePath(value)
+		f.match = 0
+		if len(f.matches) == 1 {
+			f.path.SetValue(f.matches[0])
+			f.path.CursorEnd()
+			f.matches = nil
+			return
+		}
+		if prefix := commonPrefix(f.matches); len(prefix) > len(value) {
+			f.path.SetValue(prefix)
+			f.path.CursorEnd()
+			return
+		}
+	}
+	if len(f.matches) > 0 {
+		f.path.SetValue(f.matches[f.match%len(f.matches)])
+		f.path.CursorEnd()
+		f.match++
+	}
+}
+
+// runExport exports with the dialog settings. On success the dialog closes and a toast reports the
+// file; on failure the dialog stays open with the error.
+func (k *TableRenderer) runExport() tea.Cmd {
+	form := k.exportDialog
+	form.confirm, form.err = false, nil
+	format := form.formats[form.format]
+	scope := exportScopes[form.scope]
+	path := strings.TrimSpace(form.path.Value())
+	exporter, err := tp.ResolveExporter(path, format.Name)
+	var t *tp.TableExport
+	if err == nil {
+		t, err = k.exportTable(scope)
+	}
+	if err != nil {
+		return k.exportDone(exportDoneMsg{path: path, err: err})
+	}
+	form.running = true
+	filename := expandHome(path)
+	return func() tea.Msg {
+		count, err := writeExportFile(filename, exporter, t)
+		return exportDoneMsg{path: path, title: format.Title, count: count, err: err}
+	}
+}
+
+// exportDone reports the outcome of an export run from the dialog. The dialog closes on success and
+// shows the error otherwise.
+func (k *TableRenderer) exportDone(msg exportDoneMsg) tea.Cmd {
+	if k.lazy {
+		k.resetWindow() // the source filter may have been cleared meanwhile
+	}
+	if form := k.exportDialog; form != nil {
+		form.running = false
+		form.err = msg.err
+	}
+	if msg.err != nil {
+		return k.notify(Error, fmt.Sprintf("Export to %s failed: %s", msg.path, msg.err))
+	}
+	k.lastExport = msg.path
+	k.exportDialog = nil
+	return k.notify(Info, fmt.Sprintf("Exported %d rows to %s (%s)", msg.count, msg.path, msg.title))
+}
+
+// exportDialogView renders the export dialog modal.
+func (k *TableRenderer) exportDialogView() string {
+	form := k.exportDialog
+	label := func(field int, name string) string {
+		if form.focus == field {
+			return focusedStyle.Render("> " + name)
+		}
+		return blurredStyle.Render("  " + name)
+	}
+	var b strings.Builder
+	b.WriteString(k.styles.header.Render("Export") + "\n\n")
+	format := form.formats[form.format]
+	b.WriteString(fmt.Sprintf("%s  ‹ %s › %s\n", label(0, "Format:"), format.Title, blurredStyle.Render("."+format.Extension())))
+	scope := exportScopes[form.scope]
+	rows := "? rows"
+	if n := k.scopeRowCount(scope); n >= 0 {
+		rows = fmt.Sprintf("%d rows", n)
+	}
+	b.WriteString(fmt.Sprintf("%s  ‹ %s › %s\n", label(1, "Rows:  "), scope.Description(), blurredStyle.Render(rows)))
+	b.WriteString(fmt.Sprintf("%s  %s\n", label(2, "Path:  "), form.path.View()))
+	for i, match := range form.matches {
+		if i == 8 {
+			b.WriteString(blurredStyle.Render(fmt.Sprintf("           … %d more", len(form.matches)-i)) + "\n")
+			break
+		}
+		b.WriteString(blurredStyle.Render("           "+match) + "\n")
+	}
+	if form.confirm {
+		b.WriteString("\n" + notificationStyle(Warning).Render(form.path.Value()+" exists. Overwrite? (y/n)") + "\n")
+	}
+	if form.running {
+		b.WriteString("\n" + notificationStyle(Info).Render("Exporting…") + "\n")
+	}
+	if form.err != nil {
+		b.WriteString("\n" + errorStyle.Render(form.err.Error()) + "\n")
+	}
+	b.WriteString("\n" + blurredStyle.Render("up/down field, left/right change, tab complete path, enter export, esc cancel"))
+	return lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(lipgloss.Color("238")).Padding(0, 1).Render(b.String())
+}
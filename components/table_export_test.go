@@ -0,0 +1,223 @@
+package components
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// readFile returns the content of path, failing the test when it cannot be read.
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading %s: %v", path, err)
+	}
+	return string(data)
+}
+
+// openExportDialog opens the CSV export dialog of k on path.
+func openExportDialog(k *TableRenderer, path string) {
+	k.OpenExportDialog("csv")
+	k.exportDialog.path.SetValue(path)
+}
+
+// runCmd runs cmd and returns its message, or nil for a nil command.
+func runCmd(cmd tea.Cmd) tea.Msg {
+	if cmd == nil {
+		return nil
+	}
+	return cmd()
+}
+
+func TestExportScopes(t *testing.T) {
+	k := newSortTable()
+	k.SetPageSize(2)
+	k.SortBy(0, true)
+	if err := k.SetFilter("arch=amd64"); err != nil {
+		t.Fatalf("SetFilter: %v", err)
+	}
+	k.SetColumnVisible(2, false)
+	k.ToggleRowSelection(2)
+	tests := []struct {
+		scope ExportScope
+		want  string
+	}{
+		{ExportFiltered, "Name,Size\ncurl,5\nvim,30\nzsh,\n"},
+		{ExportAll, "Name,Size\ncurl,5\ngit,30\nnano,\nvim,30\nzsh,\n"},
+		{ExportPage, "Name,Size\ncurl,5\nvim,30\n"},
+		{ExportSelected, "Name,Size\nzsh,\n"},
+	}
+	for _, tt := range tests {
+		var b strings.Builder
+		if _, err := k.ExportTo(&b, "csv", tt.scope); err != nil {
+			t.Errorf("ExportTo(%s): %v", tt.scope, err)
+			continue
+		}
+		if b.String() != tt.want {
+			t.Errorf("ExportTo(%s) = %q, want %q", tt.scope, b.String(), tt.want)
+		}
+	}
+	k.ClearSelection()
+	if _, err := k.ExportTo(&strings.Builder{}, "csv", ExportSelected); err == nil {
+		t.Error("ExportTo(selected) without a selection did not fail")
+	}
+}
+
+func TestExportDialog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "packages.csv")
+	k := newSortTable()
+	openExportDialog(k, path)
+	_, cmd := k.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if !k.exportDialog.running || cmd == nil {
+		t.Fatalf("enter did not start the export: running %v", k.exportDialog.running)
+	}
+	k.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if k.exportDialog == nil {
+		t.Fatal("esc closed the dialog while the export was running")
+	}
+	k.rows[0][0] = "neovim" // what an edit does meanwhile
+
+	k.Update(runCmd(cmd))
+	if k.exportDialog != nil {
+		t.Error("the dialog stayed open after a successful export")
+	}
+	if got, want := readFile(t, path), "Name,Size,Arch\nvim,30,amd64\nnano,,arm64\ngit,30,arm64\ncurl,5,amd64\nzsh,,amd64\n"; got != want {
+		t.Errorf("exported %q, want %q: the rows are taken when the export starts", got, want)
+	}
+	if k.toast == nil || k.toast.Type != Info || !strings.Contains(k.toast.Message, "Exported 5 rows") {
+		t.Errorf("toast = %+v, want the number of rows exported", k.toast)
+	}
+
+	k.OpenExportDialog("")
+	if got := k.exportDialog.path.Value(); got != path {
+		t.Errorf("the dialog reopened on %q, want the last export %q", got, path)
+	}
+}
+
+func TestExportDialogOverwrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "packages.csv")
+	if err := os.WriteFile(path, []byte("old"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	k := newSortTable()
+	openExportDialog(k, path)
+	k.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if !k.exportDialog.confirm {
+		t.Fatal("enter on an existing file did not ask before overwriting")
+	}
+	typeKeys(k, "n")
+	if k.exportDialog.confirm || readFile(t, path) != "old" {
+		t.Fatal("n did not cancel the overwrite")
+	}
+	k.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	_, cmd := k.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	k.Update(runCmd(cmd))
+	if got := readFile(t, path); !strings.HasPrefix(got, "Name,Size,Arch\n") {
+		t.Errorf("y did not overwrite the file: %q", got)
+	}
+}
+
+func TestExportDialogErrors(t *testing.T) {
+	dir := t.TempDir()
+	k := newSortTable()
+	openExportDialog(k, dir)
+	k.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if k.exportDialog.err == nil || k.exportDialog.running {
+		t.Errorf("exporting to a directory: error %v, running %v", k.exportDialog.err, k.exportDialog.running)
+	}
+
+	blocker := filepath.Join(dir, "file")
+	if err := os.WriteFile(blocker, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	k.exportDialog.path.SetValue(filepath.Join(blocker, "packages.csv"))
+	_, cmd := k.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	k.Update(runCmd(cmd))
+	if k.exportDialog == nil || k.exportDialog.running || k.exportDialog.err == nil {
+		t.Fatalf("a failed export should keep the dialog open with its error, got %+v", k.exportDialog)
+	}
+	if k.toast == nil || k.toast.Type != Error {
+		t.Errorf("toast = %+v, want an error", k.toast)
+	}
+}
+
+func TestExportDialogFields(t *testing.T) {
+	k := newSortTable()
+	k.ToggleRowSelection(1)
+	k.OpenExportDialog("json")
+	form := k.exportDialog
+	if got := form.path.Value(); got != "exported_data.json" {
+		t.Errorf("path = %q, want exported_data.json", got)
+	}
+	if exportScopes[form.scope] != ExportSelected {
+		t.Errorf("scope = %s, want selected rows while a selection exists", exportScopes[form.scope])
+	}
+	k.Update(tea.KeyMsg{Type: tea.KeyDown})
+	k.Update(tea.KeyMsg{Type: tea.KeyRight})
+	if form.focus != 0 || form.formats[form.format].Name != "ndjson" {
+		t.Errorf("focus %d, format %s, want the format field on ndjson", form.focus, form.formats[form.format].Name)
+	}
+	if got := form.path.Value(); got != "exported_data.ndjson" {
+		t.Errorf("path = %q, want the extension to follow the format", got)
+	}
+	k.Update(tea.KeyMsg{Type: tea.KeyDown})
+	k.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	if exportScopes[form.scope] != ExportPage || k.scopeRowCount(ExportPage) != 5 {
+		t.Errorf("scope %s with %d rows, want the page of 5 rows", exportScopes[form.scope], k.scopeRowCount(ExportPage))
+	}
+}
+
+func TestExportLazySource(t *testing.T) {
+	source := &filteringSource{pagedSource: pagedSource{headers: []string{"Name"}, rows: [][]string{{"vim"}, {"nano"}}}}
+	k := NewTableRendererFromSource(source, nil, nil)
+	if err := k.SetFilter("vim"); err != nil {
+		t.Fatalf("SetFilter: %v", err)
+	}
+	var b strings.Builder
+	if _, err := k.ExportTo(&b, "csv", ExportAll); err != nil {
+		t.Fatalf("ExportTo(all): %v", err)
+	}
+	if want := []string{"vim", "", "vim"}; !reflect.DeepEqual(source.filters, want) {
+		t.Errorf("source filters = %q, want %q: the filter is cleared for the export, then set back", source.filters, want)
+	}
+	if got := b.String(); got != "Name\nvim\nnano\n" {
+		t.Errorf("ExportTo(all) = %q", got)
+	}
+}
+
+func TestCompletePath(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"packages.csv", "packages.json", ".hidden"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Mkdir(filepath.Join(dir, "pkgs"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	prefix := dir + string(filepath.Separator)
+	want := []string{prefix + "packages.csv", prefix + "packages.json", prefix + "pkgs" + string(filepath.Separator)}
+	if got := completePath(prefix + "p"); !reflect.DeepEqual(got, want) {
+		t.Errorf("completePath(p) = %q, want %q", got, want)
+	}
+	if got, want := commonPrefix(want[:2]), prefix+"packages."; got != want {
+		t.Errorf("commonPrefix() = %q, want %q", got, want)
+	}
+
+	form := &exportForm{path: newFilterInput()}
+	form.path.SetValue(prefix + "pa")
+	form.complete()
+	if got := form.path.Value(); got != prefix+"packages." {
+		t.Errorf("first tab = %q, want the common prefix", got)
+	}
+	form.complete()
+	form.complete()
+	if got := form.path.Value(); got != prefix+"packages.json" {
+		t.Errorf("third tab = %q, want the second candidate", got)
+	}
+}
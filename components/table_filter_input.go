@@ -0,0 +1,143 @@
+package components
+
+import (
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	tp "github.com/kubex-ecosystem/xtui/types"
+)
+
+// maxFilterHistory bounds the number of previous filters kept for recall.
+const maxFilterHistory = 50
+
+// newFilterInput creates the text field used by the filter mode.
+func newFilterInput() textinput.Model {
+	input := textinput.New()
+	input.Prompt = "/ "
+	input.Placeholder = "status=installed and version>=1.2"
+	input.Cursor.Style = cursorStyle
+	input.PromptStyle = focusedStyle
+	return input
+}
+
+// StartFilterMode focuses the filter field, keeping the current filter so esc can restore it. Lazy
+// sources that cannot filter (see canFilter) get a warning instead.
+func (k *TableRenderer) StartFilterMode() tea.Cmd {
+	if !k.canFilter() {
+		return k.notify(Warning, "Filtering is not available: this source reads rows on demand and cannot filter them")
+	}
+	k.filtering = true
+	k.filterBefore = k.filter
+	k.historyIndex = len(k.filterHistory)
+	k.filterInput.SetValue(k.filter)
+	k.filterInput.CursorEnd()
+	return k.filterInput.Focus()
+}
+
+// canFilter reports whether the rows can be filtered: they are in memory, or the source implements
+// types.TableSourceFilterer.
+func (k *TableRenderer) canFilter() bool {
+	if !k.lazy {
+		return true
+	}
+	_, ok := k.source.(tp.TableSourceFilterer)
+	return ok
+}
+
+// updateFilterMode handles messages while the filter field is focused: enter applies and records the
+// filter, esc restores the previous one, up/down walk the history and everything else edits the field.
+// Client-side tables are filtered on every change; lazy sources only when the filter is applied.
+func (k *TableRenderer) updateFilterMode(msg tea.Msg) tea.Cmd {
+	if message, ok := msg.(tea.KeyMsg); ok {
+		switch message.String() {
+		case "enter":
+			k.filter = k.filterInput.Value()
+			k.ApplyFilter()
+			if k.filterErr == nil {
+				k.pushFilterHistory(k.filter)
+				k.stopFilterMode()
+			}
+			return nil
+		case "esc":
+			k.filter = k.filterBefore
+			k.ApplyFilter()
+			k.stopFilterMode()
+			return nil
+		case "up":
+			if k.historyIndex > 0 {
+				k.historyIndex--
+				k.filterInput.SetValue(k.filterHistory[k.historyIndex])
+				k.filterInput.CursorEnd()
+				k.liveFilter()
+			}
+			return nil
+		case "down":
+			if k.historyIndex < len(k.filterHistory) {
+				k.historyIndex++
+				value := k.filterBefore
+				if k.historyIndex < len(k.filterHistory) {
+					value = k.filterHistory[k.historyIndex]
+				}
+				k.filterInput.SetValue(value)
+				k.filterInput.CursorEnd()
+				k.liveFilter()
+			}
+			return nil
+		case "ctrl+c":
+			return tea.Quit
+		}
+	}
+	previous := k.filterInput.Value()
+	var cmd tea.Cmd
+	k.filterInput, cmd = k.filterInput.Update(msg)
+	if k.filterInput.Value() != previous {
+		k.liveFilter()
+	}
+	return cmd
+}
+
+// liveFilter applies the field's current value to client-side tables as the user types.
+func (k *TableRenderer) liveFilter() {
+	if k.lazy {
+		return
+	}
+	k.filter = k.filterInput.Value()
+	k.ApplyFilter()
+}
+
+func (k *TableRenderer) stopFilterMode() {
+	k.filtering = false
+	k.filterInput.Blur()
+}
+
+// pushFilterHistory records filter as the most recent history entry, dropping older duplicates.
+func (k *TableRenderer) pushFilterHistory(filter string) {
+	if filter == "" {
+		return
+	}
+	for i, previous := range k.filterHistory {
+		if previous == filter {
+			k.filterHistory = append(k.filterHistory[:i], k.filterHistory[i+1:]...)
+			break
+		}
+	}
+	k.filterHistory = append(k.filterHistory, filter)
+	if len(k.filterHistory) > maxFilterHistory {
+		k.filterHistory = k.filterHistory[len(k.filterHistory)-maxFilterHistory:]
+	}
+}
+
+// GetFilterHistory returns the previously applied filters, oldest first.
+func (k *TableRenderer) GetFilterHistory() []string { return append([]string(nil), k.filterHistory...) }
+
+// SetFilterHistory replaces the filter history, e.g. to restore it from a previous session.
+func (k *TableRenderer) SetFilterHistory(history []string) {
+	k.filterHistory = append([]string(nil), history...)
+}
+
+// matchCount returns the number of rows in the filtered view and the total number of rows.
+func (k *TableRenderer) matchCount() (int, int) {
+	if k.lazy {
+		return k.rowCount(), -1
+	}
+	return len(k.filteredRows), len(k.rows)
+}
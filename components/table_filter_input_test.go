@@ -0,0 +1,151 @@
+package components
+
+import (
+	"reflect"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// pagedSource is a source read one window at a time, like a database cursor.
+type pagedSource struct {
+	headers []string
+	rows    [][]string
+}
+
+func (s *pagedSource) GetHeaders() []string { return s.headers }
+func (s *pagedSource) RowCount() int        { return len(s.rows) }
+func (s *pagedSource) FetchRows(offset, limit int) ([][]string, error) {
+	offset = min(offset, len(s.rows))
+	return s.rows[offset:min(offset+limit, len(s.rows))], nil
+}
+
+// filteringSource is a paged source recording the filters it is given.
+type filteringSource struct {
+	pagedSource
+	filters []string
+}
+
+func (s *filteringSource) SetFilter(filter string) error {
+	s.filters = append(s.filters, filter)
+	return nil
+}
+
+// typeKeys sends text to k one key at a time.
+func typeKeys(k *TableRenderer, text string) {
+	for _, r := range text {
+		k.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+}
+
+// pressKey sends a special key to k.
+func pressKey(k *TableRenderer, key tea.KeyType) { k.Update(tea.KeyMsg{Type: key}) }
+
+func TestFilterMode(t *testing.T) {
+	k := newSortTable()
+	typeKeys(k, "/arch=arm64")
+	if !k.filtering {
+		t.Fatal("/ did not open the filter mode")
+	}
+	if got, want := viewNames(k), []string{"nano", "git"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("rows while typing = %v, want %v", got, want)
+	}
+	if matches, total := k.matchCount(); matches != 2 || total != 5 {
+		t.Errorf("matchCount() = %d, %d, want 2, 5", matches, total)
+	}
+	pressKey(k, tea.KeyEnter)
+	if k.filtering || k.GetFilter() != "arch=arm64" {
+		t.Errorf("after enter: filtering %v, filter %q", k.filtering, k.GetFilter())
+	}
+
+	typeKeys(k, "/x")
+	if got := viewNames(k); len(got) != 0 {
+		t.Errorf("rows while typing arch=arm64x = %v, want none", got)
+	}
+	pressKey(k, tea.KeyEsc)
+	if got, want := viewNames(k), []string{"nano", "git"}; k.filtering || k.GetFilter() != "arch=arm64" || !reflect.DeepEqual(got, want) {
+		t.Errorf("after esc: filtering %v, filter %q, rows %v", k.filtering, k.GetFilter(), got)
+	}
+
+	typeKeys(k, "/")
+	k.filterInput.SetValue("owner=root")
+	pressKey(k, tea.KeyEnter)
+	if !k.filtering || k.filterErr == nil {
+		t.Errorf("enter on an invalid filter: filtering %v, error %v", k.filtering, k.filterErr)
+	}
+	if got, want := viewNames(k), []string{"nano", "git"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("rows with an invalid filter = %v, want %v", got, want)
+	}
+	pressKey(k, tea.KeyEsc)
+	if got, want := k.GetFilterHistory(), []string{"arch=arm64"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GetFilterHistory() = %q, want %q", got, want)
+	}
+}
+
+func TestFilterModeHistory(t *testing.T) {
+	k := newSortTable()
+	k.SetFilterHistory([]string{"size>10", "arch=amd64"})
+	steps := []struct {
+		key    tea.KeyType
+		filter string
+		want   []string
+	}{
+		{tea.KeyUp, "arch=amd64", []string{"vim", "curl", "zsh"}},
+		{tea.KeyUp, "size>10", []string{"vim", "git"}},
+		{tea.KeyUp, "size>10", []string{"vim", "git"}},
+		{tea.KeyDown, "arch=amd64", []string{"vim", "curl", "zsh"}},
+		{tea.KeyDown, "", []string{"vim", "nano", "git", "curl", "zsh"}},
+	}
+	typeKeys(k, "/")
+	for i, step := range steps {
+		pressKey(k, step.key)
+		if got := k.filterInput.Value(); got != step.filter {
+			t.Errorf("step %d: field = %q, want %q", i, got, step.filter)
+		}
+		if got := viewNames(k); !reflect.DeepEqual(got, step.want) {
+			t.Errorf("step %d: rows = %v, want %v", i, got, step.want)
+		}
+	}
+
+	pressKey(k, tea.KeyUp)
+	pressKey(k, tea.KeyUp)
+	pressKey(k, tea.KeyEnter)
+	if got, want := k.GetFilterHistory(), []string{"arch=amd64", "size>10"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GetFilterHistory() = %q, want %q: a recalled filter moves to the end", got, want)
+	}
+}
+
+func TestFilterHistoryBound(t *testing.T) {
+	k := newSortTable()
+	for i := range maxFilterHistory + 5 {
+		k.pushFilterHistory(string(rune('a'+i%26)) + string(rune('a'+i/26)))
+	}
+	k.pushFilterHistory("")
+	history := k.GetFilterHistory()
+	if len(history) != maxFilterHistory {
+		t.Fatalf("history holds %d filters, want %d", len(history), maxFilterHistory)
+	}
+	if history[0] != "fa" || history[len(history)-1] != "cc" {
+		t.Errorf("history runs from %q to %q, want the newest filters from fa to cc", history[0], history[len(history)-1])
+	}
+}
+
+func TestFilterModeLazySource(t *testing.T) {
+	rows := [][]string{{"vim"}, {"nano"}}
+	source := &filteringSource{pagedSource: pagedSource{headers: []string{"Name"}, rows: rows}}
+	k := NewTableRendererFromSource(source, nil, nil)
+	typeKeys(k, "/vim")
+	if len(source.filters) != 0 {
+		t.Errorf("the source was filtered while typing: %q", source.filters)
+	}
+	pressKey(k, tea.KeyEnter)
+	if want := []string{"vim"}; !reflect.DeepEqual(source.filters, want) {
+		t.Errorf("source filters = %q, want %q", source.filters, want)
+	}
+
+	k = NewTableRendererFromSource(&pagedSource{headers: []string{"Name"}, rows: rows}, nil, nil)
+	typeKeys(k, "/")
+	if k.filtering || k.toast == nil || k.toast.Type != Warning {
+		t.Errorf("/ on a source that cannot filter: filtering %v, toast %+v", k.filtering, k.toast)
+	}
+}
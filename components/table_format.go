@@ -0,0 +1,128 @@
+package components
+
+import (
+	"sort"
+
+	"github.com/charmbracelet/lipgloss"
+	tp "github.com/kubex-ecosystem/xtui/types"
+)
+
+// defaultLevelColors are the log level colours every table gets unless a StyleFunc is given.
+var defaultLevelColors = map[string]lipgloss.Color{
+	"Info":    lipgloss.Color("#75FBAB"),
+	"Warning": lipgloss.Color("#FDFF90"),
+	"Error":   lipgloss.Color("#FF7698"),
+	"Debug":   lipgloss.Color("#929292"),
+}
+
+// defaultFormatRules turns the level colours, overridden or extended by customStyles, into rules
+// colouring the cells of any column equal to a key.
+func defaultFormatRules(customStyles map[string]lipgloss.Color) []tp.TableFormatRule {
+	colors := make(map[string]lipgloss.Color, len(defaultLevelColors)+len(customStyles))
+	for value, color := range defaultLevelColors {
+		colors[value] = color
+	}
+	for value, color := range customStyles {
+		colors[value] = color
+	}
+	values := make([]string, 0, len(colors))
+	for value := range colors {
+		values = append(values, value)
+	}
+	sort.Strings(values)
+	rules := make([]tp.TableFormatRule, 0, len(values))
+	for _, value := range values {
+		rules = append(rules, tp.TableFormatRule{
+			Operator: tp.FormatEquals,
+			Value:    value,
+			Style:    tp.TableFormatStyle{Foreground: string(colors[value])},
+		})
+	}
+	return rules
+}
+
+// SetFormatRules replaces the conditional formatting rules, including the default level colours.
+// Rules are applied in order, so a later rule overrides what an earlier one set. Invalid rules are
+// reported and leave the current rules untouched.
+func (k *TableRenderer) SetFormatRules(rules ...tp.TableFormatRule) error {
+	matchers, err := k.compileFormatRules(rules)
+	if err != nil {
+		return err
+	}
+	k.formatRules = append([]tp.TableFormatRule(nil), rules...)
+	k.formatMatchers = matchers
+	return nil
+}
+
+// AddFormatRules appends conditional formatting rules after the current ones.
+func (k *TableRenderer) AddFormatRules(rules ...tp.TableFormatRule) error {
+	return k.SetFormatRules(append(k.FormatRules(), rules...)...)
+}
+
+// FormatRules returns the conditional formatting rules in the order they are applied.
+func (k *TableRenderer) FormatRules() []tp.TableFormatRule {
+	return append([]tp.TableFormatRule(nil), k.formatRules...)
+}
+
+// LoadFormatRules appends the conditional formatting rules of a .json, .yaml or .toml file.
+func (k *TableRenderer) LoadFormatRules(path string) error {
+	rules, err := tp.LoadTableFormatRules(path)
+	if err != nil {
+		return err
+	}
+	return k.AddFormatRules(rules...)
+}
+
+// compileFormatRules compiles rules against the table headers.
+func (k *TableRenderer) compileFormatRules(rules []tp.TableFormatRule) ([]*tp.TableFormatMatcher, error) {
+	matchers := make([]*tp.TableFormatMatcher, 0, len(rules))
+	for _, rule := range rules {
+		m, err := tp.CompileTableFormatRule(rule, k.headers)
+		if err != nil {
+			return nil, err
+		}
+		matchers = append(matchers, m)
+	}
+	return matchers, nil
+}
+
+// formatCell applies to style the rules matching the cell of record at source column col.
+func (k *TableRenderer) formatCell(style lipgloss.Style, col int, value string, record []string) lipgloss.Style {
+	for _, m := range k.formatMatchers {
+		switch {
+		case m.Rule.Row:
+			if !m.Match(cellAt(record, m.Column), k.columnType(m.Column)) {
+				continue
+			}
+		case m.Column >= 0 && m.Column != col:
+			continue
+		case !m.Match(value, k.columnType(col)):
+			continue
+		}
+		style = applyFormatStyle(style, m.Rule.Style)
+	}
+	return style
+}
+
+// applyFormatStyle sets on style the attributes given by a rule.
+func applyFormatStyle(style lipgloss.Style, format tp.TableFormatStyle) lipgloss.Style {
+	if format.Foreground != "" {
+		style = style.Foreground(lipgloss.Color(format.Foreground))
+	}
+	if format.Background != "" {
+		style = style.Background(lipgloss.Color(format.Background))
+	}
+	if format.Bold {
+		style = style.Bold(true)
+	}
+	if format.Italic {
+		style = style.Italic(true)
+	}
+	if format.Underline {
+		style = style.Underline(true)
+	}
+	if format.Faint {
+		style = style.Faint(true)
+	}
+	return style
+}
@@ -0,0 +1,76 @@
+package components
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	tp "github.com/kubex-ecosystem/xtui/types"
+)
+
+// newFormatTable returns a table of log lines.
+func newFormatTable(customStyles map[string]lipgloss.Color) *TableRenderer {
+	return NewTableRenderer(tp.NewTableHandler([]string{"Level", "Message", "Size"}, [][]string{
+		{"Error", "disk full", "120 MB"},
+		{"Info", "started", "1 KB"},
+		{"Notice", "Error budget", "5 MB"},
+	}), customStyles, nil)
+}
+
+func TestDefaultFormatRules(t *testing.T) {
+	k := newFormatTable(map[string]lipgloss.Color{"Notice": "#00FFFF", "Error": "#FF0000"})
+	tests := []struct {
+		col   int
+		value string
+		want  lipgloss.TerminalColor
+	}{
+		{0, "Error", lipgloss.Color("#FF0000")},
+		{0, "Info", defaultLevelColors["Info"]},
+		{0, "Notice", lipgloss.Color("#00FFFF")},
+		{1, "Error budget", lipgloss.NoColor{}},
+		{1, "Warning", defaultLevelColors["Warning"]},
+	}
+	for _, tt := range tests {
+		if got := k.formatCell(lipgloss.NewStyle(), tt.col, tt.value, nil).GetForeground(); got != tt.want {
+			t.Errorf("foreground of %q = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestFormatRules(t *testing.T) {
+	k := newFormatTable(nil)
+	err := k.AddFormatRules(
+		tp.TableFormatRule{Column: "Size", Operator: tp.FormatRange, Min: "100 MB", Row: true, Style: tp.TableFormatStyle{Background: "52"}},
+		tp.TableFormatRule{Column: "Message", Operator: tp.FormatContains, Value: "disk", Style: tp.TableFormatStyle{Bold: true, Foreground: "#FFFFFF"}},
+	)
+	if err != nil {
+		t.Fatalf("AddFormatRules: %v", err)
+	}
+	k.SetColumnTypes("", "", tp.ColumnBytes)
+	full, small := k.rows[0], k.rows[1]
+
+	style := k.formatCell(lipgloss.NewStyle(), 0, full[0], full)
+	if style.GetBackground() != lipgloss.Color("52") || style.GetForeground() != defaultLevelColors["Error"] {
+		t.Errorf("Level of the 120 MB row: background %v, foreground %v, want the row rule over the level colour", style.GetBackground(), style.GetForeground())
+	}
+	style = k.formatCell(lipgloss.NewStyle(), 1, full[1], full)
+	if !style.GetBold() || style.GetForeground() != lipgloss.Color("#FFFFFF") {
+		t.Errorf("Message %q: bold %v, foreground %v", full[1], style.GetBold(), style.GetForeground())
+	}
+	if style := k.formatCell(lipgloss.NewStyle(), 2, small[2], small); style.GetBackground() != (lipgloss.NoColor{}) {
+		t.Errorf("Size of the 1 KB row got background %v", style.GetBackground())
+	}
+
+	before := k.FormatRules()
+	if err := k.AddFormatRules(tp.TableFormatRule{Column: "Owner", Operator: tp.FormatEquals}); err == nil {
+		t.Fatal("AddFormatRules accepted a rule on an unknown column")
+	}
+	if got := k.FormatRules(); len(got) != len(before) {
+		t.Errorf("an invalid rule changed the rules: %d, want %d", len(got), len(before))
+	}
+	if err := k.SetFormatRules(); err != nil || len(k.formatMatchers) != 0 {
+		t.Errorf("SetFormatRules() left %d rules, %v", len(k.formatMatchers), err)
+	}
+	if style := k.formatCell(lipgloss.NewStyle(), 0, "Error", full); style.GetForeground() != (lipgloss.NoColor{}) {
+		t.Error("the level colours stayed after SetFormatRules()")
+	}
+}
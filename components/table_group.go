@@ -0,0 +1,425 @@
+package components
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	gl "github.com/kubex-ecosystem/logz"
+	tp "github.com/kubex-ecosystem/xtui/types"
+)
+
+// rowGroup is a group of the grouped view. Its header row holds the group label in the grouped
+// column and the group aggregates in the aggregated columns.
+type rowGroup struct {
+	value     string
+	rows      [][]string
+	collapsed bool
+}
+
+// TableGroup describes a group of the grouped view.
+type TableGroup struct {
+	Value      string
+	Count      int
+	Collapsed  bool
+	Aggregates map[int]string // by source column, for the columns with an aggregate
+}
+
+// SetGroupBy groups the rows matching the filter by the values of column col, each group under a
+// header row showing its value, row count and aggregates; -1 ungroups. Groups follow the sort
+// direction of col when it is a sort key, ascending otherwise. Grouping needs the rows in memory
+// and is not available in edit mode.
+func (k *TableRenderer) SetGroupBy(col int) error {
+	if col >= len(k.headers) {
+		return fmt.Errorf("no column %d to group by", col)
+	}
+	if col >= 0 && k.lazy {
+		return fmt.Errorf("lazy sources cannot be grouped")
+	}
+	if col >= 0 && k.editable {
+		return fmt.Errorf("tables cannot be grouped in edit mode")
+	}
+	if col < 0 {
+		col = -1
+	}
+	if col != k.groupCol {
+		k.collapsed = nil
+	}
+	k.keepCursor(func() {
+		k.groupCol = col
+		k.regroup()
+	})
+	return nil
+}
+
+// GroupBy returns the grouped column, or -1.
+func (k *TableRenderer) GroupBy() int { return k.groupCol }
+
+// Groups returns the groups of the grouped view, in display order.
+func (k *TableRenderer) Groups() []TableGroup {
+	groups := make([]TableGroup, 0, len(k.groups))
+	for _, row := range k.groupView {
+		g := k.groupOf(row)
+		if g == nil {
+			continue
+		}
+		aggregates := make(map[int]string, len(k.aggregates))
+		for col := range k.aggregates {
+			aggregates[col] = cellAt(row, col)
+		}
+		groups = append(groups, TableGroup{Value: g.value, Count: len(g.rows), Collapsed: g.collapsed, Aggregates: aggregates})
+	}
+	return groups
+}
+
+// SetGroupCollapsed collapses or expands the group of value. Collapsed groups only show their header.
+func (k *TableRenderer) SetGroupCollapsed(value string, collapsed bool) {
+	if k.collapsed == nil {
+		k.collapsed = make(map[string]bool)
+	}
+	if collapsed {
+		k.collapsed[value] = true
+	} else {
+		delete(k.collapsed, value)
+	}
+	k.keepCursor(k.regroup)
+}
+
+// SetAllGroupsCollapsed collapses or expands every group.
+func (k *TableRenderer) SetAllGroupsCollapsed(collapsed bool) {
+	k.collapsed = make(map[string]bool)
+	if collapsed {
+		for _, row := range k.filteredRows {
+			k.collapsed[cellAt(row, k.groupCol)] = true
+		}
+	}
+	k.keepCursor(k.regroup)
+}
+
+// SetAggregate shows fn of column col in the table footer and in the group headers; an empty fn
+// removes it. Sum and avg need a numeric column. Aggregates follow the filter and are written as the
+// footer row of the exports that have one.
+func (k *TableRenderer) SetAggregate(col int, fn tp.AggregateFunc) error {
+	if col < 0 || col >= len(k.headers) {
+		return fmt.Errorf("no column %d to aggregate", col)
+	}
+	if fn != "" && k.lazy {
+		return fmt.Errorf("lazy sources cannot be aggregated")
+	}
+	if fn != "" && !fn.AppliesTo(k.columnType(col)) {
+		return fmt.Errorf("cannot compute %s of %s column %q", fn, k.columnType(col), k.headers[col])
+	}
+	if fn == "" {
+		delete(k.aggregates, col)
+	} else {
+		if k.aggregates == nil {
+			k.aggregates = make(map[int]tp.AggregateFunc)
+		}
+		k.aggregates[col] = fn
+	}
+	k.regroup()
+	k.resizePage()
+	k.refreshTable()
+	return nil
+}
+
+// Aggregates returns the footer aggregates by source column.
+func (k *TableRenderer) Aggregates() map[int]tp.AggregateFunc {
+	aggregates := make(map[int]tp.AggregateFunc, len(k.aggregates))
+	for col, fn := range k.aggregates {
+		aggregates[col] = fn
+	}
+	return aggregates
+}
+
+// Footer returns the aggregates of the rows matching the filter by source column, with empty cells
+// for the columns without aggregate, or nil when no aggregate is set.
+func (k *TableRenderer) Footer() []string { return slices.Clone(k.footer) }
+
+// cycleAggregate sets the next aggregate applying to column col, cycling back to none.
+func (k *TableRenderer) cycleAggregate(col int) error {
+	next := slices.Index(tp.AggregateFuncs, k.aggregates[col]) + 1
+	for ; next < len(tp.AggregateFuncs); next++ {
+		if tp.AggregateFuncs[next].AppliesTo(k.columnType(col)) {
+			return k.SetAggregate(col, tp.AggregateFuncs[next])
+		}
+	}
+	return k.SetAggregate(col, "")
+}
+
+// aggregateRows returns the aggregates of rows by source column.
+func (k *TableRenderer) aggregateRows(rows [][]string) []string {
+	cells := make([]string, max(len(k.headers), 1))
+	for col, fn := range k.aggregates {
+		aggregator := tp.NewAggregator(fn, k.columnType(col))
+		for _, row := range rows {
+			aggregator.Add(cellAt(row, col))
+		}
+		cells[col] = aggregator.Result()
+	}
+	return cells
+}
+
+// newAggregators returns an aggregator per aggregated column, for rows read one at a time.
+func (k *TableRenderer) newAggregators() map[int]*tp.Aggregator {
+	aggregators := make(map[int]*tp.Aggregator, len(k.aggregates))
+	for col, fn := range k.aggregates {
+		aggregators[col] = tp.NewAggregator(fn, k.columnType(col))
+	}
+	return aggregators
+}
+
+// regroup recomputes the footer and, when grouped, the groups of the filtered rows. The filtered rows
+// are put in group order and the view gets a header row before each group, without the rows of the
+// collapsed groups.
+func (k *TableRenderer) regroup() {
+	k.footer, k.groups, k.groupView = nil, nil, nil
+	if k.lazy {
+		return
+	}
+	if len(k.aggregates) > 0 {
+		k.footer = k.aggregateRows(k.filteredRows)
+	}
+	if k.groupCol < 0 {
+		k.clampPage()
+		return
+	}
+	var values []string
+	buckets := make(map[string][][]string)
+	for _, row := range k.filteredRows {
+		value := cellAt(row, k.groupCol)
+		if _, ok := buckets[value]; !ok {
+			values = append(values, value)
+		}
+		buckets[value] = append(buckets[value], row)
+	}
+	asc := true
+	if n := k.sortKeyIndex(k.groupCol); n >= 0 {
+		asc = k.sortKeys[n].Asc
+	}
+	columnType := k.columnType(k.groupCol)
+	sort.SliceStable(values, func(i, j int) bool {
+		return columnType.CompareOrdered(values[i], values[j], asc) < 0
+	})
+
+	k.groups = make(map[*string]*rowGroup, len(values))
+	k.groupView = make([][]string, 0, len(k.filteredRows)+len(values))
+	k.filteredRows = k.filteredRows[:0]
+	for _, value := range values {
+		g := &rowGroup{value: value, rows: buckets[value], collapsed: k.collapsed[value]}
+		header := k.aggregateRows(g.rows)
+		header[k.groupCol] = groupLabel(g)
+		k.groups[rowID(header)] = g
+		k.groupView = append(k.groupView, header)
+		if !g.collapsed {
+			k.groupView = append(k.groupView, g.rows...)
+		}
+		k.filteredRows = append(k.filteredRows, g.rows...)
+	}
+	k.clampPage()
+}
+
+// groupLabel returns the label of a group header: its state, value and row count.
+func groupLabel(g *rowGroup) string {
+	arrow, value := "▾", g.value
+	if g.collapsed {
+		arrow = "▸"
+	}
+	if value == "" {
+		value = "(empty)"
+	}
+	return fmt.Sprintf("%s %s (%d)", arrow, value, len(g.rows))
+}
+
+// viewRows returns the rows of the current view: the grouped view when grouped, the filtered rows
+// otherwise.
+func (k *TableRenderer) viewRows() [][]string {
+	if k.groups != nil {
+		return k.groupView
+	}
+	return k.filteredRows
+}
+
+// groupOf returns the group whose header is row, or nil for data rows.
+func (k *TableRenderer) groupOf(row []string) *rowGroup {
+	if k.groups == nil || len(row) == 0 {
+		return nil
+	}
+	return k.groups[rowID(row)]
+}
+
+// groupAt returns the group of the row at view index i: the group it heads or the one it belongs to.
+func (k *TableRenderer) groupAt(i int) *rowGroup {
+	if k.groups == nil {
+		return nil
+	}
+	for ; i >= 0 && i < len(k.groupView); i-- {
+		if g := k.groupOf(k.groupView[i]); g != nil {
+			return g
+		}
+	}
+	return nil
+}
+
+// keepCursor runs change, which rebuilds the view, keeping the cursor on the same row, or on the
+// header of its group when the group gets collapsed.
+func (k *TableRenderer) keepCursor(change func()) {
+	var row []string
+	header := ""
+	if k.selectedRow >= 0 {
+		row = k.rowAt(k.selectedRow)
+	}
+	g := k.groupOf(row)
+	if g != nil {
+		header = g.value
+	}
+	change()
+	if row == nil {
+		k.refreshTable()
+		return
+	}
+	rows := k.viewRows()
+	i := -1
+	if g == nil {
+		i = slices.IndexFunc(rows, func(r []string) bool { return rowID(r) == rowID(row) })
+		header = cellAt(row, k.groupCol)
+	}
+	if i < 0 && k.groups != nil {
+		i = slices.IndexFunc(rows, func(r []string) bool {
+			g := k.groupOf(r)
+			return g != nil && g.value == header
+		})
+	}
+	if i >= 0 {
+		k.selectedRow, k.page = i, i/k.pageSize
+	}
+	k.clampPage()
+	k.refreshTable()
+}
+
+// toggleGroupHeader collapses or expands the group whose header is under the cursor. It reports
+// false when the cursor is not on a group header, so enter copies data rows.
+func (k *TableRenderer) toggleGroupHeader() bool {
+	g := k.groupOf(k.rowAt(k.selectedRow))
+	if k.groups == nil || g == nil || k.selectedRow < 0 {
+		return false
+	}
+	k.SetGroupCollapsed(g.value, !g.collapsed)
+	return true
+}
+
+// toggleCurrentGroup collapses or expands the group under the cursor. It reports false for tables
+// that are not grouped.
+func (k *TableRenderer) toggleCurrentGroup() bool {
+	if k.groups == nil {
+		return false
+	}
+	if g := k.groupAt(k.selectedRow); g != nil && k.selectedRow >= 0 {
+		k.SetGroupCollapsed(g.value, !g.collapsed)
+	}
+	return true
+}
+
+// toggleAllGroups collapses every group, or expands them all when some are collapsed. It reports
+// false for tables that are not grouped.
+func (k *TableRenderer) toggleAllGroups() bool {
+	if k.groups == nil {
+		return false
+	}
+	k.SetAllGroupsCollapsed(len(k.collapsed) == 0)
+	return true
+}
+
+// toggleGroupSelection selects the rows of group g, or unselects them when they all are selected.
+func (k *TableRenderer) toggleGroupSelection(g *rowGroup) {
+	all := true
+	for _, row := range g.rows {
+		all = all && k.isSelected(row)
+	}
+	for _, row := range g.rows {
+		if all {
+			k.unselectRow(row)
+		} else {
+			k.selectRow(row)
+		}
+	}
+}
+
+// groupCells renders a group header: the label stays in the grouped column, or takes the first
+// column shown when the grouped column is scrolled out or hidden.
+func (k *TableRenderer) groupCells(row []string, cells []string) []string {
+	if len(cells) == 0 || slices.Contains(k.displayCols, k.groupCol) {
+		return cells
+	}
+	width := k.displayWidths[0]
+	if width <= 0 {
+		width = k.naturalWidth(k.displayCols[0])
+	}
+	cells[0] = ansi.Truncate(cellAt(row, k.groupCol), max(width, 1), "…")
+	return cells
+}
+
+// footerCells renders the footer row: each aggregate with its name, and "Total" in the first column
+// shown when it has no aggregate.
+func (k *TableRenderer) footerCells() []string {
+	cells := make([]string, len(k.displayCols))
+	for i, col := range k.displayCols {
+		if fn, ok := k.aggregates[col]; ok {
+			cells[i] = fn.String() + " " + cellAt(k.footer, col)
+		}
+		if width := k.displayWidths[i]; width > 0 {
+			cells[i] = ansi.Truncate(cells[i], width, "…")
+		}
+	}
+	if len(cells) > 0 && cells[0] == "" {
+		cells[0] = "Total"
+	}
+	return cells
+}
+
+// groupStyle returns the style of group header rows.
+func (k *TableRenderer) groupStyle() lipgloss.Style {
+	return k.styles.header.Foreground(lipgloss.Color("#01BE85"))
+}
+
+// footerStyle returns the style of the footer row.
+func (k *TableRenderer) footerStyle() lipgloss.Style {
+	return k.styles.header.Background(lipgloss.Color("236"))
+}
+
+// groupStatus describes the grouping for the status line.
+func (k *TableRenderer) groupStatus() string {
+	if k.groups == nil {
+		return ""
+	}
+	return fmt.Sprintf("Groups: %d by %s", len(k.groups), cellAt(k.headers, k.groupCol))
+}
+
+// exportFooter returns the footer of exports: the aggregates of the rows exported, projected on the
+// column layout with project, with "Total" in the first column when it has no aggregate.
+func exportFooter(aggregators map[int]*tp.Aggregator, columns int, project func(row []string) []string) func() []string {
+	if len(aggregators) == 0 {
+		return nil
+	}
+	return func() []string {
+		footer := make([]string, columns)
+		for col, aggregator := range aggregators {
+			footer[col] = aggregator.Result()
+		}
+		footer = project(footer)
+		if len(footer) > 0 && footer[0] == "" {
+			footer[0] = "Total"
+		}
+		return footer
+	}
+}
+
+// warnUngrouped logs that grouping was turned off for edit mode.
+func (k *TableRenderer) warnUngrouped() {
+	if k.groupCol >= 0 {
+		gl.Log("warn", "Grouping is turned off in edit mode")
+		k.groupCol, k.collapsed = -1, nil
+	}
+}
@@ -0,0 +1,216 @@
+package components
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea"
+	tp "github.com/kubex-ecosystem/xtui/types"
+)
+
+// tableKey binds keys of the table view to run, which reports whether it handled the key. A key it
+// leaves goes to the next binding of that key, then to the row action, bulk action and exporter
+// shortcuts.
+type tableKey struct {
+	keys []string
+	run  func(k *TableRenderer, key string) (tea.Cmd, bool)
+}
+
+// always returns a tableKey run handling every key it is bound to.
+func always(fn func(k *TableRenderer) tea.Cmd) func(k *TableRenderer, key string) (tea.Cmd, bool) {
+	return func(k *TableRenderer, _ string) (tea.Cmd, bool) { return fn(k), true }
+}
+
+// just is always for handlers without a command.
+func just(fn func(k *TableRenderer)) func(k *TableRenderer, key string) (tea.Cmd, bool) {
+	return func(k *TableRenderer, _ string) (tea.Cmd, bool) {
+		fn(k)
+		return nil, true
+	}
+}
+
+// editing returns a tableKey run handling its keys in edit mode only.
+func editing(fn func(k *TableRenderer) tea.Cmd) func(k *TableRenderer, key string) (tea.Cmd, bool) {
+	return func(k *TableRenderer, _ string) (tea.Cmd, bool) {
+		if !k.editable {
+			return nil, false
+		}
+		return fn(k), true
+	}
+}
+
+// grouped returns a tableKey run handling its keys when fn reports it did, e.g. in grouped tables.
+func grouped(fn func(k *TableRenderer) bool) func(k *TableRenderer, key string) (tea.Cmd, bool) {
+	return func(k *TableRenderer, _ string) (tea.Cmd, bool) { return nil, fn(k) }
+}
+
+// tableKeys are the keys of the table view outside of its modes and menus, in the order they are
+// tried. Actions cannot use them as shortcuts (see reservedKeys).
+var tableKeys = []tableKey{
+	{[]string{"e"}, editing(func(k *TableRenderer) tea.Cmd { return k.StartEdit(k.editCol) })},
+	{[]string{"ctrl+n"}, editing((*TableRenderer).addRowAndEdit)},
+	{[]string{"delete"}, editing((*TableRenderer).deleteCurrentRow)},
+	{[]string{"ctrl+z"}, editing((*TableRenderer).undoEdit)},
+	{[]string{"pgdown"}, just(func(k *TableRenderer) { k.GoToPage(k.CurrentPage() + 1) })},
+	{[]string{"pgup"}, just(func(k *TableRenderer) { k.GoToPage(k.CurrentPage() - 1) })},
+	{[]string{"home"}, just(func(k *TableRenderer) { k.GoToPage(1) })},
+	{[]string{"end"}, just((*TableRenderer).goToLastPage)},
+	{[]string{"ctrl+g"}, always((*TableRenderer).startGotoPage)},
+	{[]string{"enter"}, grouped((*TableRenderer).toggleGroupHeader)},
+	{[]string{"enter"}, just((*TableRenderer).copyActionRows)},
+	{[]string{"z"}, grouped((*TableRenderer).toggleCurrentGroup)},
+	{[]string{"Z"}, grouped((*TableRenderer).toggleAllGroups)},
+	{[]string{"ctrl+f"}, always((*TableRenderer).StartSearchMode)},
+	{[]string{"n", "N"}, func(k *TableRenderer, key string) (tea.Cmd, bool) {
+		if k.search == "" {
+			return nil, false
+		}
+		return k.NextMatch(key == "N"), true
+	}},
+	{[]string{"q", "ctrl+c"}, always(func(*TableRenderer) tea.Cmd { return tea.Quit })},
+	{[]string{"/"}, always((*TableRenderer).StartFilterMode)},
+	{[]string{"esc"}, just((*TableRenderer).clearLevel)},
+	{[]string{" "}, just((*TableRenderer).toggleCurrentSelection)},
+	{[]string{"shift+down"}, just(func(k *TableRenderer) { k.extendSelection(1) })},
+	{[]string{"shift+up"}, just(func(k *TableRenderer) { k.extendSelection(-1) })},
+	{[]string{"ctrl+a"}, just((*TableRenderer).toggleSelectAllFiltered)},
+	{[]string{"b"}, always(func(k *TableRenderer) tea.Cmd { return k.openBulkMenu(-1) })},
+	{[]string{"m"}, always((*TableRenderer).openActionMenu)},
+	{[]string{"d"}, just((*TableRenderer).ToggleDetailPane)},
+	{[]string{"D"}, just((*TableRenderer).showDetailFormat)},
+	{[]string{"tab"}, just(func(k *TableRenderer) { k.detailFocus = k.showDetail })},
+	{[]string{"ctrl+o"}, always((*TableRenderer).startSortPicker)},
+	{[]string{"shift+right"}, just(func(k *TableRenderer) { k.ScrollColumns(1) })},
+	{[]string{"shift+left"}, just(func(k *TableRenderer) { k.ScrollColumns(-1) })},
+	{[]string{"F"}, just((*TableRenderer).cycleFrozenColumns)},
+	{[]string{"right"}, just(func(k *TableRenderer) {
+		if (k.page+1)*k.pageSize < k.rowCount() {
+			k.page++
+		}
+	})},
+	{[]string{"left"}, just(func(k *TableRenderer) {
+		if k.page > 0 {
+			k.page--
+		}
+	})},
+	{[]string{"down"}, just(func(k *TableRenderer) { _ = k.RowsNavigate("down") })},
+	{[]string{"up"}, just(func(k *TableRenderer) { _ = k.RowsNavigate("up") })},
+	{[]string{"ctrl+s"}, always(func(k *TableRenderer) tea.Cmd { return k.OpenExportDialog("") })},
+	{[]string{"ctrl+h"}, just(func(k *TableRenderer) {
+		k.showHelp = !k.showHelp
+		k.resizePage()
+	})},
+	{[]string{"ctrl+k"}, just((*TableRenderer).ToggleColumnVisibility)},
+	{[]string{"v"}, just((*TableRenderer).openProfileMenu)},
+	{[]string{"V"}, just((*TableRenderer).cycleProfile)},
+}
+
+// handleKey runs the binding of key in the table view, or the action or exporter it is the shortcut
+// of.
+func (k *TableRenderer) handleKey(key string) tea.Cmd {
+	for _, binding := range tableKeys {
+		if slices.Contains(binding.keys, key) {
+			if cmd, ok := binding.run(k, key); ok {
+				return cmd
+			}
+		}
+	}
+	if i, ok := k.rowActionForKey(key); ok {
+		return k.triggerRowAction(i)
+	}
+	if i, ok := k.bulkActionForKey(key); ok {
+		return k.triggerBulkAction(i)
+	}
+	if exporter, ok := tp.ExporterForKey(key); ok {
+		return k.OpenExportDialog(exporter.Name)
+	}
+	return nil
+}
+
+// reservedKeys returns the keys the table view and its containers (tabs, linked tables) bind
+// themselves. Actions cannot use them as shortcuts, since the built-in binding would always win.
+func reservedKeys() []string {
+	var keys []string
+	for _, binding := range tableKeys {
+		keys = append(keys, binding.keys...)
+	}
+	keys = append(keys, nextTabKeys...)
+	keys = append(keys, previousTabKeys...)
+	keys = append(keys, tabNumberKeys...)
+	return append(keys, linkedFocusKeys...)
+}
+
+// checkActionKey returns an error when key is bound by the table view itself.
+func checkActionKey(name, key string) error {
+	if key != "" && slices.Contains(reservedKeys(), key) {
+		return fmt.Errorf("action %q: key %q is a built-in table key", name, key)
+	}
+	return nil
+}
+
+// copyActionRows copies the selected rows, or the row under the cursor, to the clipboard as
+// tab-separated lines.
+func (k *TableRenderer) copyActionRows() {
+	if rows := k.actionRows(); len(rows) > 0 {
+		lines := make([]string, len(rows))
+		for i, row := range rows {
+			lines[i] = strings.Join(row, "\t")
+		}
+		_ = clipboard.WriteAll(strings.Join(lines, "\n"))
+	}
+}
+
+// clearLevel clears the search, then the selection, then leaves selection mode.
+func (k *TableRenderer) clearLevel() {
+	if k.search != "" {
+		k.ClearSearch()
+	} else if len(k.selection) > 0 {
+		k.ClearSelection()
+	} else {
+		k.selectedRow = -1
+	}
+}
+
+// toggleCurrentSelection selects or unselects the row under the cursor, starting from the first row
+// of the page.
+func (k *TableRenderer) toggleCurrentSelection() {
+	if k.selectedRow < 0 {
+		k.selectedRow = k.page * k.pageSize
+	}
+	k.ToggleRowSelection(k.selectedRow)
+}
+
+// toggleSelectAllFiltered selects the rows matching the filter, or unselects them when they all are.
+func (k *TableRenderer) toggleSelectAllFiltered() {
+	if k.allFilteredSelected() {
+		k.UnselectAllFiltered()
+	} else {
+		k.SelectAllFiltered()
+	}
+}
+
+// showDetailFormat shows the detail pane, or cycles its format when it is shown.
+func (k *TableRenderer) showDetailFormat() {
+	if !k.showDetail {
+		k.SetDetailPaneVisible(true)
+	} else {
+		k.cycleDetailFormat()
+	}
+}
+
+// startSortPicker opens the sort mode on the primary sort column, or on the first visible column.
+func (k *TableRenderer) startSortPicker() tea.Cmd {
+	if !k.canSort() {
+		return k.notify(Warning, "Sorting is not available: this source reads rows on demand and cannot sort them")
+	}
+	k.sortPicking = true
+	if len(k.sortKeys) > 0 {
+		k.focusCol = k.sortKeys[0].Column
+	}
+	if cols := k.visibleColumns(); !k.IsColumnVisible(k.focusCol) && len(cols) > 0 {
+		k.focusCol = cols[0]
+	}
+	return nil
+}
@@ -0,0 +1,366 @@
+package components
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	gl "github.com/kubex-ecosystem/logz"
+	tp "github.com/kubex-ecosystem/xtui/types"
+)
+
+// LinkedDetailFunc produces the detail table of a master row, e.g. the files of a package. It
+// receives a copy of the full master row and of the master headers, and runs outside of the UI loop.
+type LinkedDetailFunc func(row []string, headers []string) (tp.TableDataHandler, error)
+
+// LinkedLayout is how the master and detail tables share the screen.
+type LinkedLayout string
+
+const (
+	LinkedAuto       LinkedLayout = "auto"
+	LinkedSideBySide LinkedLayout = "side-by-side"
+	LinkedStacked    LinkedLayout = "stacked"
+)
+
+func (l LinkedLayout) Description() string {
+	switch l {
+	case LinkedSideBySide:
+		return "Master on the left, detail on the right"
+	case LinkedStacked:
+		return "Master above the detail"
+	default:
+		return "Side by side on wide windows, stacked otherwise"
+	}
+}
+func (l LinkedLayout) String() string { return string(l) }
+
+// Linked tables: side by side from linkedSplitWidth columns in the auto layout, and the detail is
+// loaded once the master cursor rests for linkedLoadDelay.
+const (
+	linkedSplitWidth = 140
+	linkedLoadDelay  = 150 * time.Millisecond
+	linkedTitleLines = 1
+)
+
+// linkedFocusKeys move the focus between the linked tables; shift+tab only when the focused table is
+// not prompting. The tables cannot use them (see reservedKeys).
+var linkedFocusKeys = []string{"ctrl+w", "shift+tab"}
+
+// LinkedTables shows a master table and, next to it, the detail table of the master row under the
+// cursor. Each table keeps its own filter, sort, selection and layout; ctrl+w or shift+tab moves the
+// focus between them.
+type LinkedTables struct {
+	master       *TableRenderer
+	detail       *TableRenderer
+	loadDetail   LinkedDetailFunc
+	onDetailLoad func(row []string, k *TableRenderer)
+	masterTitle  string
+	detailTitle  string
+	layout       LinkedLayout
+	focusDetail  bool
+	customStyles map[string]lipgloss.Color
+	styleFunc    StyleFunc
+	gen          int      // generation of the detail requested last
+	wantKey      string   // master row key of the requested detail
+	wantRow      []string // master row of the requested detail
+	shownRow     []string // master row of the detail shown
+	loading      bool
+	loadErr      error
+	width        int
+	height       int
+}
+
+// linkedTableMsg is a message produced by the commands of one of the linked tables.
+type linkedTableMsg struct {
+	detail bool
+	msg    tea.Msg
+}
+
+// linkedRequestMsg starts loading the detail of generation gen, if the cursor has not moved since.
+type linkedRequestMsg struct{ gen int }
+
+// linkedDetailMsg carries the detail table built for generation gen.
+type linkedDetailMsg struct {
+	gen   int
+	table *TableRenderer
+	err   error
+}
+
+// NewLinkedTables creates linked tables for the master handler, building the detail table of the
+// master row under the cursor with loadDetail.
+func NewLinkedTables(master tp.TableDataHandler, loadDetail LinkedDetailFunc, customStyles map[string]lipgloss.Color, styleFunc StyleFunc) *LinkedTables {
+	return NewLinkedTablesFromRenderer(NewTableRenderer(master, customStyles, styleFunc), loadDetail, customStyles, styleFunc)
+}
+
+// NewLinkedTablesFromRenderer creates linked tables around an existing master renderer. Detail tables
+// are created with customStyles and styleFunc.
+func NewLinkedTablesFromRenderer(master *TableRenderer, loadDetail LinkedDetailFunc, customStyles map[string]lipgloss.Color, styleFunc StyleFunc) *LinkedTables {
+	master.setRoute(func(msg tea.Msg) tea.Msg { return linkedTableMsg{msg: msg} })
+	return &LinkedTables{
+		master:       master,
+		loadDetail:   loadDetail,
+		masterTitle:  "Master",
+		detailTitle:  "Detail",
+		layout:       LinkedAuto,
+		customStyles: customStyles,
+		styleFunc:    styleFunc,
+	}
+}
+
+// SetTitles sets the titles shown above the master and detail tables.
+func (m *LinkedTables) SetTitles(master, detail string) {
+	m.masterTitle, m.detailTitle = master, detail
+}
+
+// SetLayout sets how the tables share the screen.
+func (m *LinkedTables) SetLayout(layout LinkedLayout) {
+	m.layout = layout
+	m.resize()
+}
+
+// SetOnDetailLoad sets a function called with every detail table once it is loaded, e.g. to register
+// row actions. row is the master row the detail belongs to.
+func (m *LinkedTables) SetOnDetailLoad(onLoad func(row []string, k *TableRenderer)) {
+	m.onDetailLoad = onLoad
+}
+
+// Master returns the master table.
+func (m *LinkedTables) Master() *TableRenderer { return m.master }
+
+// Detail returns the detail table shown, or nil before the first one is loaded.
+func (m *LinkedTables) Detail() *TableRenderer { return m.detail }
+
+// focused returns the table with the keyboard focus.
+func (m *LinkedTables) focused() *TableRenderer {
+	if m.focusDetail && m.detail != nil {
+		return m.detail
+	}
+	return m.master
+}
+
+// FocusDetail gives the keyboard focus to the detail table, or back to the master table.
+func (m *LinkedTables) FocusDetail(focus bool) { m.focusDetail = focus && m.detail != nil }
+
+// sideBySide reports whether the tables are laid out side by side.
+func (m *LinkedTables) sideBySide() bool {
+	switch m.layout {
+	case LinkedSideBySide:
+		return true
+	case LinkedStacked:
+		return false
+	default:
+		return m.width == 0 || m.width >= linkedSplitWidth
+	}
+}
+
+// paneSizes returns the window sizes given to the master and detail tables.
+func (m *LinkedTables) paneSizes() (tea.WindowSizeMsg, tea.WindowSizeMsg) {
+	if m.sideBySide() {
+		left := (m.width - 1) / 2
+		height := max(m.height-linkedTitleLines, 1)
+		return tea.WindowSizeMsg{Width: left, Height: height}, tea.WindowSizeMsg{Width: m.width - 1 - left, Height: height}
+	}
+	top := max((m.height-2*linkedTitleLines)/2, 1)
+	return tea.WindowSizeMsg{Width: m.width, Height: top}, tea.WindowSizeMsg{Width: m.width, Height: max(m.height-2*linkedTitleLines-top, 1)}
+}
+
+// resize hands the pane sizes to both tables.
+func (m *LinkedTables) resize() tea.Cmd {
+	if m.width == 0 {
+		return nil
+	}
+	masterSize, detailSize := m.paneSizes()
+	return tea.Batch(m.send(false, masterSize), m.send(true, detailSize))
+}
+
+// send delivers msg to the master or detail table and returns its command, routed back to it.
+func (m *LinkedTables) send(detail bool, msg tea.Msg) tea.Cmd {
+	table := m.master
+	if detail {
+		table = m.detail
+	}
+	if table == nil {
+		return nil
+	}
+	_, cmd := table.Update(msg)
+	return table.route(cmd)
+}
+
+// follow schedules loading the detail of the master row under the cursor when it changed.
+func (m *LinkedTables) follow() tea.Cmd {
+	row := m.master.SelectedRow()
+	key := ""
+	if row != nil {
+		key = m.master.keyOf(row)
+	}
+	if key == m.wantKey && (row == nil) == (m.wantRow == nil) {
+		return nil
+	}
+	m.gen++
+	m.wantKey, m.wantRow, m.loadErr = key, append([]string(nil), row...), nil
+	if row == nil {
+		m.loading = false
+		return nil
+	}
+	m.loading = true
+	gen := m.gen
+	return tea.Tick(linkedLoadDelay, func(time.Time) tea.Msg { return linkedRequestMsg{gen: gen} })
+}
+
+// request loads the detail of generation gen in a command.
+func (m *LinkedTables) request(gen int) tea.Cmd {
+	if gen != m.gen || m.loadDetail == nil {
+		return nil
+	}
+	row := append([]string(nil), m.wantRow...)
+	headers := append([]string(nil), m.master.GetHeaders()...)
+	loadDetail, customStyles, styleFunc := m.loadDetail, m.customStyles, m.styleFunc
+	return func() (msg tea.Msg) {
+		defer func() {
+			if r := recover(); r != nil {
+				msg = linkedDetailMsg{gen: gen, err: fmt.Errorf("loading detail: %v", r)}
+			}
+		}()
+		handler, err := loadDetail(row, headers)
+		if err != nil {
+			return linkedDetailMsg{gen: gen, err: err}
+		}
+		if handler == nil {
+			handler = tp.NewTableHandler(nil, nil)
+		}
+		return linkedDetailMsg{gen: gen, table: NewTableRenderer(handler, customStyles, styleFunc)}
+	}
+}
+
+// detailLoaded shows the detail table loaded for the current master row.
+func (m *LinkedTables) detailLoaded(msg linkedDetailMsg) tea.Cmd {
+	if msg.gen != m.gen {
+		return nil // the cursor moved on while it was loading
+	}
+	m.loading = false
+	if msg.err != nil {
+		m.loadErr = msg.err
+		gl.Log("error", "Error loading detail table: "+msg.err.Error())
+		return nil
+	}
+	m.detail, m.shownRow = msg.table, m.wantRow
+	m.detail.setRoute(func(msg tea.Msg) tea.Msg { return linkedTableMsg{detail: true, msg: msg} })
+	if m.onDetailLoad != nil {
+		m.onDetailLoad(append([]string(nil), m.shownRow...), m.detail)
+	}
+	cmds := []tea.Cmd{m.detail.route(m.detail.Init())}
+	if m.width > 0 {
+		_, detailSize := m.paneSizes()
+		cmds = append(cmds, m.send(true, detailSize))
+	}
+	return tea.Batch(cmds...)
+}
+
+// Init starts the master table with the cursor on its first row and loads its detail.
+func (m *LinkedTables) Init() tea.Cmd {
+	if m.master.selectedRow < 0 && m.master.rowCount() > 0 {
+		_ = m.master.RowsNavigate("down")
+		m.master.refreshTable()
+	}
+	return tea.Batch(m.master.route(m.master.Init()), m.follow())
+}
+
+// Update moves the focus with ctrl+w (or shift+tab, unless the focused table is prompting), hands keys to the focused table and loads the
+// detail table whenever the master cursor moves to another row.
+func (m *LinkedTables) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	switch message := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = message.Width, message.Height
+		cmd = m.resize()
+	case linkedRequestMsg:
+		return m, m.request(message.gen)
+	case linkedDetailMsg:
+		return m, m.detailLoaded(message)
+	case linkedTableMsg:
+		cmd = m.send(message.detail, message.msg)
+	case tea.KeyMsg:
+		if key := message.String(); slices.Contains(linkedFocusKeys, key) && (key == "ctrl+w" || !m.focused().capturingKeys()) {
+			m.FocusDetail(!m.focusDetail)
+			return m, nil
+		}
+		cmd = m.send(m.focusDetail, msg)
+	default:
+		cmd = m.send(m.focusDetail, msg)
+	}
+	return m, tea.Batch(cmd, m.follow())
+}
+
+// titleView renders the title line of a pane.
+func (m *LinkedTables) titleView(title string, focused bool, width int) string {
+	style := lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
+	if focused {
+		style = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#01BE85"))
+		title = "▸ " + title
+	}
+	if width > 0 {
+		title = ansi.Truncate(title, width, "…")
+	}
+	return style.Render(title)
+}
+
+// detailTitleText returns the title of the detail pane, naming the master row and the loading state.
+func (m *LinkedTables) detailTitleText() string {
+	title := m.detailTitle
+	row := m.shownRow
+	if m.loading || m.loadErr != nil {
+		row = m.wantRow
+	}
+	if row != nil {
+		title += ": " + cellAt(row, m.master.firstVisibleColumn())
+	}
+	switch {
+	case m.loading:
+		title += "  " + blurredStyle.Render("Loading…")
+	case m.loadErr != nil:
+		title += "  " + errorStyle.Render("✗ failed")
+	}
+	return title
+}
+
+// View renders both tables with their titles.
+func (m *LinkedTables) View() string {
+	masterSize, detailSize := m.paneSizes()
+	master := m.titleView(m.masterTitle, !m.focusDetail, masterSize.Width) + "\n" + m.master.View()
+	body := "\n" + blurredStyle.Render("Select a row to see its details.") + "\n"
+	switch {
+	case m.loadErr != nil:
+		body = "\n" + errorStyle.Render("✗ "+m.loadErr.Error()) + "\n"
+	case m.detail != nil:
+		body = m.detail.View() // kept while the next detail loads
+	case m.loading:
+		body = "\n" + blurredStyle.Render("Loading…") + "\n"
+	}
+	detail := m.titleView(m.detailTitleText(), m.focusDetail, detailSize.Width) + "\n" + body
+	if m.sideBySide() {
+		left := lipgloss.NewStyle()
+		if m.width > 0 {
+			left = left.Width(masterSize.Width)
+		}
+		return lipgloss.JoinHorizontal(lipgloss.Top, left.Render(master), " ", detail)
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, master, detail)
+}
+
+// StartLinkedTables starts linked tables for the master handler.
+func StartLinkedTables(master tp.TableDataHandler, loadDetail LinkedDetailFunc, customStyles map[string]lipgloss.Color) error {
+	return StartLinkedTablesFromModel(NewLinkedTables(master, loadDetail, customStyles, nil))
+}
+
+// StartLinkedTablesFromModel starts the given linked tables.
+func StartLinkedTablesFromModel(m *LinkedTables) error {
+	prog := tea.NewProgram(m, tea.WithAltScreen())
+	if _, err := prog.Run(); err != nil {
+		gl.Log("error", "Error running linked table screen: "+err.Error())
+		return err
+	}
+	return nil
+}
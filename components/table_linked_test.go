@@ -0,0 +1,77 @@
+package components
+
+import (
+	"errors"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	tp "github.com/kubex-ecosystem/xtui/types"
+)
+
+// newTestLinkedTables returns linked tables of packages and their files, with the detail of the first
+// package loaded.
+func newTestLinkedTables(t *testing.T) *LinkedTables {
+	t.Helper()
+	master := tp.NewTableHandler([]string{"Name"}, [][]string{{"vim"}, {"nano"}})
+	m := NewLinkedTables(master, func(row []string, _ []string) (tp.TableDataHandler, error) {
+		return tp.NewTableHandler([]string{"File"}, [][]string{{"/usr/bin/" + row[0]}}), nil
+	}, nil, nil)
+	m.Init()
+	m.Update(m.request(m.gen)())
+	if m.Detail() == nil {
+		t.Fatal("the detail table did not load")
+	}
+	return m
+}
+
+func TestLinkedTablesRouting(t *testing.T) {
+	m := newTestLinkedTables(t)
+	if got := m.Detail().GetRows(); len(got) != 1 || got[0][0] != "/usr/bin/vim" {
+		t.Fatalf("detail rows = %q, want the files of vim", got)
+	}
+
+	// the callback of an external process started by the detail while the master has the focus
+	m.Update(execCallback("Open", nil, m.Detail().msgRoute)(errors.New("exit status 2")))
+	if toast := m.Detail().toast; toast == nil || toast.Message != "Open: exit status 2" {
+		t.Errorf("detail toast = %v, want the exec failure", toast)
+	}
+	if toast := m.Master().toast; toast != nil {
+		t.Errorf("master got the detail toast %q", toast.Message)
+	}
+
+	// a message of the master while the detail has the focus
+	m.FocusDetail(true)
+	m.Update(m.Master().route(func() tea.Msg { return ActionResultMsg{Message: "Upgraded vim"} })())
+	if toast := m.Master().toast; toast == nil || toast.Message != "Upgraded vim" {
+		t.Errorf("master toast = %v, want the action result", toast)
+	}
+	if toast := m.Detail().toast; toast == nil || toast.Message != "Open: exit status 2" {
+		t.Errorf("detail toast = %v, want it unchanged", toast)
+	}
+
+	// untagged messages go to the focused table
+	m.Update(ActionResultMsg{Message: "untagged"})
+	if toast := m.Detail().toast; toast == nil || toast.Message != "untagged" {
+		t.Errorf("detail toast = %v, want the untagged message", toast)
+	}
+}
+
+func TestLinkedTablesStaleDetail(t *testing.T) {
+	m := newTestLinkedTables(t)
+	m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	stale := m.request(m.gen) // nano
+	m.Update(tea.KeyMsg{Type: tea.KeyUp})
+
+	m.Update(stale())
+	if got := m.Detail().GetRows(); got[0][0] != "/usr/bin/vim" {
+		t.Errorf("the detail of a row the cursor left replaced the shown one: %q", got)
+	}
+	if !m.loading {
+		t.Error("the detail of the row under the cursor is no longer loading")
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m.Update(m.request(m.gen)())
+	if got := m.Detail().GetRows(); got[0][0] != "/usr/bin/nano" {
+		t.Errorf("detail rows = %q, want the files of nano", got)
+	}
+}
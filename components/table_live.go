@@ -0,0 +1,286 @@
+package components
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	gl "github.com/kubex-ecosystem/logz"
+	tp "github.com/kubex-ecosystem/xtui/types"
+)
+
+// Live updates: updates waiting on the channel are applied together, up to liveBatchSize at a time,
+// and changed cells stay highlighted for the highlight duration.
+const (
+	liveBatchSize            = 1024
+	defaultHighlightDuration = 2 * time.Second
+	wholeRow                 = -1
+)
+
+// liveUpdateMsg carries the updates read from the update channel.
+type liveUpdateMsg struct {
+	updates []tp.TableUpdate
+	closed  bool
+}
+
+// livePollMsg triggers a reload of the polled table. gen discards the ticks of a previous interval.
+type livePollMsg struct{ gen int }
+
+// liveFadeMsg removes the expired highlights.
+type liveFadeMsg struct{}
+
+// SetUpdateChannel makes the table apply the row changes received on updates, keeping the filter,
+// sort, selection, cursor and scroll position. Sources implementing types.TableSourceStreamer are
+// subscribed automatically. Updates and deletes find their row by key (see SetRowKey), and are
+// ignored when they have no key and no row key is set.
+// The returned command starts listening; Init returns it too.
+func (k *TableRenderer) SetUpdateChannel(updates <-chan tp.TableUpdate) tea.Cmd {
+	k.updates = updates
+	return k.listenUpdates()
+}
+
+// SetPollInterval makes the table reload its rows from the source every interval (see Reload); 0
+// stops polling. The returned command schedules the first reload; Init returns it too.
+func (k *TableRenderer) SetPollInterval(interval time.Duration) tea.Cmd {
+	k.pollInterval = interval
+	k.pollGen++
+	return k.schedulePoll()
+}
+
+// SetHighlightDuration sets how long changed cells stay highlighted; 0 turns highlighting off.
+func (k *TableRenderer) SetHighlightDuration(d time.Duration) { k.highlightFor = d }
+
+// IsLive reports whether the table receives updates or polls its source.
+func (k *TableRenderer) IsLive() bool { return k.updates != nil || k.pollInterval > 0 }
+
+// liveCmd starts listening to the update channel and polling, as configured.
+func (k *TableRenderer) liveCmd() tea.Cmd {
+	return tea.Batch(k.listenUpdates(), k.schedulePoll())
+}
+
+// listenUpdates waits for the next updates on the channel, taking every update already queued with it.
+func (k *TableRenderer) listenUpdates() tea.Cmd {
+	updates := k.updates
+	if updates == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		update, ok := <-updates
+		if !ok {
+			return liveUpdateMsg{closed: true}
+		}
+		msg := liveUpdateMsg{updates: []tp.TableUpdate{update}}
+		for len(msg.updates) < liveBatchSize {
+			select {
+			case update, ok := <-updates:
+				if !ok {
+					msg.closed = true
+					return msg
+				}
+				msg.updates = append(msg.updates, update)
+			default:
+				return msg
+			}
+		}
+		return msg
+	}
+}
+
+// schedulePoll schedules the next reload of a polled table.
+func (k *TableRenderer) schedulePoll() tea.Cmd {
+	if k.pollInterval <= 0 {
+		return nil
+	}
+	gen := k.pollGen
+	return tea.Tick(k.pollInterval, func(time.Time) tea.Msg { return livePollMsg{gen: gen} })
+}
+
+// liveUpdate applies the updates received and listens for the next ones. Updates received in edit
+// mode wait until it is turned off.
+func (k *TableRenderer) liveUpdate(msg liveUpdateMsg) tea.Cmd {
+	var next tea.Cmd
+	if msg.closed {
+		k.updates = nil
+	} else {
+		next = k.listenUpdates()
+	}
+	if k.editable {
+		k.livePending = append(k.livePending, msg.updates...)
+		return next
+	}
+	return tea.Batch(k.ApplyUpdates(msg.updates...), next)
+}
+
+// livePoll reloads a polled table and schedules the next reload.
+func (k *TableRenderer) livePoll(msg livePollMsg) tea.Cmd {
+	if msg.gen != k.pollGen || k.pollInterval <= 0 {
+		return nil
+	}
+	if k.editable {
+		return k.schedulePoll()
+	}
+	var err error
+	fade := k.trackChanges(nil, func() { err = k.Reload() })
+	if err != nil {
+		return tea.Batch(k.notify(Error, "Reload failed: "+err.Error()), k.schedulePoll())
+	}
+	return tea.Batch(fade, k.schedulePoll())
+}
+
+// ApplyUpdates applies row changes to the table, as if they were received on the update channel.
+// Lazy sources cannot be changed by the renderer: the updates only make it read the rows again.
+func (k *TableRenderer) ApplyUpdates(updates ...tp.TableUpdate) tea.Cmd {
+	if len(updates) == 0 {
+		return nil
+	}
+	inserted := make(map[string]bool)
+	return k.trackChanges(inserted, func() {
+		if k.lazy {
+			k.resetWindow()
+			k.ApplyFilter()
+			return
+		}
+		k.rows = slices.Clone(k.rows) // the source keeps its own rows
+		index := make(map[string]int, len(k.rows))
+		for i, row := range k.rows {
+			index[k.keyOf(row)] = i
+		}
+		for _, update := range updates {
+			key := update.Key
+			if key == "" && k.rowKey == nil && (update.Kind == tp.TableRowUpdate || update.Kind == tp.TableRowDelete) {
+				gl.Log("warn", fmt.Sprintf("Table %s without a key ignored: set a row key (SetRowKey) or TableUpdate.Key", update.Kind))
+				continue
+			}
+			if key == "" {
+				key = k.keyOf(update.Row)
+			}
+			i, known := index[key]
+			switch update.Kind {
+			case tp.TableRowDelete:
+				if known {
+					k.rows[i] = nil
+					delete(index, key)
+				}
+				k.unselectKey(key)
+			case tp.TableRowInsert, tp.TableRowUpdate:
+				row := slices.Clone(update.Row)
+				if known && update.Kind == tp.TableRowUpdate {
+					k.rows[i] = row
+				} else {
+					index[key] = len(k.rows)
+					k.rows = append(k.rows, row)
+					inserted[key] = true
+				}
+				if _, ok := k.selection[key]; ok {
+					k.selection[key] = row
+				}
+			default:
+				gl.Log("warn", fmt.Sprintf("Unknown table update kind: %s", update.Kind))
+			}
+		}
+		k.rows = slices.DeleteFunc(k.rows, func(row []string) bool { return row == nil })
+		k.ApplyFilter()
+	})
+}
+
+// trackChanges runs change, keeping the cursor on the same row and highlighting the cells of the
+// current page that it changed, and the rows whose keys are in inserted.
+func (k *TableRenderer) trackChanges(inserted map[string]bool, change func()) tea.Cmd {
+	before := make(map[string][]string, len(k.pageRows))
+	for _, row := range k.pageRows {
+		before[k.keyOf(row)] = slices.Clone(row)
+	}
+	cursor := ""
+	if row := k.SelectedRow(); row != nil {
+		cursor = k.keyOf(row)
+	}
+
+	change()
+
+	if cursor != "" && !k.lazy {
+		if i := slices.IndexFunc(k.viewRows(), func(row []string) bool { return k.keyOf(row) == cursor }); i >= 0 {
+			k.selectedRow = i
+			k.page = i / k.pageSize
+		}
+	}
+	k.clampPage()
+	k.lastUpdate = time.Now()
+	k.refreshTable()
+	if k.highlightFor <= 0 {
+		return nil
+	}
+	until := k.lastUpdate.Add(k.highlightFor)
+	for _, row := range k.pageRows {
+		key := k.keyOf(row)
+		old, seen := before[key]
+		switch {
+		case inserted[key]:
+			k.highlight(key, wholeRow, until)
+		case seen:
+			for col := range max(len(row), len(old)) {
+				if cellAt(row, col) != cellAt(old, col) {
+					k.highlight(key, col, until)
+				}
+			}
+		}
+	}
+	if len(k.highlights) == 0 {
+		return nil
+	}
+	return tea.Tick(k.highlightFor, func(time.Time) tea.Msg { return liveFadeMsg{} })
+}
+
+// highlight marks column col (or the whole row) of the row with key as changed until the given time.
+func (k *TableRenderer) highlight(key string, col int, until time.Time) {
+	if k.highlights == nil {
+		k.highlights = make(map[string]map[int]time.Time)
+	}
+	if k.highlights[key] == nil {
+		k.highlights[key] = make(map[int]time.Time)
+	}
+	k.highlights[key][col] = until
+}
+
+// fadeHighlights removes the expired highlights.
+func (k *TableRenderer) fadeHighlights() {
+	now := time.Now()
+	for key, cols := range k.highlights {
+		for col, until := range cols {
+			if !now.Before(until) {
+				delete(cols, col)
+			}
+		}
+		if len(cols) == 0 {
+			delete(k.highlights, key)
+		}
+	}
+}
+
+// highlightedCellStyle returns style adjusted for a recently changed cell of record.
+func (k *TableRenderer) highlightedCellStyle(style lipgloss.Style, record []string, col int) lipgloss.Style {
+	if len(k.highlights) == 0 || record == nil {
+		return style
+	}
+	cols := k.highlights[k.keyOf(record)]
+	until, ok := cols[col]
+	if !ok {
+		until, ok = cols[wholeRow]
+	}
+	if !ok || !time.Now().Before(until) {
+		return style
+	}
+	return style.Background(lipgloss.Color("#5A4A00")).Bold(true)
+}
+
+// liveStatus describes the live state for the status line.
+func (k *TableRenderer) liveStatus() string {
+	if !k.IsLive() {
+		return ""
+	}
+	if k.lastUpdate.IsZero() {
+		return "Live"
+	}
+	return "Live: " + k.lastUpdate.Format("15:04:05")
+}
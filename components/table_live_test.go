@@ -0,0 +1,122 @@
+package components
+
+import (
+	"reflect"
+	"testing"
+
+	tp "github.com/kubex-ecosystem/xtui/types"
+)
+
+func TestApplyUpdates(t *testing.T) {
+	byName := func(row []string) string { return row[0] }
+	tests := []struct {
+		name    string
+		rowKey  func(row []string) string
+		updates []tp.TableUpdate
+		want    [][]string
+	}{
+		{
+			name:    "update by row key",
+			rowKey:  byName,
+			updates: []tp.TableUpdate{{Kind: tp.TableRowUpdate, Row: []string{"vim", "9.1"}}},
+			want:    [][]string{{"vim", "9.1"}, {"nano", "6.2"}},
+		},
+		{
+			name:    "update of an unknown key inserts",
+			rowKey:  byName,
+			updates: []tp.TableUpdate{{Kind: tp.TableRowUpdate, Row: []string{"git", "2.34"}}},
+			want:    [][]string{{"vim", "8.2"}, {"nano", "6.2"}, {"git", "2.34"}},
+		},
+		{
+			name:    "explicit key wins over the row key",
+			rowKey:  byName,
+			updates: []tp.TableUpdate{{Kind: tp.TableRowUpdate, Key: "nano", Row: []string{"pico", "5.0"}}},
+			want:    [][]string{{"vim", "8.2"}, {"pico", "5.0"}},
+		},
+		{
+			name:    "delete by key",
+			rowKey:  byName,
+			updates: []tp.TableUpdate{{Kind: tp.TableRowDelete, Key: "vim"}},
+			want:    [][]string{{"nano", "6.2"}},
+		},
+		{
+			name:    "delete by row key",
+			rowKey:  byName,
+			updates: []tp.TableUpdate{{Kind: tp.TableRowDelete, Row: []string{"nano"}}},
+			want:    [][]string{{"vim", "8.2"}},
+		},
+		{
+			name:    "delete of an unknown key",
+			rowKey:  byName,
+			updates: []tp.TableUpdate{{Kind: tp.TableRowDelete, Key: "git"}},
+			want:    [][]string{{"vim", "8.2"}, {"nano", "6.2"}},
+		},
+		{
+			name:   "insert then update in one batch",
+			rowKey: byName,
+			updates: []tp.TableUpdate{
+				{Kind: tp.TableRowInsert, Row: []string{"git", "2.34"}},
+				{Kind: tp.TableRowUpdate, Row: []string{"git", "2.43"}},
+				{Kind: tp.TableRowDelete, Key: "vim"},
+			},
+			want: [][]string{{"nano", "6.2"}, {"git", "2.43"}},
+		},
+		{
+			name:    "update without any key is ignored",
+			updates: []tp.TableUpdate{{Kind: tp.TableRowUpdate, Row: []string{"vim", "9.1"}}},
+			want:    [][]string{{"vim", "8.2"}, {"nano", "6.2"}},
+		},
+		{
+			name:    "delete without any key is ignored",
+			updates: []tp.TableUpdate{{Kind: tp.TableRowDelete, Row: []string{"vim", "8.2"}}},
+			want:    [][]string{{"vim", "8.2"}, {"nano", "6.2"}},
+		},
+		{
+			name:    "update with an explicit key and no row key",
+			updates: []tp.TableUpdate{{Kind: tp.TableRowUpdate, Key: "vim\x1f8.2", Row: []string{"vim", "9.1"}}},
+			want:    [][]string{{"vim", "9.1"}, {"nano", "6.2"}},
+		},
+		{
+			name:    "insert without any key",
+			updates: []tp.TableUpdate{{Kind: tp.TableRowInsert, Row: []string{"git", "2.34"}}},
+			want:    [][]string{{"vim", "8.2"}, {"nano", "6.2"}, {"git", "2.34"}},
+		},
+		{
+			name:    "unknown kind is ignored",
+			rowKey:  byName,
+			updates: []tp.TableUpdate{{Kind: tp.TableUpdateKind("upsert"), Row: []string{"vim", "9.1"}}},
+			want:    [][]string{{"vim", "8.2"}, {"nano", "6.2"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows := [][]string{{"vim", "8.2"}, {"nano", "6.2"}}
+			k := NewTableRenderer(tp.NewTableHandler([]string{"Name", "Version"}, rows), nil, nil)
+			if tt.rowKey != nil {
+				k.SetRowKey(tt.rowKey)
+			}
+			k.ApplyUpdates(tt.updates...)
+			if got := k.GetRows(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("rows = %q, want %q", got, tt.want)
+			}
+			if want := [][]string{{"vim", "8.2"}, {"nano", "6.2"}}; !reflect.DeepEqual(rows, want) {
+				t.Errorf("the handler rows were changed to %q", rows)
+			}
+		})
+	}
+}
+
+func TestApplyUpdatesSelection(t *testing.T) {
+	k := NewTableRenderer(tp.NewTableHandler([]string{"Name", "Version"}, [][]string{{"vim", "8.2"}, {"nano", "6.2"}}), nil, nil)
+	k.SetRowKey(func(row []string) string { return row[0] })
+	k.selectRow([]string{"vim", "8.2"})
+	k.selectRow([]string{"nano", "6.2"})
+
+	k.ApplyUpdates(
+		tp.TableUpdate{Kind: tp.TableRowUpdate, Row: []string{"vim", "9.1"}},
+		tp.TableUpdate{Kind: tp.TableRowDelete, Key: "nano"},
+	)
+	if got, want := k.SelectedRows(), [][]string{{"vim", "9.1"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("SelectedRows() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,150 @@
+package components
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Lines of the table screen around the page rows: the filter line and the blank lines around it, the
+// table borders and header, the status line and the help hint.
+const (
+	screenChrome    = 10
+	defaultPageSize = 20
+)
+
+// pageSizeFromEnv returns the page size fixed by KBX_PAGE_SIZE_LIMIT, or 0 when it is unset or invalid.
+func pageSizeFromEnv() int {
+	if size, err := strconv.Atoi(os.Getenv("KBX_PAGE_SIZE_LIMIT")); err == nil && size > 0 {
+		return size
+	}
+	return 0
+}
+
+// screenHeightFromEnv returns the terminal height announced by LINES, or 0.
+func screenHeightFromEnv() int {
+	if lines, err := strconv.Atoi(os.Getenv("LINES")); err == nil && lines > 0 {
+		return lines
+	}
+	return 0
+}
+
+// SetPageSize fixes the number of rows per page; 0 sizes pages to the window height again.
+// KBX_PAGE_SIZE_LIMIT sets it for every table.
+func (k *TableRenderer) SetPageSize(size int) {
+	k.fixedPageSize = max(size, 0)
+	k.resizePage()
+}
+
+// PageSize returns the number of rows per page.
+func (k *TableRenderer) PageSize() int { return k.pageSize }
+
+// PageCount returns the number of pages of the current view.
+func (k *TableRenderer) PageCount() int { return max((k.rowCount()+k.pageSize-1)/k.pageSize, 1) }
+
+// CurrentPage returns the current page, starting at 1.
+func (k *TableRenderer) CurrentPage() int { return k.page + 1 }
+
+// GoToPage shows page (starting at 1, clamped to the pages of the view). The cursor, when shown,
+// moves to the first row of the page.
+func (k *TableRenderer) GoToPage(page int) {
+	k.page = min(max(page, 1), k.PageCount()) - 1
+	if k.selectedRow >= 0 {
+		k.selectedRow = min(k.page*k.pageSize, k.rowCount()-1)
+	}
+	k.refreshTable()
+}
+
+// chromeHeight returns the lines of the screen that are not page rows.
+func (k *TableRenderer) chromeHeight() int {
+	chrome := screenChrome
+	if k.showHelp {
+		chrome += strings.Count(k.helpText(), "\n")
+	}
+	if k.showDetail && !k.detailSplit() {
+		chrome += detailStackedHeight
+	}
+	if k.footer != nil {
+		chrome++
+	}
+	return chrome
+}
+
+// resizePage sizes pages to the window height unless the page size is fixed, keeping the cursor row,
+// or the first row of the page, on screen.
+func (k *TableRenderer) resizePage() {
+	size := k.fixedPageSize
+	if size == 0 {
+		height := k.height
+		if height == 0 {
+			height = screenHeightFromEnv()
+		}
+		size = defaultPageSize
+		if height > 0 {
+			size = max(height-k.chromeHeight(), 1)
+		}
+	}
+	if size == k.pageSize {
+		return
+	}
+	anchor := k.page * k.pageSize
+	if k.selectedRow >= 0 {
+		anchor = k.selectedRow
+	}
+	k.pageSize = size
+	k.page = anchor / size
+	k.clampPage()
+	k.refreshTable()
+}
+
+// goToLastPage goes to the last page, moving the cursor to the last row when a row is selected.
+func (k *TableRenderer) goToLastPage() {
+	k.GoToPage(k.PageCount())
+	if k.selectedRow >= 0 {
+		k.selectedRow = k.rowCount() - 1
+	}
+}
+
+// startGotoPage opens the page number prompt.
+func (k *TableRenderer) startGotoPage() tea.Cmd {
+	input := textinput.New()
+	input.Prompt = "Go to page: "
+	input.PromptStyle = focusedStyle
+	input.Cursor.Style = cursorStyle
+	input.CharLimit = 9
+	input.Placeholder = fmt.Sprintf("1-%d", k.PageCount())
+	k.gotoInput = input
+	k.gotoPage = true
+	return k.gotoInput.Focus()
+}
+
+// updateGotoPage handles the page number prompt: enter goes to the page, esc cancels.
+func (k *TableRenderer) updateGotoPage(msg tea.Msg) tea.Cmd {
+	if message, ok := msg.(tea.KeyMsg); ok {
+		switch message.String() {
+		case "esc":
+			k.gotoPage = false
+			return nil
+		case "enter":
+			k.gotoPage = false
+			page, err := strconv.Atoi(strings.TrimSpace(k.gotoInput.Value()))
+			if err != nil {
+				return k.notify(Warning, "Not a page number: "+k.gotoInput.Value())
+			}
+			k.GoToPage(page)
+			return nil
+		}
+	}
+	var cmd tea.Cmd
+	k.gotoInput, cmd = k.gotoInput.Update(msg)
+	return cmd
+}
+
+// gotoPageView renders the page number prompt in place of the status line.
+func (k *TableRenderer) gotoPageView() string {
+	return k.gotoInput.View() + blurredStyle.Render(fmt.Sprintf("  of %d (enter go, esc cancel)", k.PageCount()))
+}
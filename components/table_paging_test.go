@@ -0,0 +1,119 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	tp "github.com/kubex-ecosystem/xtui/types"
+)
+
+// newLongTable returns a table of 50 rows.
+func newLongTable() *TableRenderer {
+	rows := make([][]string, 50)
+	for i := range rows {
+		rows[i] = []string{fmt.Sprintf("row %02d", i)}
+	}
+	return NewTableRenderer(tp.NewTableHandler([]string{"Name"}, rows), nil, nil)
+}
+
+func TestResponsivePageSize(t *testing.T) {
+	t.Setenv("KBX_PAGE_SIZE_LIMIT", "")
+	t.Setenv("LINES", "")
+	k := newLongTable()
+	if k.PageSize() != defaultPageSize {
+		t.Errorf("PageSize() without a window = %d, want %d", k.PageSize(), defaultPageSize)
+	}
+	steps := []struct {
+		name string
+		do   func()
+		size int
+	}{
+		{"window", func() { k.Update(tea.WindowSizeMsg{Width: 80, Height: 60}) }, 60 - screenChrome},
+		{"help", func() { pressKey(k, tea.KeyCtrlH) }, 60 - screenChrome - strings.Count(k.helpText(), "\n")},
+		{"no help", func() { pressKey(k, tea.KeyCtrlH) }, 60 - screenChrome},
+		{"stacked detail pane", func() { k.SetDetailPaneVisible(true) }, 60 - screenChrome - detailStackedHeight},
+		{"detail pane beside the table", func() { k.Update(tea.WindowSizeMsg{Width: 120, Height: 60}) }, 60 - screenChrome},
+		{"tiny window", func() { k.Update(tea.WindowSizeMsg{Width: 120, Height: 3}) }, 1},
+		{"fixed", func() { k.SetPageSize(7) }, 7},
+		{"fixed in a resized window", func() { k.Update(tea.WindowSizeMsg{Width: 120, Height: 40}) }, 7},
+		{"unfixed", func() { k.SetPageSize(0) }, 40 - screenChrome},
+	}
+	for _, step := range steps {
+		step.do()
+		if k.PageSize() != step.size {
+			t.Errorf("%s: PageSize() = %d, want %d", step.name, k.PageSize(), step.size)
+		}
+	}
+}
+
+func TestPageSizeFromEnv(t *testing.T) {
+	t.Setenv("KBX_PAGE_SIZE_LIMIT", "")
+	t.Setenv("LINES", "25")
+	if k := newLongTable(); k.PageSize() != 25-screenChrome {
+		t.Errorf("PageSize() with LINES=25 = %d, want %d", k.PageSize(), 25-screenChrome)
+	}
+	t.Setenv("KBX_PAGE_SIZE_LIMIT", "12")
+	k := newLongTable()
+	k.Update(tea.WindowSizeMsg{Width: 80, Height: 60})
+	if k.PageSize() != 12 {
+		t.Errorf("PageSize() with KBX_PAGE_SIZE_LIMIT=12 = %d, want 12", k.PageSize())
+	}
+}
+
+func TestResizeKeepsCursor(t *testing.T) {
+	t.Setenv("KBX_PAGE_SIZE_LIMIT", "")
+	k := newLongTable()
+	k.Update(tea.WindowSizeMsg{Width: 80, Height: 20})
+	k.selectedRow = 25
+	k.page = 25 / k.PageSize()
+	k.Update(tea.WindowSizeMsg{Width: 80, Height: 15})
+	if first := (k.CurrentPage() - 1) * k.PageSize(); k.selectedRow < first || k.selectedRow >= first+k.PageSize() {
+		t.Errorf("cursor row 25 is not on page %d of %d rows", k.CurrentPage(), k.PageSize())
+	}
+}
+
+func TestPageKeys(t *testing.T) {
+	k := newLongTable()
+	k.SetPageSize(10)
+	steps := []struct {
+		key  tea.KeyMsg
+		page int
+	}{
+		{tea.KeyMsg{Type: tea.KeyPgDown}, 2},
+		{tea.KeyMsg{Type: tea.KeyEnd}, 5},
+		{tea.KeyMsg{Type: tea.KeyPgDown}, 5},
+		{tea.KeyMsg{Type: tea.KeyPgUp}, 4},
+		{tea.KeyMsg{Type: tea.KeyHome}, 1},
+		{tea.KeyMsg{Type: tea.KeyPgUp}, 1},
+	}
+	for i, step := range steps {
+		k.Update(step.key)
+		if k.CurrentPage() != step.page {
+			t.Errorf("step %d (%s): page %d, want %d", i, step.key, k.CurrentPage(), step.page)
+		}
+	}
+
+	k.selectedRow = 3
+	pressKey(k, tea.KeyEnd)
+	if k.selectedRow != 49 {
+		t.Errorf("end moved the cursor to %d, want the last row", k.selectedRow)
+	}
+	pressKey(k, tea.KeyCtrlG)
+	typeKeys(k, "3")
+	pressKey(k, tea.KeyEnter)
+	if k.gotoPage || k.CurrentPage() != 3 || k.selectedRow != 20 {
+		t.Errorf("ctrl+g 3: prompt %v, page %d, cursor %d", k.gotoPage, k.CurrentPage(), k.selectedRow)
+	}
+	pressKey(k, tea.KeyCtrlG)
+	typeKeys(k, "x")
+	pressKey(k, tea.KeyEnter)
+	if k.CurrentPage() != 3 || k.toast == nil || k.toast.Type != Warning {
+		t.Errorf("ctrl+g x: page %d, toast %+v, want a warning", k.CurrentPage(), k.toast)
+	}
+	k.GoToPage(99)
+	if k.CurrentPage() != 5 {
+		t.Errorf("GoToPage(99) = page %d, want the last page", k.CurrentPage())
+	}
+}
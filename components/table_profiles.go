@@ -0,0 +1,326 @@
+package components
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	gl "github.com/kubex-ecosystem/logz"
+	tp "github.com/kubex-ecosystem/xtui/types"
+)
+
+// SetTableID sets the identity under which view profiles of this table are stored.
+func (k *TableRenderer) SetTableID(id string) { k.tableID = id }
+
+// TableID returns the identity of the table. Unless set with SetTableID, it is derived from the
+// headers, so tables with the same columns share their profiles.
+func (k *TableRenderer) TableID() string {
+	if k.tableID != "" {
+		return k.tableID
+	}
+	sum := sha1.Sum([]byte(strings.Join(k.headers, "\x1f")))
+	return "headers:" + hex.EncodeToString(sum[:8])
+}
+
+// SetProfilesPath sets the profiles file (json, yaml or toml). It defaults to types.DefaultTableProfilesPath.
+func (k *TableRenderer) SetProfilesPath(path string) { k.profilesPath = path }
+
+// ActiveProfile returns the name of the last loaded or saved profile.
+func (k *TableRenderer) ActiveProfile() string { return k.activeProfile }
+
+// CurrentProfile captures the current column layout, sort stack, filter, page size, grouping and
+// footer aggregates as a profile.
+func (k *TableRenderer) CurrentProfile(name string) tp.TableViewProfile {
+	profile := tp.TableViewProfile{Name: name, Filter: k.filter, PageSize: k.fixedPageSize, Frozen: k.frozen}
+	for _, c := range k.layout {
+		profile.Columns = append(profile.Columns, tp.TableColumnProfile{
+			Header: cellAt(k.headers, c.Index), Hidden: c.Hidden, Width: c.Width, MinWidth: c.MinWidth, MaxWidth: c.MaxWidth,
+		})
+	}
+	for _, key := range k.sortKeys {
+		profile.Sort = append(profile.Sort, tp.TableSortProfile{Header: cellAt(k.headers, key.Column), Asc: key.Asc})
+	}
+	if k.groupCol >= 0 {
+		profile.GroupBy = cellAt(k.headers, k.groupCol)
+	}
+	for _, c := range k.layout {
+		if fn, ok := k.aggregates[c.Index]; ok {
+			profile.Aggregates = append(profile.Aggregates, tp.TableAggregateProfile{Header: cellAt(k.headers, c.Index), Func: fn})
+		}
+	}
+	return profile
+}
+
+// ApplyProfile restores a profile. Headers unknown to this table are ignored and columns missing
+// from the profile are kept visible after the listed ones.
+func (k *TableRenderer) ApplyProfile(profile tp.TableViewProfile) error {
+	layout := make([]columnLayout, 0, len(k.layout))
+	used := make(map[int]bool)
+	for _, c := range profile.Columns {
+		if col := k.headerIndex(c.Header, used); col >= 0 {
+			layout = append(layout, columnLayout{Index: col, Hidden: c.Hidden, Width: c.Width, MinWidth: c.MinWidth, MaxWidth: c.MaxWidth})
+			used[col] = true
+		}
+	}
+	for _, c := range k.layout {
+		if !used[c.Index] {
+			layout = append(layout, columnLayout{Index: c.Index})
+		}
+	}
+	k.layout = layout
+	if len(k.visibleColumns()) == 0 && len(k.layout) > 0 {
+		k.layout[0].Hidden = false
+	}
+	if profile.PageSize > 0 {
+		k.SetPageSize(profile.PageSize)
+	}
+	k.frozen, k.hScroll = profile.Frozen, 0
+	var keys []tp.SortKey
+	for _, s := range profile.Sort {
+		if col := k.headerIndex(s.Header, nil); col >= 0 {
+			keys = append(keys, tp.SortKey{Column: col, Asc: s.Asc})
+		}
+	}
+	k.sortKeys = keys
+	if k.lazy {
+		k.SortRows()
+	}
+	k.aggregates = nil
+	for _, a := range profile.Aggregates {
+		if col := k.headerIndex(a.Header, nil); col >= 0 {
+			if err := k.SetAggregate(col, a.Func); err != nil {
+				gl.Log("warn", "Ignoring aggregate of profile "+profile.Name+": "+err.Error())
+			}
+		}
+	}
+	groupCol := -1
+	if profile.GroupBy != "" && !k.lazy && !k.editable {
+		groupCol = k.headerIndex(profile.GroupBy, nil)
+	}
+	if groupCol != k.groupCol {
+		k.groupCol, k.collapsed = groupCol, nil
+		k.regroup()
+	}
+	err := k.SetFilter(profile.Filter)
+	k.activeProfile = profile.Name
+	k.resizePage()
+	k.refreshTable()
+	return err
+}
+
+// headerIndex returns the index of header, skipping the columns in used (for duplicated headers).
+func (k *TableRenderer) headerIndex(header string, used map[int]bool) int {
+	for i, h := range k.headers {
+		if h == header && !used[i] {
+			return i
+		}
+	}
+	return -1
+}
+
+// loadProfileStore reads the profiles file.
+func (k *TableRenderer) loadProfileStore() (*tp.TableProfileStore, string, error) {
+	path := k.profilesPath
+	if path == "" {
+		var err error
+		if path, err = tp.DefaultTableProfilesPath(); err != nil {
+			return nil, "", err
+		}
+	}
+	store, err := tp.LoadTableProfiles(path)
+	return store, path, err
+}
+
+// Profiles returns the saved profiles of this table.
+func (k *TableRenderer) Profiles() ([]tp.TableViewProfile, error) {
+	store, _, err := k.loadProfileStore()
+	if err != nil {
+		return nil, err
+	}
+	return store.Profiles(k.TableID()), nil
+}
+
+// LoadProfile applies the saved profile name of this table.
+func (k *TableRenderer) LoadProfile(name string) error {
+	store, _, err := k.loadProfileStore()
+	if err != nil {
+		return err
+	}
+	profile, ok := store.Profile(k.TableID(), name)
+	if !ok {
+		return fmt.Errorf("profile %q not found for table %s", name, k.TableID())
+	}
+	return k.ApplyProfile(profile)
+}
+
+// SaveProfile saves the current view of this table as profile name, replacing a profile with the same name.
+func (k *TableRenderer) SaveProfile(name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return fmt.Errorf("profile name is empty")
+	}
+	store, path, err := k.loadProfileStore()
+	if err != nil {
+		return err
+	}
+	store.SetProfile(k.TableID(), k.CurrentProfile(name))
+	if err := store.Save(path); err != nil {
+		return err
+	}
+	k.activeProfile = name
+	return nil
+}
+
+// DeleteProfile removes the saved profile name of this table.
+func (k *TableRenderer) DeleteProfile(name string) error {
+	store, path, err := k.loadProfileStore()
+	if err != nil {
+		return err
+	}
+	if !store.DeleteProfile(k.TableID(), name) {
+		return fmt.Errorf("profile %q not found for table %s", name, k.TableID())
+	}
+	if k.activeProfile == name {
+		k.activeProfile = ""
+	}
+	return store.Save(path)
+}
+
+// openProfileMenu lists the saved profiles of this table.
+func (k *TableRenderer) openProfileMenu() {
+	profiles, err := k.Profiles()
+	if err != nil {
+		gl.Log("error", "Error loading table profiles: "+err.Error())
+		k.profileErr = err
+	} else {
+		k.profileErr = nil
+	}
+	k.profiles = profiles
+	k.profileMenu = true
+	k.profileCursor = 0
+	for i, profile := range profiles {
+		if profile.Name == k.activeProfile {
+			k.profileCursor = i
+		}
+	}
+}
+
+// cycleProfile loads the next saved profile of this table, wrapping around.
+func (k *TableRenderer) cycleProfile() {
+	profiles, err := k.Profiles()
+	if err != nil || len(profiles) == 0 {
+		return
+	}
+	next := 0
+	for i, profile := range profiles {
+		if profile.Name == k.activeProfile {
+			next = (i + 1) % len(profiles)
+		}
+	}
+	if err := k.ApplyProfile(profiles[next]); err != nil {
+		gl.Log("error", "Error applying table profile: "+err.Error())
+	}
+}
+
+// updateProfileMenu handles keys while the profile menu is open: up/down move, enter loads, s saves the
+// current view under a new name (or over the selected one with S), d deletes and esc closes.
+func (k *TableRenderer) updateProfileMenu(message tea.KeyMsg) tea.Cmd {
+	if k.profileNaming {
+		switch message.String() {
+		case "enter":
+			if err := k.SaveProfile(k.profileName.Value()); err != nil {
+				k.profileErr = err
+				return nil
+			}
+			k.profileNaming = false
+			k.profileName.Blur()
+			k.openProfileMenu()
+		case "esc":
+			k.profileNaming = false
+			k.profileName.Blur()
+		default:
+			var cmd tea.Cmd
+			k.profileName, cmd = k.profileName.Update(message)
+			return cmd
+		}
+		return nil
+	}
+	switch message.String() {
+	case "up", "k":
+		if len(k.profiles) > 0 {
+			k.profileCursor = (k.profileCursor - 1 + len(k.profiles)) % len(k.profiles)
+		}
+	case "down", "j":
+		if len(k.profiles) > 0 {
+			k.profileCursor = (k.profileCursor + 1) % len(k.profiles)
+		}
+	case "enter":
+		if k.profileCursor < len(k.profiles) {
+			if err := k.ApplyProfile(k.profiles[k.profileCursor]); err != nil {
+				k.profileErr = err
+				return nil
+			}
+		}
+		k.profileMenu = false
+	case "s":
+		k.profileNaming = true
+		k.profileName = textinput.New()
+		k.profileName.Prompt = "Profile name: "
+		k.profileName.SetValue(k.activeProfile)
+		k.profileName.CursorEnd()
+		return k.profileName.Focus()
+	case "S":
+		if k.profileCursor < len(k.profiles) {
+			if err := k.SaveProfile(k.profiles[k.profileCursor].Name); err != nil {
+				k.profileErr = err
+				return nil
+			}
+			k.openProfileMenu()
+		}
+	case "d":
+		if k.profileCursor < len(k.profiles) {
+			if err := k.DeleteProfile(k.profiles[k.profileCursor].Name); err != nil {
+				k.profileErr = err
+				return nil
+			}
+			k.openProfileMenu()
+		}
+	case "esc", "q", "v":
+		k.profileMenu = false
+	}
+	return nil
+}
+
+// profileMenuView renders the profile menu modal.
+func (k *TableRenderer) profileMenuView() string {
+	var b strings.Builder
+	b.WriteString(k.styles.header.Render("View profiles") + "  " + blurredStyle.Render(k.TableID()) + "\n\n")
+	if len(k.profiles) == 0 {
+		b.WriteString(blurredStyle.Render("No saved profiles for this table.") + "\n")
+	}
+	for i, profile := range k.profiles {
+		marker := "  "
+		if profile.Name == k.activeProfile {
+			marker = "* "
+		}
+		line := fmt.Sprintf("%s%-20s sort: %d  filter: %s", marker, profile.Name, len(profile.Sort), profile.Filter)
+		if i == k.profileCursor {
+			line = k.styles.selected.Render(line)
+		} else {
+			line = k.styles.base.Render(line)
+		}
+		b.WriteString(line + "\n")
+	}
+	if k.profileNaming {
+		b.WriteString("\n" + k.profileName.View() + "\n")
+	}
+	if k.profileErr != nil {
+		b.WriteString("\n" + errorStyle.Render(k.profileErr.Error()) + "\n")
+	}
+	b.WriteString("\n" + blurredStyle.Render("enter load, s save as, S overwrite, d delete, esc close"))
+	return lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(lipgloss.Color("238")).Padding(0, 1).Render(b.String())
+}
@@ -0,0 +1,103 @@
+package components
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	tp "github.com/kubex-ecosystem/xtui/types"
+)
+
+func TestProfileRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "profiles.yaml")
+	k := newSortTable()
+	k.SetProfilesPath(path)
+	k.SetColumnOrder(2, 0, 1)
+	k.SetColumnWidth(0, 12)
+	k.SetFrozenColumns(1)
+	k.SetPageSize(2)
+	k.SetSortKeys(tp.SortKey{Column: 1}, tp.SortKey{Column: 0, Asc: true})
+	if err := k.SetGroupBy(2); err != nil {
+		t.Fatalf("SetGroupBy: %v", err)
+	}
+	if err := k.SetAggregate(1, tp.AggregateSum); err != nil {
+		t.Fatalf("SetAggregate: %v", err)
+	}
+	if err := k.SetFilter("size>1"); err != nil {
+		t.Fatalf("SetFilter: %v", err)
+	}
+	if err := k.SaveProfile(" biggest "); err != nil {
+		t.Fatalf("SaveProfile: %v", err)
+	}
+	if k.ActiveProfile() != "biggest" {
+		t.Errorf("ActiveProfile() = %q, want biggest", k.ActiveProfile())
+	}
+
+	loaded := newSortTable()
+	loaded.SetProfilesPath(path)
+	if err := loaded.LoadProfile("biggest"); err != nil {
+		t.Fatalf("LoadProfile: %v", err)
+	}
+	if got, want := loaded.CurrentProfile("biggest"), k.CurrentProfile("biggest"); !reflect.DeepEqual(got, want) {
+		t.Errorf("loaded profile = %+v, want %+v", got, want)
+	}
+	if got, want := viewNames(loaded), viewNames(k); !reflect.DeepEqual(got, want) {
+		t.Errorf("loaded rows = %v, want %v", got, want)
+	}
+	if loaded.PageSize() != 2 || loaded.FrozenColumns() != 1 || loaded.ActiveProfile() != "biggest" {
+		t.Errorf("page size %d, frozen %d, active %q", loaded.PageSize(), loaded.FrozenColumns(), loaded.ActiveProfile())
+	}
+
+	if err := loaded.LoadProfile("missing"); err == nil {
+		t.Error("LoadProfile(missing) did not fail")
+	}
+	if err := loaded.SaveProfile("  "); err == nil {
+		t.Error("SaveProfile with an empty name did not fail")
+	}
+	if err := loaded.DeleteProfile("biggest"); err != nil {
+		t.Fatalf("DeleteProfile: %v", err)
+	}
+	if profiles, err := k.Profiles(); err != nil || len(profiles) != 0 || loaded.ActiveProfile() != "" {
+		t.Errorf("after DeleteProfile: profiles %+v, %v, active %q", profiles, err, loaded.ActiveProfile())
+	}
+}
+
+func TestProfileTableID(t *testing.T) {
+	k, same := newSortTable(), newSortTable()
+	other := NewTableRenderer(tp.NewTableHandler([]string{"Name", "Size"}, nil), nil, nil)
+	if k.TableID() != same.TableID() || k.TableID() == other.TableID() {
+		t.Errorf("TableID() = %q, %q and %q: tables with the same headers share profiles", k.TableID(), same.TableID(), other.TableID())
+	}
+	k.SetTableID("packages")
+	if k.TableID() != "packages" {
+		t.Errorf("TableID() = %q, want packages", k.TableID())
+	}
+}
+
+func TestApplyProfileHeaders(t *testing.T) {
+	k := NewTableRenderer(tp.NewTableHandler([]string{"Name", "Size", "Name"}, [][]string{{"vim", "30", "vi"}}), nil, nil)
+	err := k.ApplyProfile(tp.TableViewProfile{
+		Name: "renamed",
+		Columns: []tp.TableColumnProfile{
+			{Header: "Name", Hidden: true},
+			{Header: "Owner"},
+			{Header: "Name", Width: 5},
+		},
+		Sort: []tp.TableSortProfile{{Header: "Owner"}, {Header: "Size", Asc: true}},
+	})
+	if err != nil {
+		t.Fatalf("ApplyProfile: %v", err)
+	}
+	if got, want := k.GetColumnOrder(), []int{0, 2, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GetColumnOrder() = %v, want %v: duplicated headers map in order, missing columns go last", got, want)
+	}
+	if got, want := k.visibleColumns(), []int{2, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("visibleColumns() = %v, want %v", got, want)
+	}
+	if k.columnWidth(2) != 5 {
+		t.Errorf("columnWidth(2) = %d, want 5", k.columnWidth(2))
+	}
+	if got, want := k.GetSortKeys(), []tp.SortKey{{Column: 1, Asc: true}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GetSortKeys() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,63 @@
+package components
+
+import (
+	"github.com/charmbracelet/bubbles/cursor"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// tableMsg is implemented by the messages a TableRenderer sends itself through its commands (toasts,
+// action results, live updates, search scans, exports). Screens holding several tables deliver them
+// to the table they belong to rather than to the one shown when they arrive.
+type tableMsg interface{ tableMsg() }
+
+func (toastExpiredMsg) tableMsg()   {}
+func (bulkActionDoneMsg) tableMsg() {}
+func (ActionResultMsg) tableMsg()   {}
+func (liveUpdateMsg) tableMsg()     {}
+func (livePollMsg) tableMsg()       {}
+func (liveFadeMsg) tableMsg()       {}
+func (searchMatchesMsg) tableMsg()  {}
+func (exportDoneMsg) tableMsg()     {}
+
+// isTableMsg reports whether msg belongs to a table: its own messages and the cursor blinks of its
+// text fields. Other messages (quit, external processes, sequences, messages of the application) are
+// left to the runtime and to the screen.
+func isTableMsg(msg tea.Msg) bool {
+	switch msg.(type) {
+	case tableMsg, cursor.BlinkMsg:
+		return true
+	}
+	return false
+}
+
+// routeTableMsgs wraps with wrap the table messages the commands of a TableRenderer produce, looking
+// into batches, so a screen holding several tables can deliver them to their table.
+func routeTableMsgs(cmd tea.Cmd, wrap func(tea.Msg) tea.Msg) tea.Cmd {
+	if cmd == nil || wrap == nil {
+		return cmd
+	}
+	return func() tea.Msg { return routeTableMsg(cmd(), wrap) }
+}
+
+// routeTableMsg wraps msg for routeTableMsgs.
+func routeTableMsg(msg tea.Msg, wrap func(tea.Msg) tea.Msg) tea.Msg {
+	if batch, ok := msg.(tea.BatchMsg); ok {
+		cmds := make(tea.BatchMsg, len(batch))
+		for i, c := range batch {
+			cmds[i] = routeTableMsgs(c, wrap)
+		}
+		return cmds
+	}
+	if wrap != nil && isTableMsg(msg) {
+		return wrap(msg)
+	}
+	return msg
+}
+
+// setRoute makes the screen holding k wrap the messages of k with wrap: the messages of its commands,
+// routed by the screen with k.route, and those of the external processes its row actions start, which
+// the runtime sends directly.
+func (k *TableRenderer) setRoute(wrap func(tea.Msg) tea.Msg) { k.msgRoute = wrap }
+
+// route wraps the table messages of cmd for the screen holding k, if any.
+func (k *TableRenderer) route(cmd tea.Cmd) tea.Cmd { return routeTableMsgs(cmd, k.msgRoute) }
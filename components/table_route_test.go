@@ -0,0 +1,168 @@
+package components
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	tp "github.com/kubex-ecosystem/xtui/types"
+)
+
+// wrapped is the message a test route wraps table messages in.
+type wrapped struct{ msg tea.Msg }
+
+func wrapTest(msg tea.Msg) tea.Msg { return wrapped{msg} }
+
+func TestRouteTableMsg(t *testing.T) {
+	quit := tea.Quit()
+	tests := []struct {
+		name string
+		msg  tea.Msg
+		want tea.Msg
+	}{
+		{"table message", ActionResultMsg{Message: "done"}, wrapped{ActionResultMsg{Message: "done"}}},
+		{"toast", toastExpiredMsg{id: 2}, wrapped{toastExpiredMsg{id: 2}}},
+		{"key is left alone", tea.KeyMsg{Type: tea.KeyEnter}, tea.KeyMsg{Type: tea.KeyEnter}},
+		{"quit is left alone", quit, quit},
+		{"application message is left alone", "reloaded", "reloaded"},
+		{"nil", nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := routeTableMsg(tt.msg, wrapTest); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("routeTableMsg(%#v) = %#v, want %#v", tt.msg, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRouteTableMsgBatch(t *testing.T) {
+	batch := tea.BatchMsg{
+		func() tea.Msg { return livePollMsg{} },
+		nil,
+		func() tea.Msg { return "app" },
+	}
+	got, ok := routeTableMsg(batch, wrapTest).(tea.BatchMsg)
+	if !ok || len(got) != 3 {
+		t.Fatalf("routeTableMsg(batch) = %#v, want a batch of 3", got)
+	}
+	if msg := got[0](); !reflect.DeepEqual(msg, wrapped{livePollMsg{}}) {
+		t.Errorf("first command of the batch = %#v, want it wrapped", msg)
+	}
+	if got[1] != nil {
+		t.Error("a nil command of the batch was replaced")
+	}
+	if msg := got[2](); msg != "app" {
+		t.Errorf("third command of the batch = %#v, want it left alone", msg)
+	}
+}
+
+func TestRouteTableMsgsWithoutRoute(t *testing.T) {
+	if routeTableMsgs(nil, wrapTest) != nil {
+		t.Error("a nil command was wrapped")
+	}
+	cmd := func() tea.Msg { return ActionResultMsg{} }
+	if msg := routeTableMsgs(cmd, nil)(); !reflect.DeepEqual(msg, ActionResultMsg{}) {
+		t.Errorf("without a route the message became %#v", msg)
+	}
+}
+
+func TestExecCallback(t *testing.T) {
+	failed := errors.New("exit status 1")
+	tests := []struct {
+		name string
+		fn   tea.ExecCallback
+		err  error
+		wrap func(tea.Msg) tea.Msg
+		want tea.Msg
+	}{
+		{
+			name: "callback message is routed",
+			fn:   func(err error) tea.Msg { return ActionResultMsg{Message: "Removed vim", Err: err} },
+			wrap: wrapTest,
+			want: wrapped{ActionResultMsg{Message: "Removed vim"}},
+		},
+		{
+			name: "application message of the callback is left alone",
+			fn:   func(error) tea.Msg { return "done" },
+			wrap: wrapTest,
+			want: "done",
+		},
+		{
+			name: "nil callback reports the failure",
+			err:  failed,
+			wrap: wrapTest,
+			want: wrapped{ActionResultMsg{Message: "Remove", Err: failed}},
+		},
+		{
+			name: "nil callback without failure",
+			wrap: wrapTest,
+		},
+		{
+			name: "callback returning nil",
+			fn:   func(error) tea.Msg { return nil },
+			err:  failed,
+			wrap: wrapTest,
+		},
+		{
+			name: "table outside of a screen",
+			err:  failed,
+			want: ActionResultMsg{Message: "Remove", Err: failed},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := execCallback("Remove", tt.fn, tt.wrap)(tt.err); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("callback returned %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+// loadTabs loads every tab of m, running the commands that build the tables.
+func loadTabs(t *testing.T, m *TabbedTables) {
+	t.Helper()
+	for i := range m.manager.Handlers {
+		msg := m.load(i)()
+		m.Update(msg)
+		if m.Table(i) == nil {
+			t.Fatalf("tab %d did not load: %#v", i, msg)
+		}
+	}
+}
+
+func TestTabbedTablesRouting(t *testing.T) {
+	manager := &tp.MultiTableManager{}
+	manager.Add("Apps", tp.NewTableHandler([]string{"Name"}, [][]string{{"vim"}}))
+	manager.Add("Services", tp.NewTableHandler([]string{"Name"}, [][]string{{"cron"}}))
+	m := NewTabbedTables(manager, nil, nil)
+	loadTabs(t, m)
+
+	// a message of the first tab arriving while the second one is shown
+	msg := m.Table(0).route(func() tea.Msg { return ActionResultMsg{Message: "Removed vim"} })()
+	m.SelectTab(1)
+	m.Update(msg)
+	if toast := m.Table(0).toast; toast == nil || toast.Message != "Removed vim" {
+		t.Errorf("first tab toast = %v, want the action result", toast)
+	}
+	if toast := m.Table(1).toast; toast != nil {
+		t.Errorf("second tab got the toast %q of the first one", toast.Message)
+	}
+
+	// the callback of an external process started by the first tab
+	msg = execCallback("Remove", nil, m.Table(0).msgRoute)(errors.New("exit status 100"))
+	m.Update(msg)
+	if toast := m.Table(0).toast; toast == nil || toast.Message != "Remove: exit status 100" {
+		t.Errorf("first tab toast = %v, want the exec failure", toast)
+	}
+	if toast := m.Table(1).toast; toast != nil {
+		t.Errorf("second tab got the exec failure %q of the first one", toast.Message)
+	}
+
+	// untagged messages go to the tab shown
+	m.Update(ActionResultMsg{Message: "untagged"})
+	if toast := m.Table(1).toast; toast == nil || toast.Message != "untagged" {
+		t.Errorf("second tab toast = %v, want the untagged message", toast)
+	}
+}
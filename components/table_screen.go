@@ -1,187 +1,284 @@
 package components
 
 import (
-	"encoding/csv"
-	"encoding/json"
-	"encoding/xml"
 	"fmt"
 	"os"
-	"sort"
-	"strconv"
-	"strings"
+	"sync/atomic"
+	"time"
 
-	"github.com/atotto/clipboard"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/lipgloss/table"
-	"github.com/johnfercher/maroto/pkg/consts"
-	p "github.com/johnfercher/maroto/pkg/pdf"
-	"github.com/johnfercher/maroto/pkg/props"
 	gl "github.com/kubex-ecosystem/logz"
 	tp "github.com/kubex-ecosystem/xtui/types"
-	"gopkg.in/yaml.v2"
 )
 
 // TableRenderer is responsible for rendering tables in the terminal with customizable styles and dynamic behavior.
 type TableRenderer struct {
-	tbHandler    tp.TableDataHandler
-	kTb          *table.Table
-	headers      []string
-	rows         [][]string
-	filter       string
-	filteredRows [][]string
-	sortColumn   int
-	sortAsc      bool
-	page         int
-	pageSize     int
-	search       string
-	selectedRow  int
-	showHelp     bool
-	visibleCols  map[string]bool
+	tbHandler      tp.TableDataHandler
+	source         tp.TableDataSource
+	lazy           bool
+	window         [][]string
+	windowStart    int
+	kTb            *table.Table
+	headers        []string
+	rows           [][]string
+	filter         string
+	filterExpr     *tp.TableFilter
+	filterErr      error
+	filterInput    textinput.Model
+	filtering      bool
+	filterBefore   string
+	filterHistory  []string
+	historyIndex   int
+	filteredRows   [][]string
+	groupCol       int
+	groups         map[*string]*rowGroup
+	groupView      [][]string
+	collapsed      map[string]bool
+	aggregates     map[int]tp.AggregateFunc
+	footer         []string
+	columnTypes    []tp.ColumnType
+	sortKeys       []tp.SortKey
+	sortPicking    bool
+	focusCol       int
+	page           int
+	pageSize       int
+	fixedPageSize  int
+	gotoPage       bool
+	gotoInput      textinput.Model
+	search         string
+	searching      bool
+	searchBefore   string
+	searchInput    textinput.Model
+	searchMatches  []searchMatch
+	searchIndex    int
+	searchGen      int
+	searchLive     *atomic.Int64
+	searchScanning bool
+	selectedRow    int
+	selection      map[string][]string
+	selectionOrder []string
+	selectAnchor   int
+	rowKey         func(row []string) string
+	bulkActions    []BulkAction
+	bulkMenu       bool
+	bulkCursor     int
+	bulkConfirm    bool
+	rowActions     []RowAction
+	actionMenu     bool
+	actionChoices  []int
+	actionCursor   int
+	actionConfirm  bool
+	showDetail     bool
+	detailFocus    bool
+	detailFormat   DetailFormat
+	detailRenderer DetailRenderer
+	detail         viewport.Model
+	detailKey      string
+	editable       bool
+	editing        bool
+	editInput      textinput.Model
+	editRow        []string
+	editCol        int
+	editErr        error
+	edits          tableEdits
+	columnEditors  map[int]ColumnEditor
+	updates        <-chan tp.TableUpdate
+	livePending    []tp.TableUpdate
+	pollInterval   time.Duration
+	pollGen        int
+	highlightFor   time.Duration
+	highlights     map[string]map[int]time.Time
+	lastUpdate     time.Time
+	width          int
+	height         int
+	showHelp       bool
+	styles         tableStyles
+	cellStyle      StyleFunc
+	formatRules    []tp.TableFormatRule
+	formatMatchers []*tp.TableFormatMatcher
+	layout         []columnLayout
+	pickingCols    bool
+	pickerCursor   int
+	displayCols    []int
+	displayWidths  []int
+	tableWidth     int
+	frozen         int
+	hScroll        int
+	scrollTo       int
+	scrollFirst    int
+	scrollLast     int
+	scrollCols     int
+	pageRows       [][]string
+	pageMarked     []bool
+	tableID        string
+	profilesPath   string
+	activeProfile  string
+	profiles       []tp.TableViewProfile
+	profileMenu    bool
+	profileCursor  int
+	profileNaming  bool
+	profileName    textinput.Model
+	profileErr     error
+	htmlTheme      string
+	csvFooter      bool
+	xmlRoot        string
+	xmlRow         string
+	pdfOptions     tp.PDFOptions
+	exportDialog   *exportForm
+	lastExport     string
+	toast          *Notification
+	toastID        int
+	msgRoute       func(tea.Msg) tea.Msg // set by screens holding several tables (see setRoute)
+}
+
+// tableStyles holds the base styles of the table.
+type tableStyles struct {
+	base     lipgloss.Style
+	header   lipgloss.Style
+	selected lipgloss.Style
+	marked   lipgloss.Style
 }
 
 // StyleFunc defines a function that returns a lipgloss.Style based on row, column, and cell value.
+// Row is the index of the row in the current (filtered and sorted) view and col the index of the
+// column in the source rows, regardless of the column layout.
 type StyleFunc func(row, col int, cellValue string) lipgloss.Style
 
 // NewTableRenderer creates a new TableRenderer with custom styles and an optional style function.
+// Without a style function, cells equal to a key of customStyles (or to a log level: Info, Warning,
+// Error, Debug) are coloured by conditional formatting rules; see SetFormatRules.
 func NewTableRenderer(tbHandler tp.TableDataHandler, customStyles map[string]lipgloss.Color, styleFunc StyleFunc) *TableRenderer {
-	headers := tbHandler.GetHeaders()
-	rows := tbHandler.GetRows()
-	re := lipgloss.NewRenderer(os.Stdout)
-	baseStyle := re.NewStyle().Padding(0, 1)
-	headerStyle := baseStyle.Foreground(lipgloss.Color("252")).Bold(true)
-	selectedStyle := baseStyle.Foreground(lipgloss.Color("#01BE85")).Background(lipgloss.Color("#00432F"))
-
-	defaultTypeColors := map[string]lipgloss.Color{
-		"Info":    lipgloss.Color("#75FBAB"),
-		"Warning": lipgloss.Color("#FDFF90"),
-		"Error":   lipgloss.Color("#FF7698"),
-		"Debug":   lipgloss.Color("#929292"),
-	}
+	k := NewTableRendererFromSource(tp.NewTableSourceFromHandler(tbHandler), customStyles, styleFunc)
+	k.tbHandler = tbHandler
+	return k
+}
 
-	for key, value := range customStyles {
-		defaultTypeColors[key] = value
+// NewTableRendererFromSource creates a new TableRenderer driven by a paged TableDataSource.
+// Sources that keep their rows in memory (TableRowsProvider) are filtered and sorted by the renderer;
+// any other source is read one window at a time and filtering/sorting are delegated to it when it
+// implements TableSourceFilterer/TableSourceSorter.
+func NewTableRendererFromSource(source tp.TableDataSource, customStyles map[string]lipgloss.Color, styleFunc StyleFunc) *TableRenderer {
+	headers := source.GetHeaders()
+	var rows [][]string
+	provider, materialized := source.(tp.TableRowsProvider)
+	if materialized {
+		rows = provider.GetRows()
 	}
-
-	if styleFunc == nil {
-		styleFunc = func(row, col int, cellValue string) lipgloss.Style {
-			if row == 0 {
-				return headerStyle
-			}
-
-			rowIndex := row - 1
-			if rowIndex < 0 || rowIndex >= len(rows) {
-				return baseStyle
-			}
-			if rows != nil && len(rows) < rowIndex {
-				if len(rows[rowIndex]) > 1 {
-					if rows[rowIndex][1] == "Bug" {
-						return selectedStyle
-					}
-				}
-			}
-
-			switch col {
-			case 2, 3:
-				c := defaultTypeColors
-
-				if col >= len(rows[rowIndex]) {
-					return baseStyle
-				}
-
-				color, ok := c[rows[rowIndex][col]]
-				if !ok {
-					return baseStyle
-				}
-				return baseStyle.Foreground(color)
-			}
-			return baseStyle.Foreground(lipgloss.Color("252"))
-		}
-	} else {
-		styleFunc = func(row, col int, cellValue string) lipgloss.Style {
-			if row == 0 {
-				return headerStyle
-			}
-			rowIndex := row - 1
-			if rowIndex < 0 || rowIndex >= len(rows) {
-				return baseStyle
-			}
-			return styleFunc(rowIndex, col, rows[rowIndex][col])
-		}
+	re := lipgloss.NewRenderer(os.Stdout)
+	baseStyle := re.NewStyle().Padding(0, 1)
+	styles := tableStyles{
+		base:     baseStyle,
+		header:   baseStyle.Foreground(lipgloss.Color("252")).Bold(true),
+		selected: baseStyle.Foreground(lipgloss.Color("#01BE85")).Background(lipgloss.Color("#00432F")),
+		marked:   baseStyle.Foreground(lipgloss.Color("#01BE85")).Background(lipgloss.Color("236")).Bold(true),
 	}
 
 	t := table.New().
 		Headers(headers...).
-		Rows(rows...).
 		Border(lipgloss.NormalBorder()).
 		BorderStyle(re.NewStyle().Foreground(lipgloss.Color("238"))).
-		StyleFunc(func(row, col int) lipgloss.Style {
-			if row == 0 {
-				return headerStyle
-			}
-			if row == 1 {
-				return selectedStyle
-			}
-			rowIndex := row - 1
-			if rowIndex < 0 || len(rows) <= rowIndex {
-				return baseStyle
-			}
-			if rows != nil && rowIndex < len(rows) {
-				if len(rows[rowIndex]) > col &&
-					len(rows[rowIndex]) > 1 {
-					if rows[rowIndex][1] == "Bug" {
-						return selectedStyle
-					}
-				}
-			}
-			if col >= len(rows[rowIndex]) {
-				return baseStyle
-			}
-
-			return styleFunc(row, col, rows[row][col])
-			//return styleFunc(row, col, rows[rowIndex][col])
-		}).
 		Border(lipgloss.ThickBorder())
 
-	pageSizeLimitStr := os.Getenv("KBX_PAGE_SIZE_LIMIT")
-	if pageSizeLimitStr != "" {
-		pageSizeLimitStr = os.Getenv("LINES")
-		if pageSizeLimitStr == "" {
-			pageSizeLimitStr = "20"
+	k := &TableRenderer{
+		source:        source,
+		lazy:          !materialized,
+		kTb:           t,
+		headers:       headers,
+		rows:          rows,
+		filteredRows:  append([][]string(nil), rows...),
+		page:          0,
+		pageSize:      defaultPageSize,
+		fixedPageSize: pageSizeFromEnv(),
+		search:        "",
+		searchInput:   newSearchInput(),
+		searchLive:    new(atomic.Int64),
+		selectedRow:   -1,
+		selectAnchor:  -1,
+		groupCol:      -1,
+		scrollTo:      -1,
+		highlightFor:  defaultHighlightDuration,
+		detail:        viewport.New(0, 0),
+		showHelp:      false,
+		styles:        styles,
+		cellStyle:     styleFunc,
+		layout:        newColumnLayout(len(headers)),
+		filterInput:   newFilterInput(),
+		htmlTheme:     tp.DefaultHTMLTheme,
+	}
+	if streamer, ok := source.(tp.TableSourceStreamer); ok {
+		k.updates = streamer.Updates()
+	}
+	k.columnTypes = k.resolveColumnTypes()
+	if styleFunc == nil {
+		k.formatRules = defaultFormatRules(customStyles)
+		k.formatMatchers, _ = k.compileFormatRules(k.formatRules)
+	}
+	k.kTb = k.kTb.StyleFunc(k.tableStyle)
+	k.resizePage()
+	k.refreshTable()
+	return k
+}
+
+// resolveColumnTypes takes the column types declared by the source (or the handler behind it) and
+// auto-detects the missing ones from the loaded rows, or from the first window of lazy sources.
+func (k *TableRenderer) resolveColumnTypes() []tp.ColumnType {
+	var declared []tp.ColumnType
+	if schema, ok := k.source.(tp.TableSchemaProvider); ok {
+		declared = schema.GetColumnTypes()
+	} else if adapter, ok := k.source.(*tp.TableHandlerSource); ok {
+		if schema, ok := adapter.Handler.(tp.TableSchemaProvider); ok {
+			declared = schema.GetColumnTypes()
 		}
 	}
-	pageSizeLimit, pageSizeLimitErr := strconv.Atoi(pageSizeLimitStr)
-	if pageSizeLimitErr == nil {
-		pageSizeLimit = 20
-	} else if pageSizeLimit < 1 {
-		pageSizeLimit = 20
+	sample := k.rows
+	if k.lazy {
+		sample = k.rowsRange(0, 200)
+	}
+	detected := tp.DetectColumnTypes(len(k.headers), sample)
+	for i := range detected {
+		if i < len(declared) && declared[i] != "" {
+			detected[i] = declared[i]
+		}
 	}
+	return detected
+}
 
-	visibleCols := make(map[string]bool)
-	for _, header := range headers {
-		visibleCols[header] = true
+// SetColumnTypes overrides the column types used for sorting and filtering. Empty entries keep the current type.
+func (k *TableRenderer) SetColumnTypes(columnTypes ...tp.ColumnType) {
+	for i, ct := range columnTypes {
+		if i < len(k.columnTypes) && ct != "" {
+			k.columnTypes[i] = ct
+		}
+	}
+	if len(k.sortKeys) > 0 {
+		k.SortRows()
 	}
+}
 
-	return &TableRenderer{
-		tbHandler:    tbHandler,
-		kTb:          t,
-		headers:      headers,
-		rows:         rows,
-		filteredRows: rows,
-		sortColumn:   -1,
-		sortAsc:      true,
-		page:         0,
-		pageSize:     pageSizeLimit,
-		search:       "",
-		selectedRow:  -1,
-		showHelp:     false,
-		visibleCols:  visibleCols,
+// GetColumnTypes returns the type of every column.
+func (k *TableRenderer) GetColumnTypes() []tp.ColumnType { return k.columnTypes }
+
+// columnType returns the type of column col, falling back to string.
+func (k *TableRenderer) columnType(col int) tp.ColumnType {
+	if col >= 0 && col < len(k.columnTypes) {
+		return k.columnTypes[col]
 	}
+	return tp.ColumnString
 }
 
-// Init initializes the table renderer.
+// Init initializes the table renderer, starting the live updates when configured.
 func (k *TableRenderer) Init() tea.Cmd {
-	return nil
+	return k.liveCmd()
+}
+
+// capturingKeys reports whether a prompt, menu or dialog of the table takes the keys typed.
+func (k *TableRenderer) capturingKeys() bool {
+	return k.editing || k.gotoPage || k.exportDialog != nil || k.filtering || k.searching || k.actionMenu || k.bulkMenu ||
+		k.profileMenu || k.profileNaming || k.pickingCols || k.sortPicking
 }
 
 // Update updates the table renderer based on user input.
@@ -189,102 +286,343 @@ func (k *TableRenderer) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 	switch message := msg.(type) {
 	case tea.WindowSizeMsg:
-		k.kTb = k.kTb.Width(message.Width)
-		k.kTb = k.kTb.Height(message.Height)
+		k.width, k.height = message.Width, message.Height
+		k.applyWidth()
+		k.resizePage()
+	case toastExpiredMsg:
+		if message.id == k.toastID {
+			k.toast = nil
+		}
+	case bulkActionDoneMsg:
+		cmd = k.bulkActionDone(message)
+	case ActionResultMsg:
+		cmd = k.actionResult(message)
+	case liveUpdateMsg:
+		cmd = k.liveUpdate(message)
+	case livePollMsg:
+		cmd = k.livePoll(message)
+	case liveFadeMsg:
+		k.fadeHighlights()
+	case exportDoneMsg:
+		cmd = k.exportDone(message)
+	case searchMatchesMsg:
+		cmd = k.searchScanned(message)
 	case tea.KeyMsg:
-		switch message.String() {
-		case "q", "ctrl+c":
-			return k, tea.Quit
-		case "enter":
-			k.ApplyFilter()
-			if k.selectedRow >= 0 && k.selectedRow < len(k.filteredRows) {
-				row := k.filteredRows[k.selectedRow]
-				_ = clipboard.WriteAll(strings.Join(row, "\t"))
-			}
-		case "backspace":
-			if len(k.filter) > 0 {
-				k.filter = k.filter[:len(k.filter)-1]
-			}
-		case "esc":
-			k.selectedRow = -1
-		case "ctrl+o":
-			k.sortColumn = (k.sortColumn + 1) % len(k.headers)
-			k.sortAsc = !k.sortAsc
-			k.SortRows()
-		case "right":
-			if (k.page+1)*k.pageSize < len(k.filteredRows) {
-				k.page++
-			}
-		case "left":
-			if k.page > 0 {
-				k.page--
-			}
-		case "down":
-			_ = k.RowsNavigate("down")
-		case "up":
-			_ = k.RowsNavigate("up")
-		case "ctrl+e":
-			k.ExportToCSV("exported_data.csv")
-		case "ctrl+h":
-			k.showHelp = !k.showHelp
-		case "ctrl+y":
-			k.ExportToYAML("exported_data.yaml")
-		case "ctrl+j":
-			k.ExportToJSON("exported_data.json")
-		case "ctrl+x":
-			k.ExportToXML("exported_data.xml")
-		case "ctrl+l":
-			k.ExportToExcel("exported_data.xlsx")
-		case "ctrl+p":
-			k.ExportToPDF("exported_data.pdf")
-		case "ctrl+m":
-			k.ExportToMarkdown("exported_data.md")
-		case "ctrl+k":
-			k.ToggleColumnVisibility()
-		default:
-			k.filter += message.String()
+		if k.editing {
+			cmd = k.updateEditMode(message)
+			k.refreshTable()
+			return k, cmd
+		}
+		if k.gotoPage {
+			cmd = k.updateGotoPage(message)
+			k.refreshTable()
+			return k, cmd
+		}
+		if k.exportDialog != nil {
+			cmd = k.updateExportDialog(message)
+			k.refreshTable()
+			return k, cmd
+		}
+		if k.filtering {
+			cmd = k.updateFilterMode(message)
+			k.refreshTable()
+			return k, cmd
+		}
+		if k.searching {
+			cmd = k.updateSearchMode(message)
+			k.refreshTable()
+			return k, cmd
+		}
+		if k.actionMenu {
+			cmd = k.updateActionMenu(message)
+			k.refreshTable()
+			return k, cmd
+		}
+		if k.bulkMenu {
+			cmd = k.updateBulkMenu(message)
+			k.refreshTable()
+			return k, cmd
+		}
+		if k.profileMenu {
+			cmd = k.updateProfileMenu(message)
+			k.refreshTable()
+			return k, cmd
+		}
+		if k.pickingCols {
+			k.updateColumnPicker(message)
+			k.refreshTable()
+			return k, nil
+		}
+		if k.sortPicking {
+			cmd = k.updateSortPicker(message)
+			k.refreshTable()
+			return k, cmd
+		}
+		if k.showDetail && k.detailFocus {
+			cmd = k.updateDetailPane(message)
+			k.refreshTable()
+			return k, cmd
+		}
+		cmd = k.handleKey(message.String())
+	default:
+		if k.editing {
+			cmd = k.updateEditMode(msg)
+		} else if k.gotoPage {
+			cmd = k.updateGotoPage(msg)
+		} else if k.exportDialog != nil {
+			k.exportDialog.path, cmd = k.exportDialog.path.Update(msg)
+		} else if k.filtering {
+			cmd = k.updateFilterMode(msg)
+		} else if k.searching {
+			cmd = k.updateSearchMode(msg)
+		} else if k.profileNaming {
+			k.profileName, cmd = k.profileName.Update(msg)
 		}
 	}
-	k.kTb.ClearRows()                             // Clear the table rows before adding new ones
-	k.kTb = k.kTb.Rows(k.GetCurrentPageRows()...) // Update the table with the current rows
+	k.refreshTable()
 	return k, cmd
 }
 
-// View returns the string representation of the table for rendering.
-func (k *TableRenderer) View() string {
+// refreshTable pushes the current headers and page rows, projected on the column layout, to the underlying table.
+func (k *TableRenderer) refreshTable() {
+	k.pageRows = k.GetCurrentPageRows()
+	k.pageMarked = make([]bool, len(k.pageRows))
+	for i, row := range k.pageRows {
+		k.pageMarked[i] = k.isSelected(row)
+	}
+	if k.editing {
+		k.scrollTo = k.editCol
+	}
+	k.displayCols, k.displayWidths = k.scrollWindow()
+	k.kTb = k.kTb.Headers(k.projectCells(k.headerLabels())...)
+	k.kTb.ClearRows() // Clear the table rows before adding new ones
+	for _, row := range k.pageRows {
+		cells := k.projectCells(row)
+		if k.groupOf(row) != nil {
+			cells = k.groupCells(row, cells)
+		}
+		if k.editing && rowID(row) == rowID(k.editRow) {
+			for i, col := range k.displayCols {
+				if col == k.editCol {
+					if width := k.displayWidths[i]; width > 0 {
+						k.editInput.Width = max(width-1, 1)
+					}
+					cells[i] = k.editInput.View()
+				}
+			}
+		}
+		k.kTb = k.kTb.Row(cells...) // Update the table with the current rows
+	}
+	if k.footer != nil {
+		k.kTb = k.kTb.Row(k.footerCells()...)
+	}
+}
+
+// tableStyle is the style function of the underlying table. Row and col are relative to the rendered
+// page and to the projected columns.
+func (k *TableRenderer) tableStyle(row, col int) lipgloss.Style {
+	srcCol, width := col, 0
+	if col < len(k.displayCols) {
+		srcCol, width = k.displayCols[col], k.displayWidths[col]
+	}
+	var style lipgloss.Style
+	if row == table.HeaderRow {
+		style = k.styles.header
+	} else if row == len(k.pageRows) && k.footer != nil {
+		style = k.footerStyle()
+	} else {
+		var record []string
+		if row >= 0 && row < len(k.pageRows) {
+			record = k.pageRows[row]
+		}
+		viewRow := k.page*k.pageSize + row
+		style = k.editedCellStyle(k.styleCell(viewRow, srcCol, cellAt(record, srcCol), record), record, srcCol)
+		if k.groupOf(record) != nil {
+			style = k.groupStyle()
+		}
+		if row >= 0 && row < len(k.pageMarked) && k.pageMarked[row] {
+			style = k.styles.marked
+		}
+		if viewRow == k.selectedRow {
+			style = k.styles.selected
+		}
+		style = k.searchCellStyle(style, viewRow, record, srcCol)
+		style = k.highlightedCellStyle(style, record, srcCol)
+	}
+	if width > 0 {
+		style = style.Width(width + style.GetHorizontalPadding())
+	}
+	return style
+}
+
+// styleCell returns the style of a data cell: the caller's StyleFunc when given, otherwise the base
+// style, then the conditional formatting rules matching the cell.
+func (k *TableRenderer) styleCell(row, col int, value string, record []string) lipgloss.Style {
+	style := k.styles.base.Foreground(lipgloss.Color("252"))
+	if k.cellStyle != nil {
+		style = k.cellStyle(row, col, value)
+	}
+	return k.formatCell(style, col, value, record)
+}
+
+// helpText returns the shortcuts listed by ctrl+h.
+func (k *TableRenderer) helpText() string {
 	helpText := "\nShortcuts:\n" +
 		"  - q, ctrl+c: Quit\n" +
-		"  - enter: Copy selected row to clipboard\n" +
-		"  - esc: Exit selection mode\n" +
-		"  - backspace: Remove last character from filter\n" +
-		"  - ctrl+o: Toggle sorting\n" +
-		"  - right: Next page\n" +
-		"  - left: Previous page\n" +
+		"  - enter: Copy selected rows (or the current row) to clipboard\n" +
+		"  - esc: Clear the search, then the selection, then exit selection mode\n" +
+		"  - space: Select/unselect the current row\n" +
+		"  - shift+up/down: Extend the selection\n" +
+		"  - ctrl+a: Select/unselect all rows matching the filter\n" +
+		"  - b: Bulk actions on the selected rows\n" +
+		"  - m: Actions on the current row\n" +
+		"  - d: Show/hide the row detail pane (D: fields/JSON/YAML, tab: scroll it)\n"
+	if k.canFilter() {
+		helpText += "  - /: Filter mode (enter apply, esc cancel, up/down history)\n"
+	}
+	helpText += "  - ctrl+f: Search, highlighting matches (typos allowed), n/N: Next/previous match\n"
+	if k.canFilter() {
+		helpText += "  - filter syntax: words, col=value, col!=value, col>=value, col~regex, col:text, and/or/not, ( )\n"
+	}
+	if k.canSort() {
+		helpText += "  - ctrl+o: Sort mode (focus a header, then enter/a/d/space/p/c; g: group by it, t: cycle its total)\n"
+	}
+	if !k.canFilter() || !k.canSort() {
+		helpText += "  - rows are read on demand from the source, which cannot " + unsupportedViewOps(k.canFilter(), k.canSort()) + "\n"
+	}
+	if !k.lazy {
+		helpText += "  - z: Collapse/expand the current group, Z: all groups (enter on a group header too)\n"
+	}
+	helpText += "  - right, pgdown: Next page\n" +
+		"  - left, pgup: Previous page\n" +
+		"  - home/end: First/last page, ctrl+g: Go to page\n" +
 		"  - down: Select next row\n" +
 		"  - up: Select previous row\n" +
-		"  - ctrl+e: Export to CSV\n" +
-		"  - ctrl+y: Export to YAML\n" +
-		"  - ctrl+j: Export to JSON\n" +
-		"  - ctrl+x: Export to XML\n" +
-		"  - ctrl+l: Export to Excel\n" +
-		"  - ctrl+p: Export to PDF\n" +
-		"  - ctrl+m: Export to Markdown\n" +
-		"  - ctrl+k: Toggle column visibility\n"
+		"  - shift+left/right: Scroll the columns\n" +
+		"  - F: Freeze one more leading column (cycles back to none)\n" +
+		"  - ctrl+s: Export dialog (format, rows, path)\n" +
+		exportKeysHelp() +
+		"  - ctrl+k: Column picker (show/hide, reorder, pin widths)\n" +
+		"  - v: View profiles (load, save, delete)\n" +
+		"  - V: Switch to the next view profile\n"
+	if k.editable {
+		helpText += "  - e: Edit the current cell (enter save, esc cancel, tab next column)\n" +
+			"  - ctrl+n: Add a row, delete: Delete the current row, ctrl+z: Undo\n"
+	}
+	return helpText
+}
+
+// unsupportedViewOps names what a lazy source cannot do, for the help text.
+func unsupportedViewOps(canFilter, canSort bool) string {
+	switch {
+	case !canFilter && !canSort:
+		return "filter or sort them"
+	case !canFilter:
+		return "filter them"
+	default:
+		return "sort them"
+	}
+}
 
+// View returns the string representation of the table for rendering.
+func (k *TableRenderer) View() string {
 	toggleHelpText := "\nPress ctrl+h to show/hide shortcuts."
+	if k.sortPicking && k.focusCol < len(k.headers) {
+		toggleHelpText = fmt.Sprintf("\nSort: %s (%s) - left/right/1-9 focus, enter sort by, a add key, d remove key, space asc/desc, p make primary, c clear, g group by, t totals, esc done.", k.headers[k.focusCol], k.columnType(k.focusCol))
+	}
+
+	filterText := k.filter
+	if k.filtering {
+		filterText = k.filterInput.View()
+		toggleHelpText = "\nenter: apply filter, esc: cancel, up/down: history."
+	}
+	if k.filterErr != nil {
+		filterText += "  " + errorStyle.Render("✗ "+k.filterErr.Error())
+	}
+
+	if k.pickingCols {
+		return "\n" + k.columnPickerView() + "\n"
+	}
+	if k.profileMenu {
+		return "\n" + k.profileMenuView() + "\n"
+	}
+	if k.exportDialog != nil {
+		return "\n" + k.exportDialogView() + "\n"
+	}
+	if k.bulkMenu {
+		return "\n" + k.bulkMenuView() + "\n"
+	}
+	if k.actionMenu {
+		return "\n" + k.actionMenuView() + "\n"
+	}
+
+	matches, total := k.matchCount()
+	status := fmt.Sprintf("Page: %d/%d", k.CurrentPage(), k.PageCount())
+	if total >= 0 {
+		status += fmt.Sprintf(" | Matches: %d/%d", matches, total)
+	} else {
+		status += fmt.Sprintf(" | Rows: %d", matches)
+	}
+	if n := k.SelectedCount(); n > 0 {
+		status += fmt.Sprintf(" | Selected: %d", n)
+	}
+	if groups := k.groupStatus(); groups != "" {
+		status += " | " + groups
+	}
+	if k.activeProfile != "" {
+		status += " | Profile: " + k.activeProfile
+	}
+	if live := k.liveStatus(); live != "" {
+		status += " | " + live
+	}
+	if scroll := k.scrollStatus(); scroll != "" {
+		status += " | " + scroll
+	}
+	if search := k.searchStatus(); search != "" {
+		status += " | " + search
+	}
+	if !k.showDetail && k.rowTruncated(k.SelectedRow()) {
+		status += " | d: full values"
+	}
+	if summary := k.diffSummary(); summary != "" {
+		status += " | " + summary
+	}
+	if k.toast != nil {
+		status += "  " + notificationStyle(k.toast.Type).Render(k.toast.Message)
+	}
+	if k.gotoPage {
+		status = k.gotoPageView()
+	}
+	if k.searching {
+		status = k.searchInput.View()
+		toggleHelpText = "\nenter: search and go to the best match, esc: cancel."
+	}
 
+	if k.showDetail && k.detailFocus {
+		toggleHelpText = "\nDetails: up/down/pgup/pgdown scroll, D format, tab/esc back to the table, d close."
+	}
+	if k.editing {
+		toggleHelpText = fmt.Sprintf("\nEdit %s: enter save, esc cancel, tab/shift+tab next/previous column, up/down next/previous row.", cellAt(k.headers, k.editCol))
+		if k.editErr != nil {
+			toggleHelpText += "  " + errorStyle.Render("✗ "+k.editErr.Error())
+		}
+	}
+	tableView := k.withDetailPane(k.kTb.String())
 	if k.showHelp {
-		return fmt.Sprintf("\nFilter: %s\n\n%s\nPage: %d/%d\n%s%s", k.filter, k.kTb.String(), k.page+1, (len(k.filteredRows)+k.pageSize-1)/k.pageSize, helpText, toggleHelpText)
+		return fmt.Sprintf("\nFilter: %s\n\n%s\n%s\n%s%s", filterText, tableView, status, k.helpText(), toggleHelpText)
 	}
-	return fmt.Sprintf("\nFilter: %s\n\n%s\nPage: %d/%d\n%s", k.filter, k.kTb.String(), k.page+1, (len(k.filteredRows)+k.pageSize-1)/k.pageSize, toggleHelpText)
+	return fmt.Sprintf("\nFilter: %s\n\n%s\n%s\n%s", filterText, tableView, status, toggleHelpText)
 }
 
 // GetHeaders returns the table headers.
 func (k *TableRenderer) GetHeaders() []string { return k.headers }
 
-// GetRows returns the table rows.
+// GetRows returns the table rows. Lazy sources never materialise their rows, so it returns nil for them.
 func (k *TableRenderer) GetRows() [][]string { return k.rows }
 
+// GetSource returns the data source driving the table.
+func (k *TableRenderer) GetSource() tp.TableDataSource { return k.source }
+
 // GetArrayMap returns the table data as a map of arrays.
 func (k *TableRenderer) GetArrayMap() map[string][]string {
 	m := make(map[string][]string)
@@ -303,11 +641,16 @@ func (k *TableRenderer) GetHashMap() map[string]string {
 	return m
 }
 
-// GetObjectMap returns the table data as a slice of maps.
+// GetObjectMap returns the table data as a slice of header→value records.
 func (k *TableRenderer) GetObjectMap() []map[string]string {
+	keys := tp.RecordKeys(k.headers)
 	var m []map[string]string
 	for _, row := range k.rows {
-		m = append(m, map[string]string{row[0]: row[1]})
+		record := make(map[string]string, len(keys))
+		for i, key := range keys {
+			record[key] = cellAt(row, i)
+		}
+		m = append(m, record)
 	}
 	return m
 }
@@ -321,7 +664,7 @@ func (k *TableRenderer) GetByteMap() map[string][]byte {
 	return m
 }
 
-// RowsNavigate navigates through the table rows.
+// RowsNavigate navigates through the table rows, moving to the page of the selected row.
 func (k *TableRenderer) RowsNavigate(direction string) error {
 	if direction == "down" {
 		k.selectedRow++
@@ -332,223 +675,256 @@ func (k *TableRenderer) RowsNavigate(direction string) error {
 	if k.selectedRow < 0 {
 		k.selectedRow = 0
 	}
-	if k.selectedRow >= len(k.filteredRows) {
-		k.selectedRow = len(k.filteredRows) - 1
+	if k.selectedRow >= k.rowCount() {
+		k.selectedRow = k.rowCount() - 1
 	}
-
-	if k.selectedRow >= 0 && len(k.filteredRows) > 0 {
-		k.kTb.StyleFunc(func(row, col int) lipgloss.Style {
-			if row == k.selectedRow {
-				return lipgloss.NewStyle().Foreground(lipgloss.Color("#01BE85")).Background(lipgloss.Color("#00432F"))
-			}
-			return lipgloss.NewStyle().Foreground(lipgloss.Color("252"))
-		})
-	} else if k.selectedRow == 0 {
-		k.kTb.StyleFunc(func(row, col int) lipgloss.Style {
-			if row == 1 {
-				return lipgloss.NewStyle().Foreground(lipgloss.Color("#01BE85")).Background(lipgloss.Color("#00432F"))
-			}
-			return lipgloss.NewStyle().Foreground(lipgloss.Color("252"))
-		})
-	} else {
-		k.kTb.StyleFunc(func(row, col int) lipgloss.Style {
-			return lipgloss.NewStyle().Foreground(lipgloss.Color("252"))
-		})
+	if k.selectedRow >= 0 {
+		k.page = k.selectedRow / k.pageSize
 	}
 	return nil
 }
 
-// ApplyFilter applies a filter to the table rows.
+// ApplyFilter parses the filter expression (see types.TableFilter) and applies it to the table rows.
+// When the expression does not parse, the error is kept for display and the previous view is left untouched.
 func (k *TableRenderer) ApplyFilter() {
-	if k.filter == "" {
-		k.filteredRows = k.rows
-	} else {
-		var filtered [][]string
-		for _, row := range k.rows {
-			for _, cell := range row {
-				if strings.Contains(strings.ToLower(cell), strings.ToLower(k.filter)) {
-					filtered = append(filtered, row)
-					break
-				}
+	if k.lazy {
+		k.filterErr = nil
+		if filterer, ok := k.source.(tp.TableSourceFilterer); ok {
+			if err := filterer.SetFilter(k.filter); err != nil {
+				k.filterErr = err
+				gl.Log("error", "Error filtering table source: "+err.Error())
 			}
+		} else if k.filter != "" {
+			gl.Log("warn", "Table source does not support filtering")
 		}
-		k.filteredRows = filtered
+		k.resetWindow()
+		k.clampPage()
+		k.kTb = k.kTb.Rows(k.GetCurrentPageRows()...)
+		return
+	}
+	expr, err := tp.ParseTableFilter(k.filter, k.headers, k.columnTypes)
+	if err != nil {
+		k.filterErr = err
+		return
 	}
+	k.filterExpr, k.filterErr = expr, nil
+	k.filteredRows = k.filterRows()
+	k.clampPage()
+	if len(k.sortKeys) > 0 {
+		k.SortRows()
+		return
+	}
+	k.regroup()
 	k.kTb = k.kTb.Rows(k.GetCurrentPageRows()...)
 }
 
-// SortRows sorts the table rows.
-func (k *TableRenderer) SortRows() {
-	sort.SliceStable(k.filteredRows, func(i, j int) bool {
-		if k.sortAsc {
-			return k.filteredRows[i][k.sortColumn] < k.filteredRows[j][k.sortColumn]
+// filterRows returns the rows matching the applied filter expression, in their natural order.
+func (k *TableRenderer) filterRows() [][]string {
+	if k.filterExpr == nil {
+		return append([][]string(nil), k.rows...)
+	}
+	var filtered [][]string
+	for _, row := range k.rows {
+		if k.filterExpr.Match(row) {
+			filtered = append(filtered, row)
 		}
-		return k.filteredRows[i][k.sortColumn] > k.filteredRows[j][k.sortColumn]
-	})
-	k.kTb = k.kTb.Rows(k.GetCurrentPageRows()...)
+	}
+	return filtered
 }
 
-// GetCurrentPageRows returns the rows for the current page.
-func (k *TableRenderer) GetCurrentPageRows() [][]string {
-	start := k.page * k.pageSize
-	end := start + k.pageSize
-	if end > len(k.filteredRows) {
-		end = len(k.filteredRows)
+// SetFilter sets and applies a filter expression, returning its parse error if any.
+// It lets callers open the table pre-filtered, e.g. SetFilter("status=installed and version>=1.2").
+func (k *TableRenderer) SetFilter(expr string) error {
+	k.filter = expr
+	k.ApplyFilter()
+	if k.filterErr == nil {
+		k.refreshTable()
 	}
-	return k.filteredRows[start:end]
+	return k.filterErr
 }
 
-// ExportToCSV exports the table data to a CSV file.
-func (k *TableRenderer) ExportToCSV(filename string) {
-	file, err := os.Create(filename)
-	if err != nil {
-		gl.Log("error", "Error creating file: "+err.Error())
-		return
+// Reload re-reads the rows from the source, keeping the filter, sort, page and selection. Sources
+// that cache their rows are asked to drop the cache first (see types.TableSourceReloader). Pending
+// edits are discarded.
+func (k *TableRenderer) Reload() error {
+	if reloader, ok := k.source.(tp.TableSourceReloader); ok {
+		if err := reloader.Reload(); err != nil {
+			gl.Log("error", "Error reloading table source: "+err.Error())
+			return err
+		}
+	}
+	if provider, ok := k.source.(tp.TableRowsProvider); ok && !k.lazy {
+		k.rows = provider.GetRows()
 	}
-	defer func(file *os.File) {
-		_ = file.Close()
-	}(file)
+	if k.editable {
+		k.SetEditable(true) // edit a fresh copy of the reloaded rows
+		return nil
+	}
+	k.resetWindow()
+	k.ApplyFilter()
+	k.refreshTable()
+	return nil
+}
 
-	writer := csv.NewWriter(file)
-	defer writer.Flush()
+// GetFilter returns the current filter expression.
+func (k *TableRenderer) GetFilter() string { return k.filter }
 
-	// Write headers
-	if writerErr := writer.Write(k.headers); writerErr != nil {
-		gl.Log("error", "Error writing headers to CSV:"+writerErr.Error())
-		return
+// clampPage keeps the current page and selection inside the view after it shrinks.
+func (k *TableRenderer) clampPage() {
+	total := k.rowCount()
+	if k.page*k.pageSize >= total {
+		k.page = 0
 	}
-
-	// Write rows
-	for _, row := range k.filteredRows {
-		if writerRowsErr := writer.Write(row); writerRowsErr != nil {
-			gl.Log("error", "Error writing row to CSV: "+writerRowsErr.Error())
-			return
-		}
+	if k.selectedRow >= total {
+		k.selectedRow = total - 1
 	}
-
-	gl.Log("info", "Data exported to CSV:"+filename)
 }
 
-// ExportToYAML exports the table data to a YAML file.
-func (k *TableRenderer) ExportToYAML(filename string) {
-	file, err := os.Create(filename)
-	if err != nil {
-		gl.Log("error", "Error creating file:"+err.Error())
-		return
-	}
-	defer func(file *os.File) {
-		_ = file.Close()
-	}(file)
-	data := k.GetObjectMap()
-	encoder := yaml.NewEncoder(file)
-	defer func(encoder *yaml.Encoder) {
-		_ = encoder.Close()
-	}(encoder)
-
-	if err := encoder.Encode(data); err != nil {
-		gl.Log("error", "Error writing data to YAML:"+err.Error())
-		return
+// cellAt returns row[col], or an empty string for short rows.
+func cellAt(row []string, col int) string {
+	if col >= 0 && col < len(row) {
+		return row[col]
 	}
+	return ""
+}
 
-	gl.Log("info", "Data exported to YAML:"+filename)
+// GetCurrentPageRows returns the rows for the current page.
+func (k *TableRenderer) GetCurrentPageRows() [][]string {
+	return k.rowsRange(k.page*k.pageSize, (k.page+1)*k.pageSize)
 }
 
-// ExportToJSON exports the table data to a JSON file.
-func (k *TableRenderer) ExportToJSON(filename string) {
-	file, err := os.Create(filename)
-	if err != nil {
-		gl.Log("error", "Error creating file:"+err.Error())
-		return
-	}
-	defer func(file *os.File) {
-		_ = file.Close()
-	}(file)
-	data := k.GetObjectMap()
-	encoder := json.NewEncoder(file)
-	if err := encoder.Encode(data); err != nil {
-		gl.Log("error", "Error writing data to JSON:"+err.Error())
-		return
+// rowCount returns the number of rows in the current (filtered) view.
+func (k *TableRenderer) rowCount() int {
+	if k.lazy {
+		return k.source.RowCount()
 	}
-	gl.Log("info", "Data exported to JSON:"+filename)
+	return len(k.viewRows())
 }
 
-// ExportToXML exports the table data to an XML file.
-func (k *TableRenderer) ExportToXML(filename string) {
-	file, err := os.Create(filename)
-	if err != nil {
-		gl.Log("error", "Error creating file:"+err.Error())
-		return
+// rowsRange returns the rows [start, end) of the current view. Lazy sources are only asked for
+// the window, which is cached until the view moves outside of it.
+func (k *TableRenderer) rowsRange(start, end int) [][]string {
+	if total := k.rowCount(); end > total {
+		end = total
+	}
+	if start < 0 || start >= end {
+		return nil
+	}
+	if !k.lazy {
+		return k.viewRows()[start:end]
 	}
-	defer func(file *os.File) {
-		_ = file.Close()
-	}(file)
-	encoder := xml.NewEncoder(file)
-	encoder.Indent("", "  ") // Indent for better readability
-	vstr := xml.StartElement{Name: xml.Name{Local: "table"}}
-	defer func(encoder *xml.Encoder) {
-		if err := encoder.EncodeToken(xml.EndElement{Name: vstr.Name}); err != nil {
-			gl.Log("error", "Error writing end token to XML:"+err.Error())
+	if start < k.windowStart || end > k.windowStart+len(k.window) {
+		rows, err := k.source.FetchRows(start, end-start)
+		if err != nil {
+			gl.Log("error", "Error fetching rows from table source: "+err.Error())
+			return nil
 		}
-	}(encoder)
-	if err := encoder.EncodeToken(vstr); err != nil {
-		gl.Log("error", "Error writing start token to XML:"+err.Error())
-		return
+		k.window, k.windowStart = rows, start
 	}
-	gl.Log("info", "Data exported to XML:"+filename)
+	return k.window[start-k.windowStart : end-k.windowStart]
 }
 
-// ExportToExcel is a placeholder for exporting the table data to an Excel file.
-func (k *TableRenderer) ExportToExcel(filename string) {
-	// Implementation for exporting to Excel
+// rowAt returns the row at index i of the current view, or nil when out of range.
+func (k *TableRenderer) rowAt(i int) []string {
+	rows := k.rowsRange(i, i+1)
+	if len(rows) == 0 {
+		return nil
+	}
+	return rows[0]
 }
 
-// ExportToPDF exports the table data to a PDF file.
-func (k *TableRenderer) ExportToPDF(filename string) {
-	m := p.NewMaroto(consts.Landscape, consts.Letter)
-	m.SetBorder(true)
-
-	// Add headers
-	m.Row(10, func() {
-		for _, header := range k.headers {
-			w := uint(12 / len(k.headers))
-			m.Col(w, func() {
-				m.Text(header, props.Text{Align: consts.Center, Style: consts.Bold})
-			})
+// eachViewRow calls fn for every row of the current view, reading lazy sources in page-sized chunks.
+// Grouped views give the rows of every group, collapsed or not, without the group headers.
+func (k *TableRenderer) eachViewRow(fn func(row []string) error) error {
+	if k.groups != nil {
+		return eachOf(k.filteredRows)(fn)
+	}
+	total := k.rowCount()
+	chunk := k.pageSize
+	if chunk < 256 {
+		chunk = 256
+	}
+	for start := 0; start < total; start += chunk {
+		var rows [][]string
+		if k.lazy {
+			fetched, err := k.source.FetchRows(start, chunk)
+			if err != nil {
+				return err
+			}
+			rows = fetched
+		} else {
+			rows = k.rowsRange(start, start+chunk)
 		}
-	})
-
-	// Add rows
-	for _, row := range k.filteredRows {
-		m.Row(10, func() {
-			for _, cell := range row {
-				w := uint(12 / len(row))
-				m.Col(w, func() {
-					m.Text(cell, props.Text{Align: consts.Left})
-				})
+		for _, row := range rows {
+			if err := fn(row); err != nil {
+				return err
 			}
-		})
-	}
-
-	// Save the PDF
-	err := m.OutputFileAndClose(filename)
-	if err != nil {
-		gl.Log("error", "Could not save PDF: "+err.Error())
+		}
 	}
+	return nil
 }
 
-// ExportToMarkdown exports the table data to a Markdown file.
-func (k *TableRenderer) ExportToMarkdown(filename string) {
-	// Implementation for exporting to Markdown
+// resetWindow drops the cached window so the next read hits the source again.
+func (k *TableRenderer) resetWindow() {
+	k.window = nil
+	k.windowStart = 0
 }
 
-// ToggleColumnVisibility toggles the visibility of the columns in the table.
-func (k *TableRenderer) ToggleColumnVisibility() {
-	for header := range k.visibleCols {
-		k.visibleCols[header] = !k.visibleCols[header]
+// exportToFile exports the current view to filename in format, logging the outcome.
+func (k *TableRenderer) exportToFile(filename, format string) {
+	if _, err := k.Export(filename, format, ExportFiltered); err != nil {
+		gl.Log("error", "Error exporting to "+format+": "+err.Error())
+		return
 	}
-	k.kTb = k.kTb.Rows(k.GetCurrentPageRows()...)
+	gl.Log("info", "Data exported to "+format+":"+filename)
+}
+
+// ExportToCSV exports the table data to a CSV file.
+func (k *TableRenderer) ExportToCSV(filename string) { k.exportToFile(filename, "CSV") }
+
+// ExportToYAML exports the table data to a YAML file as a list of header→value records.
+func (k *TableRenderer) ExportToYAML(filename string) { k.exportToFile(filename, "YAML") }
+
+// ExportToJSON exports the table data to a JSON file as an array of header→value records.
+func (k *TableRenderer) ExportToJSON(filename string) { k.exportToFile(filename, "JSON") }
+
+// ExportToXML exports the table data to an XML file with one row element per record (see SetXMLElements).
+func (k *TableRenderer) ExportToXML(filename string) { k.exportToFile(filename, "XML") }
+
+// ExportToExcel exports the table data to an XLSX workbook with typed cells, a frozen header and an autofilter.
+func (k *TableRenderer) ExportToExcel(filename string) { k.exportToFile(filename, "XLSX") }
+
+// ExportToPDF exports the table data to a PDF file.
+func (k *TableRenderer) ExportToPDF(filename string) { k.exportToFile(filename, "PDF") }
+
+// ExportToMarkdown exports the table data to a GitHub-flavoured Markdown table.
+func (k *TableRenderer) ExportToMarkdown(filename string) { k.exportToFile(filename, "Markdown") }
+
+// ExportToHTML exports the table data to a standalone HTML document styled with the HTML theme.
+func (k *TableRenderer) ExportToHTML(filename string) { k.exportToFile(filename, "HTML") }
+
+// ExportToAsciiDoc exports the table data to an AsciiDoc table.
+func (k *TableRenderer) ExportToAsciiDoc(filename string) { k.exportToFile(filename, "AsciiDoc") }
+
+// SetXMLElements sets the root and row element names used by ExportToXML. Empty names keep the
+// defaults, types.DefaultXMLRootElement and types.DefaultXMLRowElement.
+func (k *TableRenderer) SetXMLElements(root, row string) {
+	k.xmlRoot = root
+	k.xmlRow = row
 }
 
+// SetHTMLTheme sets the CSS embedded by ExportToHTML. It defaults to types.DefaultHTMLTheme; an empty
+// string exports an unstyled table.
+func (k *TableRenderer) SetHTMLTheme(css string) { k.htmlTheme = css }
+
+// SetCSVFooter makes CSV and TSV exports end with the footer aggregates as a last record. They are
+// left out by default, as tools reading the file would take them for data.
+func (k *TableRenderer) SetCSVFooter(footer bool) { k.csvFooter = footer }
+
+// SetPDFOptions sets the orientation, page size, titles and colours of PDF reports. Colours left empty
+// follow the table theme: the header row uses the selected-row colours and stripes a tint of them.
+func (k *TableRenderer) SetPDFOptions(opts tp.PDFOptions) { k.pdfOptions = opts }
+
 // Execution functions
 
 // GetTableScreenCustom returns the string representation of the table with custom styles and an optional style function.
@@ -596,6 +972,31 @@ func StartTableScreen(tbHandler tp.TableDataHandler, customStyles map[string]lip
 	return StartTableScreenCustom(tbHandler, customStyles, nil)
 }
 
+// StartTableScreenFromSource starts the table screen for a paged TableDataSource.
+func StartTableScreenFromSource(source tp.TableDataSource, customStyles map[string]lipgloss.Color) error {
+	return StartTableScreenFromRenderer(NewTableRendererFromSource(source, customStyles, nil))
+}
+
+// StartTableEditor starts the table screen in edit mode and returns the changes made once it exits.
+func StartTableEditor(tbHandler tp.TableDataHandler, customStyles map[string]lipgloss.Color) (tp.TableDiff, error) {
+	return StartTableEditorFromRenderer(NewTableRenderer(tbHandler, customStyles, nil))
+}
+
+// StartTableEditorFromRenderer starts the table screen for k in edit mode, turning it on when needed,
+// and returns the changes made once it exits.
+func StartTableEditorFromRenderer(k *TableRenderer) (tp.TableDiff, error) {
+	if !k.editable {
+		k.SetEditable(true)
+	}
+	if !k.editable {
+		return tp.TableDiff{}, fmt.Errorf("table source cannot be edited")
+	}
+	if err := StartTableScreenFromRenderer(k); err != nil {
+		return tp.TableDiff{}, err
+	}
+	return k.Diff(), nil
+}
+
 // StartTableScreenFromRenderer starts the table screen from a given TableRenderer.
 func StartTableScreenFromRenderer(k *TableRenderer) error {
 	prog := tea.NewProgram(k, tea.WithAltScreen())
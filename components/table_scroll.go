@@ -0,0 +1,185 @@
+package components
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Rendered columns take their content width plus the cell padding and one border.
+const (
+	columnChrome    = 3
+	minScrollWidth  = 3 // narrowest width a scrolled column is squeezed to
+	tableEdgeBorder = 1
+)
+
+// SetFrozenColumns keeps the first n visible columns on screen while the others scroll horizontally.
+func (k *TableRenderer) SetFrozenColumns(n int) {
+	k.frozen = max(n, 0)
+	k.refreshTable()
+}
+
+// FrozenColumns returns the number of frozen leading columns.
+func (k *TableRenderer) FrozenColumns() int { return k.frozen }
+
+// cycleFrozenColumns freezes one more leading column, back to none once all but one are frozen.
+func (k *TableRenderer) cycleFrozenColumns() {
+	if k.frozen+1 >= len(k.visibleColumns()) {
+		k.SetFrozenColumns(0)
+		return
+	}
+	k.SetFrozenColumns(k.frozen + 1)
+}
+
+// SetColumnWidthLimits bounds the automatic width of source column col: narrower content is padded
+// to minWidth and wider content is truncated with an ellipsis at maxWidth. 0 removes a bound.
+// Pinned widths (SetColumnWidth) take precedence.
+func (k *TableRenderer) SetColumnWidthLimits(col, minWidth, maxWidth int) {
+	if i := k.layoutPosition(col); i >= 0 {
+		k.layout[i].MinWidth = max(minWidth, 0)
+		k.layout[i].MaxWidth = max(maxWidth, 0)
+		k.refreshTable()
+	}
+}
+
+// ScrollColumns scrolls the non-frozen columns by delta columns.
+func (k *TableRenderer) ScrollColumns(delta int) {
+	k.hScroll = max(k.hScroll+delta, 0)
+	k.refreshTable()
+}
+
+// ScrollToColumn scrolls horizontally until source column col is on screen.
+func (k *TableRenderer) ScrollToColumn(col int) {
+	k.scrollTo = col
+	k.refreshTable()
+}
+
+// fixedWidth returns the content width column col is rendered at when it is not sized by lipgloss:
+// its pinned width, or its natural width once bounded by the width limits. It returns 0 for columns
+// within their limits.
+func (k *TableRenderer) fixedWidth(col int, natural int) int {
+	i := k.layoutPosition(col)
+	if i < 0 {
+		return 0
+	}
+	c := k.layout[i]
+	switch {
+	case c.Width > 0:
+		return c.Width
+	case c.MaxWidth > 0 && natural > c.MaxWidth:
+		return c.MaxWidth
+	case c.MinWidth > 0 && natural < c.MinWidth:
+		return c.MinWidth
+	}
+	return 0
+}
+
+// scrollWindow picks the columns rendered on the current page: the frozen ones, then as many
+// scrolled columns as fit in the table width starting at the horizontal scroll offset. It returns
+// their source indexes and the width to truncate each one to (0 for none).
+func (k *TableRenderer) scrollWindow() ([]int, []int) {
+	visible := k.visibleColumns()
+	widths := make([]int, len(visible))
+	contents := make([]int, len(visible))
+	for i, col := range visible {
+		natural := k.naturalWidth(col)
+		widths[i] = k.fixedWidth(col, natural)
+		contents[i] = natural
+		if widths[i] > 0 {
+			contents[i] = widths[i]
+		}
+	}
+	frozen := min(k.frozen, max(len(visible)-1, 0))
+	k.scrollCols = len(visible) - frozen
+	if k.tableWidth <= 0 || len(visible) == 0 {
+		k.hScroll, k.scrollTo = 0, -1
+		k.scrollFirst, k.scrollLast = 0, k.scrollCols-1
+		return visible, widths
+	}
+
+	if pos := indexOf(visible, k.scrollTo); pos >= frozen && pos-frozen < k.hScroll {
+		k.hScroll = pos - frozen
+	}
+	k.hScroll = min(k.hScroll, k.scrollCols-1)
+	available := k.tableWidth - tableEdgeBorder
+	for i := range frozen {
+		available -= contents[i] + columnChrome
+	}
+	fits := func(first int) int { // index of the last scrolled column fitting from first
+		room, last := available, first-1
+		for i := frozen + first; i < len(visible) && room >= contents[i]+columnChrome; i++ {
+			room -= contents[i] + columnChrome
+			last = i - frozen
+		}
+		return last
+	}
+	if pos := indexOf(visible, k.scrollTo); pos >= frozen {
+		for k.hScroll < pos-frozen && fits(k.hScroll) < pos-frozen {
+			k.hScroll++
+		}
+	}
+	k.scrollTo = -1
+	// Scroll back while the columns before the offset fit, so widening the window fills it.
+	for k.hScroll > 0 && fits(k.hScroll-1) >= k.scrollCols-1 {
+		k.hScroll--
+	}
+	last := max(fits(k.hScroll), k.hScroll)
+	k.scrollFirst, k.scrollLast = k.hScroll, last
+
+	cols := append(append([]int(nil), visible[:frozen]...), visible[frozen+k.hScroll:frozen+last+1]...)
+	colWidths := append(append([]int(nil), widths[:frozen]...), widths[frozen+k.hScroll:frozen+last+1]...)
+	if last == k.hScroll && fits(k.hScroll) < k.hScroll {
+		// A single column wider than the room left is squeezed into it.
+		colWidths[len(colWidths)-1] = max(available-columnChrome, minScrollWidth)
+	}
+	return cols, colWidths
+}
+
+// indexOf returns the position of v in values, or -1.
+func indexOf(values []int, v int) int {
+	for i, value := range values {
+		if value == v {
+			return i
+		}
+	}
+	return -1
+}
+
+// projectCells returns the cells of row for the rendered columns, truncated with an ellipsis to their widths.
+func (k *TableRenderer) projectCells(row []string) []string {
+	cells := make([]string, len(k.displayCols))
+	for i, col := range k.displayCols {
+		cells[i] = cellAt(row, col)
+		if width := k.displayWidths[i]; width > 0 {
+			cells[i] = ansi.Truncate(cells[i], width, "…")
+		}
+	}
+	return cells
+}
+
+// rowTruncated reports whether a rendered cell of row is cut short.
+func (k *TableRenderer) rowTruncated(row []string) bool {
+	for i, col := range k.displayCols {
+		if width := k.displayWidths[i]; width > 0 && ansi.StringWidth(cellAt(row, col)) > width {
+			return true
+		}
+	}
+	return false
+}
+
+// scrollStatus describes the horizontal scroll position for the status line, or "" when every
+// visible column is shown.
+func (k *TableRenderer) scrollStatus() string {
+	if k.scrollFirst == 0 && k.scrollLast >= k.scrollCols-1 {
+		if k.frozen > 0 {
+			return fmt.Sprintf("Frozen: %d", k.frozen)
+		}
+		return ""
+	}
+	frozen := len(k.visibleColumns()) - k.scrollCols
+	status := fmt.Sprintf("Columns: %d-%d/%d", frozen+k.scrollFirst+1, frozen+k.scrollLast+1, frozen+k.scrollCols)
+	if frozen > 0 {
+		status += fmt.Sprintf(" | Frozen: %d", frozen)
+	}
+	return status
+}
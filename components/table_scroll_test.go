@@ -0,0 +1,93 @@
+package components
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	tp "github.com/kubex-ecosystem/xtui/types"
+)
+
+// newWideTable returns a table of 8 columns 9 characters wide, each taking 12 characters on screen,
+// in a window of width 60: 4 columns fit.
+func newWideTable() *TableRenderer {
+	headers := make([]string, 8)
+	rows := make([][]string, 3)
+	for c := range headers {
+		headers[c] = fmt.Sprintf("Column %02d", c)
+		for r := range rows {
+			rows[r] = append(rows[r], fmt.Sprintf("r%d-c%02d-xy", r, c))
+		}
+	}
+	k := NewTableRenderer(tp.NewTableHandler(headers, rows), nil, nil)
+	k.Update(tea.WindowSizeMsg{Width: 60, Height: 30})
+	return k
+}
+
+func TestHorizontalScroll(t *testing.T) {
+	k := newWideTable()
+	steps := []struct {
+		name   string
+		do     func()
+		cols   []int
+		status string
+	}{
+		{"initial", func() {}, []int{0, 1, 2, 3}, "Columns: 1-4/8"},
+		{"scrolled", func() { k.ScrollColumns(2) }, []int{2, 3, 4, 5}, "Columns: 3-6/8"},
+		{"frozen", func() { k.SetFrozenColumns(1) }, []int{0, 3, 4, 5}, "Columns: 4-6/8 | Frozen: 1"},
+		{"scrolled past the end", func() { k.ScrollColumns(10) }, []int{0, 5, 6, 7}, "Columns: 6-8/8 | Frozen: 1"},
+		{"scrolled to a column", func() { k.ScrollToColumn(1) }, []int{0, 1, 2, 3}, "Columns: 2-4/8 | Frozen: 1"},
+		{"hidden column", func() { k.SetColumnVisible(2, false) }, []int{0, 1, 3, 4}, "Columns: 2-4/7 | Frozen: 1"},
+		{"wide window", func() { k.Update(tea.WindowSizeMsg{Width: 200, Height: 30}) }, []int{0, 1, 3, 4, 5, 6, 7}, "Frozen: 1"},
+	}
+	for _, step := range steps {
+		step.do()
+		if !reflect.DeepEqual(k.displayCols, step.cols) {
+			t.Errorf("%s: columns %v, want %v", step.name, k.displayCols, step.cols)
+		}
+		if got := k.scrollStatus(); got != step.status {
+			t.Errorf("%s: scrollStatus() = %q, want %q", step.name, got, step.status)
+		}
+	}
+}
+
+func TestHorizontalScrollKeys(t *testing.T) {
+	k := newWideTable()
+	k.Update(tea.KeyMsg{Type: tea.KeyShiftRight})
+	k.Update(tea.KeyMsg{Type: tea.KeyShiftRight})
+	k.Update(tea.KeyMsg{Type: tea.KeyShiftLeft})
+	if got, want := k.displayCols, []int{1, 2, 3, 4}; !reflect.DeepEqual(got, want) {
+		t.Errorf("columns = %v, want %v", got, want)
+	}
+	for want := range 9 { // up to all columns but one, then none again
+		if k.FrozenColumns() != want%8 {
+			t.Fatalf("F pressed %d times: %d frozen columns, want %d", want, k.FrozenColumns(), want%8)
+		}
+		typeKeys(k, "F")
+	}
+	if !strings.Contains(k.View(), "Column 01") {
+		t.Error("the view does not show the scrolled columns")
+	}
+}
+
+func TestColumnWidthLimits(t *testing.T) {
+	k := newWideTable()
+	k.SetColumnWidthLimits(0, 0, 4)
+	k.SetColumnWidthLimits(1, 12, 0)
+	if got, want := k.displayWidths[:3], []int{4, 12, 0}; !reflect.DeepEqual(got, want) {
+		t.Errorf("widths = %v, want %v", got, want)
+	}
+	if got := k.projectCells(k.rows[0])[0]; got != "r0-…" {
+		t.Errorf("cell truncated to %q, want r0-…", got)
+	}
+	if !k.rowTruncated(k.rows[0]) {
+		t.Error("rowTruncated() = false with a truncated cell")
+	}
+
+	k.SetColumnWidth(0, 80)
+	if got := k.displayCols; !reflect.DeepEqual(got, []int{0}) || k.displayWidths[0] != 60-1-columnChrome {
+		t.Errorf("a column wider than the window: columns %v, widths %v, want it squeezed alone", got, k.displayWidths)
+	}
+}
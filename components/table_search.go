@@ -0,0 +1,264 @@
+package components
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	tp "github.com/kubex-ecosystem/xtui/types"
+)
+
+// searchMatch is a row of the view matching the search: its position, identity, best matching
+// column and score (0 for exact matches, see types.FuzzyMatch).
+type searchMatch struct {
+	pos   int
+	key   string
+	col   int
+	score int
+}
+
+// newSearchInput creates the text field used by the search mode.
+func newSearchInput() textinput.Model {
+	input := textinput.New()
+	input.Prompt = "Search: "
+	input.Placeholder = "text, typos allowed"
+	input.PromptStyle = focusedStyle
+	input.Cursor.Style = cursorStyle
+	return input
+}
+
+// StartSearchMode focuses the search field, as the ctrl+f key does.
+func (k *TableRenderer) StartSearchMode() tea.Cmd {
+	k.searching = true
+	k.searchBefore = k.search
+	k.searchInput = newSearchInput()
+	k.searchInput.SetValue(k.search)
+	k.searchInput.CursorEnd()
+	return k.searchInput.Focus()
+}
+
+// updateSearchMode handles messages while the search field is focused: cells are highlighted as the
+// query is typed, enter searches and jumps to the best match, esc restores the previous search.
+func (k *TableRenderer) updateSearchMode(msg tea.Msg) tea.Cmd {
+	if message, ok := msg.(tea.KeyMsg); ok {
+		switch message.String() {
+		case "enter":
+			k.searching = false
+			k.searchInput.Blur()
+			return k.Search(k.searchInput.Value())
+		case "esc":
+			k.searching = false
+			k.searchInput.Blur()
+			k.search = k.searchBefore
+			return nil
+		}
+	}
+	var cmd tea.Cmd
+	k.searchInput, cmd = k.searchInput.Update(msg)
+	k.search = k.searchInput.Value()
+	return cmd
+}
+
+// searchMatchesMsg carries the matches of a scan of a lazy source. gen discards the results of a
+// search replaced or cleared since.
+type searchMatchesMsg struct {
+	gen     int
+	matches []searchMatch
+}
+
+// Search highlights the cells matching query without hiding any row and moves the cursor to the best
+// match. Rows containing query come first, then approximate matches by increasing distance, each in
+// view order. An empty query clears the search. Lazy sources are scanned in the background by the
+// returned command; other tables are searched at once and the command only reports a search without
+// matches.
+func (k *TableRenderer) Search(query string) tea.Cmd {
+	k.search = query
+	if cmd := k.findMatches(); cmd != nil || query == "" {
+		return cmd
+	}
+	k.refreshTable()
+	if len(k.searchMatches) == 0 {
+		return k.notify(Warning, fmt.Sprintf("No matches for %q", k.search))
+	}
+	return k.jumpToMatch(0)
+}
+
+// ClearSearch removes the search highlights and cancels a scan in progress.
+func (k *TableRenderer) ClearSearch() {
+	k.search = ""
+	k.cancelSearchScan()
+	k.searchMatches = nil
+	k.searchIndex = 0
+	k.refreshTable()
+}
+
+// GetSearch returns the search query.
+func (k *TableRenderer) GetSearch() string { return k.search }
+
+// SearchMatchCount returns the number of rows matching the search.
+func (k *TableRenderer) SearchMatchCount() int { return len(k.searchMatches) }
+
+// IsSearchScanning reports whether a lazy source is being scanned for the search.
+func (k *TableRenderer) IsSearchScanning() bool { return k.searchScanning }
+
+// NextMatch moves the cursor to the next match, turning to the first one after the last, or to the
+// previous match when backward is set. The returned command reports a search without matches, or
+// scans a lazy source again when its view changed.
+func (k *TableRenderer) NextMatch(backward bool) tea.Cmd {
+	if k.searchScanning {
+		return k.notify(Info, fmt.Sprintf("Still searching for %q…", k.search))
+	}
+	if len(k.searchMatches) == 0 {
+		return k.notify(Warning, fmt.Sprintf("No matches for %q", k.search))
+	}
+	step := 1
+	if backward {
+		step = -1
+	}
+	n := len(k.searchMatches)
+	return k.jumpToMatch(((k.searchIndex+step)%n + n) % n)
+}
+
+// findMatches searches the rows of the view, in their visible columns, and ranks the matches.
+// Rows inside collapsed groups are not searched. Lazy sources are read in the background by the
+// returned command, so the screen stays responsive while a large file is scanned.
+func (k *TableRenderer) findMatches() tea.Cmd {
+	k.cancelSearchScan()
+	k.searchMatches, k.searchIndex = nil, 0
+	if k.search == "" {
+		return nil
+	}
+	query, cols := k.search, k.visibleColumns()
+	if !k.lazy {
+		for pos, row := range k.viewRows() {
+			if k.groupOf(row) != nil {
+				continue
+			}
+			if m, ok := matchSearchRow(query, cols, pos, row); ok {
+				m.key = k.keyOf(row)
+				k.searchMatches = append(k.searchMatches, m)
+			}
+		}
+		rankSearchMatches(k.searchMatches)
+		return nil
+	}
+	gen := k.searchGen
+	live := k.searchLive
+	source, total, chunk, keyOf := k.source, k.rowCount(), max(k.pageSize, 256), k.keyOf
+	k.searchScanning = true
+	return func() tea.Msg {
+		var matches []searchMatch
+		for start := 0; start < total; start += chunk {
+			if live.Load() != int64(gen) {
+				return nil
+			}
+			rows, err := source.FetchRows(start, chunk)
+			if err != nil || len(rows) == 0 {
+				break
+			}
+			for i, row := range rows {
+				if m, ok := matchSearchRow(query, cols, start+i, row); ok {
+					m.key = keyOf(row)
+					matches = append(matches, m)
+				}
+			}
+		}
+		rankSearchMatches(matches)
+		return searchMatchesMsg{gen: gen, matches: matches}
+	}
+}
+
+// cancelSearchScan stops a scan in progress: it ends at its next chunk and its result is discarded.
+func (k *TableRenderer) cancelSearchScan() {
+	k.searchGen++
+	k.searchLive.Store(int64(k.searchGen))
+	k.searchScanning = false
+}
+
+// searchScanned applies the matches of a finished scan and moves to the best one.
+func (k *TableRenderer) searchScanned(msg searchMatchesMsg) tea.Cmd {
+	if msg.gen != k.searchGen {
+		return nil
+	}
+	k.searchScanning = false
+	k.searchMatches, k.searchIndex = msg.matches, 0
+	if len(k.searchMatches) == 0 {
+		return k.notify(Warning, fmt.Sprintf("No matches for %q", k.search))
+	}
+	return k.jumpToMatch(0)
+}
+
+// matchSearchRow returns the best matching cell of row among cols, if any.
+func matchSearchRow(query string, cols []int, pos int, row []string) (searchMatch, bool) {
+	best := searchMatch{pos: pos, col: -1}
+	for _, col := range cols {
+		if score, ok := tp.FuzzyMatch(query, cellAt(row, col)); ok && (best.col < 0 || score < best.score) {
+			best.col, best.score = col, score
+		}
+	}
+	return best, best.col >= 0
+}
+
+// rankSearchMatches orders matches by score, keeping the view order of equal scores.
+func rankSearchMatches(matches []searchMatch) {
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].score < matches[j].score })
+}
+
+// jumpToMatch moves the cursor to match i, showing its page and column. When the view changed since
+// the search (filter, sort, live updates), the matches are looked up again first; for lazy sources
+// the returned command scans the source again and moves to the best match when done.
+func (k *TableRenderer) jumpToMatch(i int) tea.Cmd {
+	if m := k.searchMatches[i]; k.keyOf(k.rowAt(m.pos)) != m.key {
+		if cmd := k.findMatches(); cmd != nil {
+			return cmd
+		}
+		if len(k.searchMatches) == 0 {
+			return k.notify(Warning, fmt.Sprintf("No matches for %q", k.search))
+		}
+		i = min(i, len(k.searchMatches)-1)
+	}
+	m := k.searchMatches[i]
+	k.searchIndex = i
+	k.selectedRow = m.pos
+	k.page = m.pos / k.pageSize
+	k.ScrollToColumn(m.col)
+	return nil
+}
+
+// searchCellStyle highlights the cells matching the search: exact matches in yellow, approximate ones
+// underlined, and the cell of the current match in bold.
+func (k *TableRenderer) searchCellStyle(style lipgloss.Style, viewRow int, record []string, col int) lipgloss.Style {
+	if k.search == "" || record == nil || k.groupOf(record) != nil {
+		return style
+	}
+	score, ok := tp.FuzzyMatch(k.search, cellAt(record, col))
+	if !ok {
+		return style
+	}
+	style = style.Foreground(lipgloss.Color("#FDFF90"))
+	if score == 0 {
+		style = style.Background(lipgloss.Color("#4A4A00"))
+	} else {
+		style = style.Underline(true)
+	}
+	if k.searchIndex < len(k.searchMatches) && k.searchMatches[k.searchIndex].pos == viewRow && viewRow == k.selectedRow {
+		style = style.Bold(true).Background(lipgloss.Color("#7A6A00"))
+	}
+	return style
+}
+
+// searchStatus describes the search for the status line.
+func (k *TableRenderer) searchStatus() string {
+	if k.search == "" || k.searching {
+		return ""
+	}
+	if k.searchScanning {
+		return fmt.Sprintf("Search %q: scanning…", k.search)
+	}
+	if len(k.searchMatches) == 0 {
+		return fmt.Sprintf("Search %q: no matches", k.search)
+	}
+	return fmt.Sprintf("Search %q: %d/%d (n/N)", k.search, k.searchIndex+1, len(k.searchMatches))
+}
@@ -0,0 +1,154 @@
+package components
+
+import (
+	"reflect"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	tp "github.com/kubex-ecosystem/xtui/types"
+)
+
+var searchRows = [][]string{
+	{"nano", "small text editor"},
+	{"vim", "Vi IMproved, a text editr"},
+	{"ed", "line editor"},
+	{"git", "revision control"},
+	{"emacs", "extensible editor"},
+}
+
+// newSearchTable returns a table of editors, one of them with a typo in its description.
+func newSearchTable() *TableRenderer {
+	return NewTableRenderer(tp.NewTableHandler([]string{"Name", "Description"}, searchRows), nil, nil)
+}
+
+// matchPositions returns the view positions of the search matches, in rank order.
+func matchPositions(k *TableRenderer) []int {
+	var positions []int
+	for _, m := range k.searchMatches {
+		positions = append(positions, m.pos)
+	}
+	return positions
+}
+
+func TestSearchRanking(t *testing.T) {
+	k := newSearchTable()
+	k.SetPageSize(2)
+	if cmd := k.Search("editor"); cmd != nil {
+		t.Errorf("Search() returned a command for a search with matches")
+	}
+	if got, want := matchPositions(k), []int{0, 2, 4, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("matches = %v, want %v: exact matches in view order, then the typo", got, want)
+	}
+	if len(k.filteredRows) != len(searchRows) {
+		t.Errorf("the search hid rows: %d left", len(k.filteredRows))
+	}
+	if k.selectedRow != 0 || k.searchMatches[0].col != 1 {
+		t.Errorf("cursor on row %d, column %d, want the best match at 0 in Description", k.selectedRow, k.searchMatches[0].col)
+	}
+
+	k.SetColumnVisible(1, false)
+	k.Search("ed")
+	if k.SearchMatchCount() != 1 || k.selectedRow != 2 {
+		t.Errorf("search in the visible columns: %d matches, cursor %d, want ed only", k.SearchMatchCount(), k.selectedRow)
+	}
+
+	k.Search("rust")
+	if k.SearchMatchCount() != 0 || k.toast == nil || k.toast.Type != Warning {
+		t.Errorf("search without matches: %d matches, toast %+v", k.SearchMatchCount(), k.toast)
+	}
+}
+
+func TestSearchNavigation(t *testing.T) {
+	k := newSearchTable()
+	k.SetPageSize(2)
+	k.Search("editor")
+	steps := []struct {
+		key  string
+		row  int
+		page int
+	}{
+		{"n", 2, 1},
+		{"n", 4, 2},
+		{"n", 1, 0},
+		{"n", 0, 0},
+		{"N", 1, 0},
+		{"N", 4, 2},
+	}
+	for i, step := range steps {
+		k.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(step.key)})
+		if k.selectedRow != step.row || k.page != step.page {
+			t.Errorf("step %d (%s): row %d page %d, want row %d page %d", i, step.key, k.selectedRow, k.page, step.row, step.page)
+		}
+	}
+	if got, want := k.searchStatus(), `Search "editor": 3/4 (n/N)`; got != want {
+		t.Errorf("searchStatus() = %q, want %q", got, want)
+	}
+
+	if err := k.SetFilter("name!=nano"); err != nil {
+		t.Fatalf("SetFilter: %v", err)
+	}
+	k.NextMatch(false)
+	if got, want := matchPositions(k), []int{1, 3, 0}; !reflect.DeepEqual(got, want) {
+		t.Errorf("matches after filtering = %v, want %v", got, want)
+	}
+	if got := k.rowAt(k.selectedRow); got[0] != "vim" {
+		t.Errorf("next match after filtering is %v, want the last one, vim", got)
+	}
+
+	pressKey(k, tea.KeyEsc)
+	if k.GetSearch() != "" || k.SearchMatchCount() != 0 {
+		t.Errorf("esc left the search %q with %d matches", k.GetSearch(), k.SearchMatchCount())
+	}
+	typeKeys(k, "n")
+	if k.search != "" {
+		t.Error("n started a search")
+	}
+}
+
+func TestSearchMode(t *testing.T) {
+	k := newSearchTable()
+	k.Search("vim")
+	k.Update(tea.KeyMsg{Type: tea.KeyCtrlF})
+	if !k.searching || k.searchInput.Value() != "vim" {
+		t.Fatalf("ctrl+f: searching %v, field %q", k.searching, k.searchInput.Value())
+	}
+	typeKeys(k, "x")
+	if k.GetSearch() != "vimx" {
+		t.Errorf("search while typing = %q, want vimx", k.GetSearch())
+	}
+	pressKey(k, tea.KeyEsc)
+	if k.searching || k.GetSearch() != "vim" {
+		t.Errorf("after esc: searching %v, search %q, want vim back", k.searching, k.GetSearch())
+	}
+
+	k.Update(tea.KeyMsg{Type: tea.KeyCtrlF})
+	k.searchInput.SetValue("emacs")
+	pressKey(k, tea.KeyEnter)
+	if k.searching || k.selectedRow != 4 {
+		t.Errorf("after enter: searching %v, cursor %d, want emacs at 4", k.searching, k.selectedRow)
+	}
+}
+
+func TestSearchLazySource(t *testing.T) {
+	k := NewTableRendererFromSource(&pagedSource{headers: []string{"Name", "Description"}, rows: searchRows}, nil, nil)
+	cmd := k.Search("editor")
+	if cmd == nil || !k.IsSearchScanning() {
+		t.Fatalf("Search() on a lazy source: command %v, scanning %v", cmd != nil, k.IsSearchScanning())
+	}
+	if k.NextMatch(false); k.toast == nil || k.toast.Type != Info {
+		t.Errorf("NextMatch while scanning: toast %+v", k.toast)
+	}
+	k.Update(runCmd(cmd))
+	if k.IsSearchScanning() || k.SearchMatchCount() != 4 || k.selectedRow != 0 {
+		t.Errorf("after the scan: scanning %v, %d matches, cursor %d", k.IsSearchScanning(), k.SearchMatchCount(), k.selectedRow)
+	}
+
+	cmd = k.Search("vim")
+	k.ClearSearch()
+	if msg := runCmd(cmd); msg != nil {
+		k.Update(msg)
+	}
+	if k.SearchMatchCount() != 0 || k.IsSearchScanning() {
+		t.Errorf("a cancelled scan left %d matches, scanning %v", k.SearchMatchCount(), k.IsSearchScanning())
+	}
+}
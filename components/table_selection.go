@@ -0,0 +1,349 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// BulkAction is a named action the embedding application runs on the selected rows, e.g.
+// "Uninstall selected packages". Run receives a copy of the full source rows, regardless of the column
+// layout, and is called outside of the UI loop.
+type BulkAction struct {
+	Name    string // label in the bulk action menu
+	Key     string // optional shortcut in the table view
+	Confirm bool   // ask before running
+	Run     func(rows [][]string) error
+}
+
+// bulkActionDoneMsg reports the outcome of a bulk action.
+type bulkActionDoneMsg struct {
+	name string
+	rows int
+	err  error
+}
+
+// SetRowKey sets how rows are identified by the selection. Selected rows stay selected across
+// filtering, sorting and paging as long as their key is unchanged. The default key is the whole row,
+// so identical rows are selected together; pass a function returning an ID column to avoid that.
+func (k *TableRenderer) SetRowKey(key func(row []string) string) { k.rowKey = key }
+
+// keyOf returns the selection key of row.
+func (k *TableRenderer) keyOf(row []string) string {
+	if k.rowKey != nil {
+		return k.rowKey(row)
+	}
+	return strings.Join(row, "\x1f")
+}
+
+// selectRow adds row to the selection.
+func (k *TableRenderer) selectRow(row []string) {
+	if k.groupOf(row) != nil {
+		return
+	}
+	key := k.keyOf(row)
+	if _, ok := k.selection[key]; ok {
+		return
+	}
+	if k.selection == nil {
+		k.selection = make(map[string][]string)
+	}
+	k.selection[key] = row
+	k.selectionOrder = append(k.selectionOrder, key)
+}
+
+// unselectRow removes row from the selection.
+func (k *TableRenderer) unselectRow(row []string) { k.unselectKey(k.keyOf(row)) }
+
+// unselectKey removes the row with key from the selection.
+func (k *TableRenderer) unselectKey(key string) {
+	if _, ok := k.selection[key]; !ok {
+		return
+	}
+	delete(k.selection, key)
+	for i, selected := range k.selectionOrder {
+		if selected == key {
+			k.selectionOrder = append(k.selectionOrder[:i], k.selectionOrder[i+1:]...)
+			break
+		}
+	}
+}
+
+// isSelected reports whether row is in the selection.
+func (k *TableRenderer) isSelected(row []string) bool {
+	if len(k.selection) == 0 || row == nil {
+		return false
+	}
+	_, ok := k.selection[k.keyOf(row)]
+	return ok
+}
+
+// ToggleRowSelection selects or unselects the row at index i of the current view.
+func (k *TableRenderer) ToggleRowSelection(i int) {
+	row := k.rowAt(i)
+	if row == nil {
+		return
+	}
+	if g := k.groupOf(row); g != nil {
+		k.toggleGroupSelection(g)
+	} else if k.isSelected(row) {
+		k.unselectRow(row)
+	} else {
+		k.selectRow(row)
+	}
+	k.selectAnchor = i
+}
+
+// SelectRange adds the rows between the view indexes from and to, both included, to the selection.
+func (k *TableRenderer) SelectRange(from, to int) {
+	if from > to {
+		from, to = to, from
+	}
+	for _, row := range k.rowsRange(max(from, 0), to+1) {
+		k.selectRow(row)
+	}
+}
+
+// SelectAllFiltered adds every row matching the filter to the selection.
+func (k *TableRenderer) SelectAllFiltered() {
+	_ = k.eachViewRow(func(row []string) error {
+		k.selectRow(row)
+		return nil
+	})
+}
+
+// UnselectAllFiltered removes every row matching the filter from the selection, keeping the selected
+// rows the filter hides.
+func (k *TableRenderer) UnselectAllFiltered() {
+	_ = k.eachViewRow(func(row []string) error {
+		delete(k.selection, k.keyOf(row))
+		return nil
+	})
+	order := k.selectionOrder[:0]
+	for _, key := range k.selectionOrder {
+		if _, ok := k.selection[key]; ok {
+			order = append(order, key)
+		}
+	}
+	k.selectionOrder = order
+	k.selectAnchor = -1
+}
+
+// allFilteredSelected reports whether every row of the current view is selected.
+func (k *TableRenderer) allFilteredSelected() bool {
+	total, _ := k.matchCount()
+	if total == 0 || len(k.selection) < total {
+		return false
+	}
+	selected := 0
+	_ = k.eachViewRow(func(row []string) error {
+		if k.isSelected(row) {
+			selected++
+		}
+		return nil
+	})
+	return selected == total
+}
+
+// ClearSelection unselects every row.
+func (k *TableRenderer) ClearSelection() {
+	k.selection = nil
+	k.selectionOrder = nil
+	k.selectAnchor = -1
+}
+
+// SelectedRows returns the selected rows in the order they were selected, including rows hidden by
+// the current filter.
+func (k *TableRenderer) SelectedRows() [][]string {
+	rows := make([][]string, 0, len(k.selectionOrder))
+	for _, key := range k.selectionOrder {
+		rows = append(rows, k.selection[key])
+	}
+	return rows
+}
+
+// SelectedCount returns the number of selected rows.
+func (k *TableRenderer) SelectedCount() int { return len(k.selectionOrder) }
+
+// actionRows returns the rows an action applies to: the selection, or the row under the cursor when
+// nothing is selected.
+func (k *TableRenderer) actionRows() [][]string {
+	if len(k.selectionOrder) > 0 {
+		return k.SelectedRows()
+	}
+	if row := k.SelectedRow(); row != nil {
+		return [][]string{row}
+	}
+	return nil
+}
+
+// extendSelection moves the cursor by delta and selects the rows between the anchor and the cursor.
+func (k *TableRenderer) extendSelection(delta int) {
+	if k.rowCount() == 0 {
+		return
+	}
+	if k.selectedRow < 0 {
+		k.selectedRow = k.page * k.pageSize
+	}
+	if k.selectAnchor < 0 {
+		k.selectAnchor = k.selectedRow
+	}
+	k.selectedRow = min(max(k.selectedRow+delta, 0), k.rowCount()-1)
+	k.page = k.selectedRow / k.pageSize
+	k.SelectRange(k.selectAnchor, k.selectedRow)
+}
+
+// RegisterBulkAction adds action to the bulk action menu, replacing an action with the same name.
+// Its Key may not be one of the built-in table keys.
+func (k *TableRenderer) RegisterBulkAction(action BulkAction) error {
+	if err := checkActionKey(action.Name, action.Key); err != nil {
+		return err
+	}
+	for i, registered := range k.bulkActions {
+		if registered.Name == action.Name {
+			k.bulkActions[i] = action
+			return nil
+		}
+	}
+	k.bulkActions = append(k.bulkActions, action)
+	return nil
+}
+
+// UnregisterBulkAction removes the bulk action name.
+func (k *TableRenderer) UnregisterBulkAction(name string) {
+	for i, registered := range k.bulkActions {
+		if registered.Name == name {
+			k.bulkActions = append(k.bulkActions[:i], k.bulkActions[i+1:]...)
+			return
+		}
+	}
+}
+
+// BulkActions returns the registered bulk actions.
+func (k *TableRenderer) BulkActions() []BulkAction {
+	return append([]BulkAction(nil), k.bulkActions...)
+}
+
+// bulkActionForKey returns the bulk action bound to key.
+func (k *TableRenderer) bulkActionForKey(key string) (int, bool) {
+	for i, action := range k.bulkActions {
+		if action.Key != "" && action.Key == key {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+// RunBulkAction runs the bulk action name on the selected rows (or the row under the cursor) and
+// returns the command reporting its outcome in the status line.
+func (k *TableRenderer) RunBulkAction(name string) tea.Cmd {
+	for _, action := range k.bulkActions {
+		if action.Name == name {
+			return k.runBulkAction(action)
+		}
+	}
+	return k.notify(Error, "Unknown bulk action: "+name)
+}
+
+// runBulkAction runs action in a command, so slow actions do not block the UI.
+func (k *TableRenderer) runBulkAction(action BulkAction) tea.Cmd {
+	rows := k.actionRows()
+	if len(rows) == 0 {
+		return k.notify(Warning, "No rows selected")
+	}
+	if action.Run == nil {
+		return nil
+	}
+	rows = cloneRows(rows) // the table keeps rendering and editing its rows while Run works on them
+	return func() tea.Msg {
+		return bulkActionDoneMsg{name: action.Name, rows: len(rows), err: action.Run(rows)}
+	}
+}
+
+// bulkActionDone shows the outcome of a bulk action and clears the selection it succeeded on.
+func (k *TableRenderer) bulkActionDone(msg bulkActionDoneMsg) tea.Cmd {
+	if msg.err != nil {
+		return k.notify(Error, fmt.Sprintf("%s failed: %v", msg.name, msg.err))
+	}
+	k.ClearSelection()
+	return k.notify(Info, fmt.Sprintf("%s: %d rows", msg.name, msg.rows))
+}
+
+// openBulkMenu opens the bulk action menu, with the action at index confirm awaiting confirmation
+// when it is not -1.
+func (k *TableRenderer) openBulkMenu(confirm int) tea.Cmd {
+	if len(k.bulkActions) == 0 {
+		return k.notify(Warning, "No bulk actions registered")
+	}
+	k.bulkMenu = true
+	k.bulkCursor = max(confirm, 0)
+	k.bulkConfirm = confirm >= 0
+	return nil
+}
+
+// triggerBulkAction runs the action at index i, asking first when it needs confirmation.
+func (k *TableRenderer) triggerBulkAction(i int) tea.Cmd {
+	action := k.bulkActions[i]
+	if action.Confirm && len(k.actionRows()) > 0 {
+		return k.openBulkMenu(i)
+	}
+	k.bulkMenu = false
+	return k.runBulkAction(action)
+}
+
+// updateBulkMenu handles the keys of the bulk action menu.
+func (k *TableRenderer) updateBulkMenu(message tea.KeyMsg) tea.Cmd {
+	if k.bulkConfirm {
+		k.bulkConfirm = false
+		switch message.String() {
+		case "y", "Y", "enter":
+			k.bulkMenu = false
+			return k.runBulkAction(k.bulkActions[k.bulkCursor])
+		}
+		return nil
+	}
+	switch message.String() {
+	case "esc", "b", "q":
+		k.bulkMenu = false
+	case "up", "k":
+		if k.bulkCursor > 0 {
+			k.bulkCursor--
+		}
+	case "down", "j":
+		if k.bulkCursor < len(k.bulkActions)-1 {
+			k.bulkCursor++
+		}
+	case "enter":
+		return k.triggerBulkAction(k.bulkCursor)
+	}
+	return nil
+}
+
+// bulkMenuView renders the bulk action menu.
+func (k *TableRenderer) bulkMenuView() string {
+	count := len(k.actionRows())
+	var b strings.Builder
+	b.WriteString(k.styles.header.Render(fmt.Sprintf("Bulk actions (%d rows)", count)) + "\n\n")
+	for i, action := range k.bulkActions {
+		line := "  " + action.Name
+		if action.Key != "" {
+			line += blurredStyle.Render("  " + action.Key)
+		}
+		if i == k.bulkCursor {
+			line = focusedStyle.Render("> " + action.Name)
+			if action.Key != "" {
+				line += blurredStyle.Render("  " + action.Key)
+			}
+		}
+		b.WriteString(line + "\n")
+	}
+	if k.bulkConfirm {
+		prompt := fmt.Sprintf("Run %q on %d rows? (y/n)", k.bulkActions[k.bulkCursor].Name, count)
+		b.WriteString("\n" + notificationStyle(Warning).Render(prompt) + "\n")
+	} else {
+		b.WriteString("\n" + blurredStyle.Render("up/down choose, enter run, esc close"))
+	}
+	return lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(lipgloss.Color("238")).Padding(0, 1).Render(b.String())
+}
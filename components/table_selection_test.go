@@ -0,0 +1,156 @@
+package components
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// selectedNames returns the first cell of the selected rows, in selection order.
+func selectedNames(k *TableRenderer) []string {
+	var names []string
+	for _, row := range k.SelectedRows() {
+		names = append(names, row[0])
+	}
+	return names
+}
+
+func TestSelectionAcrossPagingAndFiltering(t *testing.T) {
+	k := newSortTable()
+	k.SetPageSize(2)
+	pressKey(k, tea.KeySpace) // vim, first row of the first page
+	pressKey(k, tea.KeyPgDown)
+	if k.CurrentPage() != 2 {
+		t.Fatalf("CurrentPage() = %d, want 2", k.CurrentPage())
+	}
+	k.ToggleRowSelection(2) // git
+	if err := k.SetFilter("arch=arm64"); err != nil {
+		t.Fatalf("SetFilter: %v", err)
+	}
+	if got, want := selectedNames(k), []string{"vim", "git"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("selection after filtering = %v, want %v: rows hidden by the filter stay selected", got, want)
+	}
+
+	pressKey(k, tea.KeyCtrlA)
+	if got, want := selectedNames(k), []string{"vim", "git", "nano"}; !reflect.DeepEqual(got, want) || !k.allFilteredSelected() {
+		t.Errorf("selection after ctrl+a = %v, want %v", got, want)
+	}
+	pressKey(k, tea.KeyCtrlA)
+	if got, want := selectedNames(k), []string{"vim"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("selection after a second ctrl+a = %v, want %v: only the filtered rows are unselected", got, want)
+	}
+
+	if err := k.SetFilter(""); err != nil {
+		t.Fatalf("SetFilter: %v", err)
+	}
+	k.SortBy(0, true)
+	if got := viewNames(k); got[3] != "vim" || !k.isSelected(k.rowAt(3)) {
+		t.Fatalf("vim is not selected after sorting: %v", got)
+	}
+	k.ToggleRowSelection(3)
+	if k.SelectedCount() != 0 {
+		t.Errorf("SelectedCount() = %d after unselecting vim", k.SelectedCount())
+	}
+}
+
+func TestExtendSelection(t *testing.T) {
+	k := newSortTable()
+	k.SetPageSize(2)
+	k.selectedRow = 1
+	pressKey(k, tea.KeyShiftDown)
+	pressKey(k, tea.KeyShiftDown)
+	if got, want := selectedNames(k), []string{"nano", "git", "curl"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("selection = %v, want %v", got, want)
+	}
+	if k.CurrentPage() != 2 {
+		t.Errorf("CurrentPage() = %d, want the page of the cursor", k.CurrentPage())
+	}
+	pressKey(k, tea.KeyEsc)
+	if k.SelectedCount() != 0 || k.selectedRow != 3 {
+		t.Errorf("esc: %d rows selected, cursor %d, want the selection cleared first", k.SelectedCount(), k.selectedRow)
+	}
+}
+
+func TestSetRowKey(t *testing.T) {
+	k := newSortTable()
+	k.SetRowKey(func(row []string) string { return row[0] })
+	k.ToggleRowSelection(0)
+	k.rows[0] = []string{"vim", "31", "amd64"} // a reload with a new version
+	k.ApplyFilter()
+	if !k.isSelected(k.rowAt(0)) {
+		t.Error("a row keyed by name lost its selection when another cell changed")
+	}
+}
+
+func TestRegisterBulkActionKeys(t *testing.T) {
+	k := newSortTable()
+	for _, key := range []string{"z", "n", "ctrl+s", "alt+3", "ctrl+pgup", "shift+tab", "ctrl+w"} {
+		if err := k.RegisterBulkAction(BulkAction{Name: "purge", Key: key}); err == nil {
+			t.Errorf("RegisterBulkAction accepted the built-in key %q", key)
+		}
+		if err := k.RegisterRowAction(RowAction{Name: "open", Key: key}); err == nil {
+			t.Errorf("RegisterRowAction accepted the built-in key %q", key)
+		}
+	}
+	if err := k.RegisterBulkAction(BulkAction{Name: "purge", Key: "x"}); err != nil {
+		t.Errorf("RegisterBulkAction(x): %v", err)
+	}
+	if err := k.RegisterBulkAction(BulkAction{Name: "purge", Key: "X"}); err != nil || len(k.BulkActions()) != 1 {
+		t.Errorf("re-registering purge: %v, %d actions", err, len(k.BulkActions()))
+	}
+}
+
+func TestBulkAction(t *testing.T) {
+	k := newSortTable()
+	var got [][]string
+	err := k.RegisterBulkAction(BulkAction{Name: "purge", Key: "x", Run: func(rows [][]string) error {
+		got = rows
+		rows[0][0] = "changed"
+		return nil
+	}})
+	if err != nil {
+		t.Fatalf("RegisterBulkAction: %v", err)
+	}
+	k.ToggleRowSelection(2)
+	k.ToggleRowSelection(0)
+	_, cmd := k.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	k.Update(runCmd(cmd))
+	if want := [][]string{{"changed", "30", "arm64"}, {"vim", "30", "amd64"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Run received %v, want the selected rows in selection order", got)
+	}
+	if k.rows[2][0] != "git" {
+		t.Errorf("the action changed the table row to %q: it must receive copies", k.rows[2][0])
+	}
+	if k.SelectedCount() != 0 || k.toast == nil || k.toast.Message != "purge: 2 rows" {
+		t.Errorf("after the action: %d rows selected, toast %+v", k.SelectedCount(), k.toast)
+	}
+}
+
+func TestBulkActionConfirmAndFailure(t *testing.T) {
+	k := newSortTable()
+	runs := 0
+	_ = k.RegisterBulkAction(BulkAction{Name: "purge", Key: "x", Confirm: true, Run: func([][]string) error {
+		runs++
+		return errors.New("dpkg is locked")
+	}})
+	k.ToggleRowSelection(1)
+	typeKeys(k, "x")
+	if !k.bulkMenu || !k.bulkConfirm {
+		t.Fatal("x did not ask for confirmation")
+	}
+	typeKeys(k, "n")
+	if !k.bulkMenu || k.bulkConfirm || runs != 0 {
+		t.Fatalf("n should go back to the menu without running the action")
+	}
+	pressKey(k, tea.KeyEnter)
+	_, cmd := k.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	k.Update(runCmd(cmd))
+	if runs != 1 || k.bulkMenu {
+		t.Errorf("y: %d runs, menu open %v", runs, k.bulkMenu)
+	}
+	if k.SelectedCount() != 1 || k.toast == nil || k.toast.Type != Error {
+		t.Errorf("a failed action should keep the selection and report the error, got %d rows, toast %+v", k.SelectedCount(), k.toast)
+	}
+}
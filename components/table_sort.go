@@ -0,0 +1,246 @@
+package components
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	tea "github.com/charmbracelet/bubbletea"
+	gl "github.com/kubex-ecosystem/logz"
+	tp "github.com/kubex-ecosystem/xtui/types"
+)
+
+// SortBy sorts the table by column col only, in the given direction.
+func (k *TableRenderer) SortBy(col int, asc bool) {
+	if col < 0 || col >= len(k.headers) {
+		return
+	}
+	k.SetSortKeys(tp.SortKey{Column: col, Asc: asc})
+}
+
+// SetSortKeys replaces the sort stack (primary key first) and re-sorts the table.
+// Keys pointing outside of the headers are ignored.
+func (k *TableRenderer) SetSortKeys(keys ...tp.SortKey) {
+	k.sortKeys = k.sortKeys[:0]
+	for _, key := range keys {
+		if key.Column >= 0 && key.Column < len(k.headers) && k.sortKeyIndex(key.Column) < 0 {
+			k.sortKeys = append(k.sortKeys, key)
+		}
+	}
+	k.resort()
+}
+
+// GetSortKeys returns the current sort stack, primary key first.
+func (k *TableRenderer) GetSortKeys() []tp.SortKey {
+	return append([]tp.SortKey(nil), k.sortKeys...)
+}
+
+// AddSortKey appends col to the sort stack as its lowest priority key. Columns already in the stack are left alone.
+func (k *TableRenderer) AddSortKey(col int, asc bool) {
+	if col < 0 || col >= len(k.headers) || k.sortKeyIndex(col) >= 0 {
+		return
+	}
+	k.sortKeys = append(k.sortKeys, tp.SortKey{Column: col, Asc: asc})
+	k.SortRows()
+}
+
+// RemoveSortKey removes col from the sort stack.
+func (k *TableRenderer) RemoveSortKey(col int) {
+	i := k.sortKeyIndex(col)
+	if i < 0 {
+		return
+	}
+	k.sortKeys = append(k.sortKeys[:i], k.sortKeys[i+1:]...)
+	k.resort()
+}
+
+// ToggleSortDirection flips the direction of col when it is part of the sort stack.
+func (k *TableRenderer) ToggleSortDirection(col int) {
+	i := k.sortKeyIndex(col)
+	if i < 0 {
+		return
+	}
+	k.sortKeys[i].Asc = !k.sortKeys[i].Asc
+	k.SortRows()
+}
+
+// PromoteSortKey moves col to the top of the sort stack, adding it ascending when missing.
+func (k *TableRenderer) PromoteSortKey(col int) {
+	if col < 0 || col >= len(k.headers) {
+		return
+	}
+	key := tp.SortKey{Column: col, Asc: true}
+	if i := k.sortKeyIndex(col); i >= 0 {
+		key = k.sortKeys[i]
+		k.sortKeys = append(k.sortKeys[:i], k.sortKeys[i+1:]...)
+	}
+	k.sortKeys = append([]tp.SortKey{key}, k.sortKeys...)
+	k.SortRows()
+}
+
+// ClearSort empties the sort stack and restores the natural row order.
+func (k *TableRenderer) ClearSort() {
+	k.sortKeys = k.sortKeys[:0]
+	k.resort()
+}
+
+// sortKeyIndex returns the position of col in the sort stack, or -1.
+func (k *TableRenderer) sortKeyIndex(col int) int {
+	for i, key := range k.sortKeys {
+		if key.Column == col {
+			return i
+		}
+	}
+	return -1
+}
+
+// resort rebuilds the view when keys are removed: client-side rows matching the applied filter are
+// taken back in their natural order and sorted by the remaining keys, lazy sources receive the new
+// stack. The filter being edited is not parsed again, so a filter with errors cannot block it.
+func (k *TableRenderer) resort() {
+	if !k.lazy {
+		k.filteredRows = k.filterRows()
+		k.clampPage()
+	}
+	k.SortRows()
+	k.kTb = k.kTb.Rows(k.GetCurrentPageRows()...)
+}
+
+// canSort reports whether the rows can be sorted: they are in memory, or the source implements
+// types.TableSourceSorter.
+func (k *TableRenderer) canSort() bool {
+	if !k.lazy {
+		return true
+	}
+	_, ok := k.source.(tp.TableSourceSorter)
+	return ok
+}
+
+// SortRows sorts the table rows by the sort stack, using the comparator of each column's type.
+func (k *TableRenderer) SortRows() {
+	if k.lazy {
+		if sorter, ok := k.source.(tp.TableSourceSorter); ok {
+			if err := sorter.SetSort(k.GetSortKeys()); err != nil {
+				gl.Log("error", "Error sorting table source: "+err.Error())
+			}
+		} else if len(k.sortKeys) > 0 {
+			gl.Log("warn", "Table source does not support sorting")
+		}
+		k.resetWindow()
+		k.kTb = k.kTb.Rows(k.GetCurrentPageRows()...)
+		return
+	}
+	if len(k.sortKeys) == 0 {
+		k.regroup()
+		return
+	}
+	k.sortSlice(k.filteredRows)
+	k.regroup()
+	k.kTb = k.kTb.Rows(k.GetCurrentPageRows()...)
+}
+
+// sortSlice sorts rows in place by the sort stack.
+func (k *TableRenderer) sortSlice(rows [][]string) {
+	if len(k.sortKeys) == 0 {
+		return
+	}
+	columnTypes := make([]tp.ColumnType, len(k.sortKeys))
+	for i, key := range k.sortKeys {
+		columnTypes[i] = k.columnType(key.Column)
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		for n, key := range k.sortKeys {
+			if r := columnTypes[n].CompareOrdered(cellAt(rows[i], key.Column), cellAt(rows[j], key.Column), key.Asc); r != 0 {
+				return r < 0
+			}
+		}
+		return false
+	})
+}
+
+// updateSortPicker handles keys while in sort mode: left/right or 1-9 focus a header, enter sorts by
+// the focused column only (reversing it when it already is the only key), a/+ adds it as the next
+// key, d/- removes it, space flips its direction, p makes it primary, c clears the stack, g groups by
+// the focused column (or ungroups), t cycles its footer aggregate and esc leaves sort mode.
+func (k *TableRenderer) updateSortPicker(message tea.KeyMsg) tea.Cmd {
+	switch key := message.String(); key {
+	case "left", "shift+tab":
+		k.focusCol = k.stepVisibleColumn(k.focusCol, -1)
+	case "right", "tab":
+		k.focusCol = k.stepVisibleColumn(k.focusCol, 1)
+	case "enter":
+		asc := true
+		if len(k.sortKeys) == 1 && k.sortKeys[0].Column == k.focusCol {
+			asc = !k.sortKeys[0].Asc
+		}
+		k.SortBy(k.focusCol, asc)
+	case "a", "+":
+		k.AddSortKey(k.focusCol, true)
+	case "d", "-", "backspace":
+		k.RemoveSortKey(k.focusCol)
+	case " ", "space":
+		k.ToggleSortDirection(k.focusCol)
+	case "p":
+		k.PromoteSortKey(k.focusCol)
+	case "c":
+		k.ClearSort()
+	case "g":
+		col := k.focusCol
+		if col == k.groupCol {
+			col = -1
+		}
+		if err := k.SetGroupBy(col); err != nil {
+			return k.notify(Warning, err.Error())
+		}
+	case "t":
+		if err := k.cycleAggregate(k.focusCol); err != nil {
+			return k.notify(Warning, err.Error())
+		}
+	case "esc", "q", "ctrl+o":
+		k.sortPicking = false
+	default:
+		if cols := k.visibleColumns(); len(cols) > 0 {
+			if n, err := strconv.Atoi(key); err == nil && n >= 1 && n <= len(cols) {
+				k.focusCol = cols[n-1]
+			}
+		}
+	}
+	return nil
+}
+
+// stepVisibleColumn returns the visible column delta positions away from col in display order, wrapping around.
+func (k *TableRenderer) stepVisibleColumn(col, delta int) int {
+	cols := k.visibleColumns()
+	if len(cols) == 0 {
+		return col
+	}
+	pos := 0
+	for i, c := range cols {
+		if c == col {
+			pos = i
+			break
+		}
+	}
+	return cols[((pos+delta)%len(cols)+len(cols))%len(cols)]
+}
+
+// headerLabels returns the headers decorated with the sort indicators (direction and priority)
+// and the sort mode focus.
+func (k *TableRenderer) headerLabels() []string {
+	labels := make([]string, len(k.headers))
+	for i, header := range k.headers {
+		label := header
+		if n := k.sortKeyIndex(i); n >= 0 {
+			arrow := "▲"
+			if !k.sortKeys[n].Asc {
+				arrow = "▼"
+			}
+			label = fmt.Sprintf("%s %s%d", label, arrow, n+1)
+		}
+		if k.sortPicking && i == k.focusCol {
+			label = "[" + label + "]"
+		}
+		labels[i] = label
+	}
+	return labels
+}
@@ -0,0 +1,132 @@
+package components
+
+import (
+	"reflect"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	tp "github.com/kubex-ecosystem/xtui/types"
+)
+
+// newSortTable returns a table of packages with an integer size column holding empty cells.
+func newSortTable() *TableRenderer {
+	k := NewTableRenderer(tp.NewTableHandler([]string{"Name", "Size", "Arch"}, [][]string{
+		{"vim", "30", "amd64"},
+		{"nano", "", "arm64"},
+		{"git", "30", "arm64"},
+		{"curl", "5", "amd64"},
+		{"zsh", "", "amd64"},
+	}), nil, nil)
+	k.SetColumnTypes(tp.ColumnString, tp.ColumnInt, tp.ColumnString)
+	return k
+}
+
+// viewNames returns the first cell of the rows of the view, in order.
+func viewNames(k *TableRenderer) []string {
+	var names []string
+	for _, row := range k.filteredRows {
+		names = append(names, row[0])
+	}
+	return names
+}
+
+func TestSortStack(t *testing.T) {
+	k := newSortTable()
+	steps := []struct {
+		name string
+		do   func()
+		keys []tp.SortKey
+		want []string
+	}{
+		{
+			"size descending then name, empty sizes last",
+			func() { k.SetSortKeys(tp.SortKey{Column: 1}, tp.SortKey{Column: 0, Asc: true}) },
+			[]tp.SortKey{{Column: 1}, {Column: 0, Asc: true}},
+			[]string{"git", "vim", "curl", "nano", "zsh"},
+		},
+		{
+			"size ascending keeps empty sizes last",
+			func() { k.ToggleSortDirection(1) },
+			[]tp.SortKey{{Column: 1, Asc: true}, {Column: 0, Asc: true}},
+			[]string{"curl", "git", "vim", "nano", "zsh"},
+		},
+		{
+			"promoted arch",
+			func() { k.PromoteSortKey(2) },
+			[]tp.SortKey{{Column: 2, Asc: true}, {Column: 1, Asc: true}, {Column: 0, Asc: true}},
+			[]string{"curl", "vim", "zsh", "git", "nano"},
+		},
+		{
+			"added column already in the stack",
+			func() { k.AddSortKey(1, false) },
+			[]tp.SortKey{{Column: 2, Asc: true}, {Column: 1, Asc: true}, {Column: 0, Asc: true}},
+			[]string{"curl", "vim", "zsh", "git", "nano"},
+		},
+		{
+			"removed arch",
+			func() { k.RemoveSortKey(2) },
+			[]tp.SortKey{{Column: 1, Asc: true}, {Column: 0, Asc: true}},
+			[]string{"curl", "git", "vim", "nano", "zsh"},
+		},
+		{
+			"cleared",
+			k.ClearSort,
+			nil,
+			[]string{"vim", "nano", "git", "curl", "zsh"},
+		},
+		{
+			"column outside of the headers",
+			func() { k.SetSortKeys(tp.SortKey{Column: 3}, tp.SortKey{Column: 0}) },
+			[]tp.SortKey{{Column: 0}},
+			[]string{"zsh", "vim", "nano", "git", "curl"},
+		},
+	}
+	for _, step := range steps {
+		step.do()
+		if got := k.GetSortKeys(); !reflect.DeepEqual(got, step.keys) {
+			t.Errorf("%s: GetSortKeys() = %v, want %v", step.name, got, step.keys)
+		}
+		if got := viewNames(k); !reflect.DeepEqual(got, step.want) {
+			t.Errorf("%s: rows = %v, want %v", step.name, got, step.want)
+		}
+	}
+}
+
+func TestSortKeepsFilter(t *testing.T) {
+	k := newSortTable()
+	k.SortBy(1, false)
+	if err := k.SetFilter("arch=amd64"); err != nil {
+		t.Fatalf("SetFilter: %v", err)
+	}
+	if got, want := viewNames(k), []string{"vim", "curl", "zsh"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("filtered rows = %v, want %v", got, want)
+	}
+	k.ClearSort()
+	if got, want := viewNames(k), []string{"vim", "curl", "zsh"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("rows after ClearSort = %v, want %v", got, want)
+	}
+}
+
+func TestSortPicker(t *testing.T) {
+	k := newSortTable()
+	press := func(keys ...string) {
+		for _, key := range keys {
+			k.updateSortPicker(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+		}
+	}
+	k.sortPicking, k.focusCol = true, 0
+	press("2")
+	k.updateSortPicker(tea.KeyMsg{Type: tea.KeyEnter})
+	k.updateSortPicker(tea.KeyMsg{Type: tea.KeyEnter}) // reverses the only key
+	press("1", "a")
+	if got, want := k.GetSortKeys(), []tp.SortKey{{Column: 1}, {Column: 0, Asc: true}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GetSortKeys() = %v, want %v", got, want)
+	}
+	if got, want := k.headerLabels(), []string{"[Name ▲2]", "Size ▼1", "Arch"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("headerLabels() = %q, want %q", got, want)
+	}
+	press("c")
+	if got := k.GetSortKeys(); len(got) != 0 {
+		t.Errorf("GetSortKeys() after c = %v, want none", got)
+	}
+}
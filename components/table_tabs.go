@@ -0,0 +1,263 @@
+package components
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	gl "github.com/kubex-ecosystem/logz"
+	tp "github.com/kubex-ecosystem/xtui/types"
+)
+
+// tabBarHeight is the number of lines the tab bar adds above the table of the current tab.
+const tabBarHeight = 1
+
+// Keys switching tabs. The tables cannot use them (see reservedKeys).
+var (
+	nextTabKeys     = []string{"alt+right", "ctrl+pgdown"}
+	previousTabKeys = []string{"alt+left", "ctrl+pgup"}
+	tabNumberKeys   = []string{"alt+1", "alt+2", "alt+3", "alt+4", "alt+5", "alt+6", "alt+7", "alt+8", "alt+9"}
+)
+
+// TabbedTables shows the tables of a types.MultiTableManager one at a time under a tab bar. Each tab
+// is a TableRenderer with its own filter, sort, selection and layout, created the first time the tab
+// is shown: handlers are only asked for their rows then, outside of the UI loop.
+type TabbedTables struct {
+	manager      *tp.MultiTableManager
+	tables       []*TableRenderer
+	loading      []bool
+	loadErr      []error
+	customStyles map[string]lipgloss.Color
+	styleFunc    StyleFunc
+	onLoad       func(index int, k *TableRenderer)
+	size         *tea.WindowSizeMsg
+}
+
+// tabMsg is a message produced by the commands of one tab, delivered to that tab only.
+type tabMsg struct {
+	index int
+	msg   tea.Msg
+}
+
+// tabLoadedMsg carries the renderer built for a tab.
+type tabLoadedMsg struct {
+	index int
+	table *TableRenderer
+	err   error
+}
+
+// NewTabbedTables creates a tabbed screen for the tables of manager, starting on manager.Current.
+func NewTabbedTables(manager *tp.MultiTableManager, customStyles map[string]lipgloss.Color, styleFunc StyleFunc) *TabbedTables {
+	if manager == nil {
+		manager = &tp.MultiTableManager{}
+	}
+	return &TabbedTables{
+		manager:      manager,
+		tables:       make([]*TableRenderer, manager.Len()),
+		loading:      make([]bool, manager.Len()),
+		loadErr:      make([]error, manager.Len()),
+		customStyles: customStyles,
+		styleFunc:    styleFunc,
+	}
+}
+
+// SetOnLoad sets a function called with every table once it is loaded, e.g. to register row actions
+// or set a row key.
+func (m *TabbedTables) SetOnLoad(onLoad func(index int, k *TableRenderer)) { m.onLoad = onLoad }
+
+// Manager returns the manager holding the handlers and the current tab.
+func (m *TabbedTables) Manager() *tp.MultiTableManager { return m.manager }
+
+// Table returns the renderer of tab i, or nil while it is not loaded.
+func (m *TabbedTables) Table(i int) *TableRenderer {
+	if i >= 0 && i < len(m.tables) {
+		return m.tables[i]
+	}
+	return nil
+}
+
+// CurrentTable returns the renderer of the current tab, or nil while it is not loaded.
+func (m *TabbedTables) CurrentTable() *TableRenderer { return m.Table(m.manager.Current) }
+
+// SelectTab shows tab i, loading it when needed.
+func (m *TabbedTables) SelectTab(i int) tea.Cmd {
+	m.manager.Select(i)
+	return m.load(m.manager.Current)
+}
+
+// NextTab shows the next tab.
+func (m *TabbedTables) NextTab() tea.Cmd {
+	m.manager.Next()
+	return m.load(m.manager.Current)
+}
+
+// PreviousTab shows the previous tab.
+func (m *TabbedTables) PreviousTab() tea.Cmd {
+	m.manager.Previous()
+	return m.load(m.manager.Current)
+}
+
+// load builds the renderer of tab i in a command, unless it is loaded or loading.
+func (m *TabbedTables) load(i int) tea.Cmd {
+	m.sync()
+	if i < 0 || i >= len(m.tables) || m.tables[i] != nil || m.loading[i] {
+		return nil
+	}
+	m.loading[i], m.loadErr[i] = true, nil
+	handler, customStyles, styleFunc := m.manager.Handlers[i], m.customStyles, m.styleFunc
+	return func() (msg tea.Msg) {
+		defer func() {
+			if r := recover(); r != nil {
+				msg = tabLoadedMsg{index: i, err: fmt.Errorf("loading table: %v", r)}
+			}
+		}()
+		return tabLoadedMsg{index: i, table: NewTableRenderer(handler, customStyles, styleFunc)}
+	}
+}
+
+// sync grows the per-tab state after tables were added to the manager.
+func (m *TabbedTables) sync() {
+	for len(m.tables) < m.manager.Len() {
+		m.tables = append(m.tables, nil)
+		m.loading = append(m.loading, false)
+		m.loadErr = append(m.loadErr, nil)
+	}
+}
+
+// loaded installs the renderer of a tab and starts it.
+func (m *TabbedTables) loaded(msg tabLoadedMsg) tea.Cmd {
+	m.loading[msg.index] = false
+	if msg.err != nil {
+		m.loadErr[msg.index] = msg.err
+		gl.Log("error", "Error loading table "+m.manager.Title(msg.index)+": "+msg.err.Error())
+		return nil
+	}
+	m.tables[msg.index] = msg.table
+	index := msg.index
+	msg.table.setRoute(func(msg tea.Msg) tea.Msg { return tabMsg{index: index, msg: msg} })
+	if m.onLoad != nil {
+		m.onLoad(msg.index, msg.table)
+	}
+	cmds := []tea.Cmd{m.tag(msg.index, msg.table.Init())}
+	if m.size != nil {
+		cmds = append(cmds, m.send(msg.index, m.tableSize()))
+	}
+	return tea.Batch(cmds...)
+}
+
+// tableSize returns the window size left to the tables under the tab bar.
+func (m *TabbedTables) tableSize() tea.WindowSizeMsg {
+	return tea.WindowSizeMsg{Width: m.size.Width, Height: max(m.size.Height-tabBarHeight, 1)}
+}
+
+// send delivers msg to tab i and returns its command, tagged for that tab.
+func (m *TabbedTables) send(i int, msg tea.Msg) tea.Cmd {
+	if m.tables[i] == nil {
+		return nil
+	}
+	_, cmd := m.tables[i].Update(msg)
+	return m.tag(i, cmd)
+}
+
+// tag makes the messages the table of tab i produces go back to that tab rather than to whichever
+// tab is shown when they arrive.
+func (m *TabbedTables) tag(i int, cmd tea.Cmd) tea.Cmd { return m.tables[i].route(cmd) }
+
+// Init loads the current tab.
+func (m *TabbedTables) Init() tea.Cmd { return m.load(m.manager.Current) }
+
+// Update switches tabs with alt+right/alt+left (or ctrl+pgdown/ctrl+pgup) and alt+1..alt+9, and
+// hands every other message to the current tab.
+func (m *TabbedTables) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	m.sync()
+	current := m.manager.Current
+	switch message := msg.(type) {
+	case tabLoadedMsg:
+		return m, m.loaded(message)
+	case tabMsg:
+		if message.index < len(m.tables) {
+			return m, m.send(message.index, message.msg)
+		}
+		return m, nil
+	case tea.WindowSizeMsg:
+		m.size = &message
+		var cmds []tea.Cmd
+		for i := range m.tables {
+			cmds = append(cmds, m.send(i, m.tableSize()))
+		}
+		return m, tea.Batch(cmds...)
+	case tea.KeyMsg:
+		switch key := message.String(); {
+		case slices.Contains(nextTabKeys, key):
+			return m, m.NextTab()
+		case slices.Contains(previousTabKeys, key):
+			return m, m.PreviousTab()
+		case slices.Contains(tabNumberKeys, key):
+			return m, m.SelectTab(slices.Index(tabNumberKeys, key))
+		case key == "q" || key == "ctrl+c":
+			if current >= len(m.tables) || m.tables[current] == nil {
+				return m, tea.Quit
+			}
+		}
+	}
+	if current >= len(m.tables) {
+		return m, nil
+	}
+	return m, m.send(current, msg)
+}
+
+// tabBarView renders the tab bar.
+func (m *TabbedTables) tabBarView() string {
+	active := lipgloss.NewStyle().Bold(true).Padding(0, 1).Foreground(lipgloss.Color("#01BE85")).Background(lipgloss.Color("#00432F"))
+	inactive := lipgloss.NewStyle().Padding(0, 1).Foreground(lipgloss.Color("245"))
+	tabs := make([]string, 0, m.manager.Len())
+	for i := range m.manager.Len() {
+		label := m.manager.Title(i)
+		if i < 9 {
+			label = fmt.Sprintf("%d %s", i+1, label)
+		}
+		if i < len(m.tables) && m.tables[i] != nil && m.tables[i].SelectedCount() > 0 {
+			label += fmt.Sprintf(" (%d)", m.tables[i].SelectedCount())
+		}
+		if i == m.manager.Current {
+			tabs = append(tabs, active.Render(label))
+		} else {
+			tabs = append(tabs, inactive.Render(label))
+		}
+	}
+	bar := strings.Join(tabs, blurredStyle.Render("│"))
+	return bar + blurredStyle.Render("   alt+←/→ switch tabs")
+}
+
+// View renders the tab bar and the current tab.
+func (m *TabbedTables) View() string {
+	if m.manager.Len() == 0 {
+		return "\nNo tables.\n"
+	}
+	current := m.manager.Current
+	body := "\n" + blurredStyle.Render("Loading "+m.manager.Title(current)+"…") + "\n"
+	switch {
+	case current < len(m.loadErr) && m.loadErr[current] != nil:
+		body = "\n" + errorStyle.Render("✗ "+m.loadErr[current].Error()) + "\n"
+	case current < len(m.tables) && m.tables[current] != nil:
+		body = m.tables[current].View()
+	}
+	return m.tabBarView() + "\n" + body
+}
+
+// StartTabbedTables starts a tabbed screen for the tables of manager.
+func StartTabbedTables(manager *tp.MultiTableManager, customStyles map[string]lipgloss.Color) error {
+	return StartTabbedTablesFromModel(NewTabbedTables(manager, customStyles, nil))
+}
+
+// StartTabbedTablesFromModel starts the given tabbed screen.
+func StartTabbedTablesFromModel(m *TabbedTables) error {
+	prog := tea.NewProgram(m, tea.WithAltScreen())
+	if _, err := prog.Run(); err != nil {
+		gl.Log("error", "Error running tabbed table screen: "+err.Error())
+		return err
+	}
+	return nil
+}
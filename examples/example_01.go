@@ -1,39 +1,32 @@
 package examples
 
 import (
+	"strings"
+
 	tea "github.com/charmbracelet/bubbletea"
 	c "github.com/kubex-ecosystem/xtui/components"
+	tp "github.com/kubex-ecosystem/xtui/types"
 )
 
-type AppModel struct {
-	tables       []*c.TableRenderer
-	currentTable int
-}
-
-func (m *AppModel) Init() tea.Cmd {
-	return nil
-}
-
-func (m *AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	switch message := msg.(type) {
-	case tea.KeyMsg:
-		switch message.String() {
-		case "ctrl+tab":
-			m.currentTable = (m.currentTable + 1) % len(m.tables)
-		case "ctrl+shift+tab":
-			m.currentTable = (m.currentTable - 1 + len(m.tables)) % len(m.tables)
-		case "enter":
-			/*config := t*/
-
-			// Abre um formulário baseado na tabela atual e linha selecionada
-			//if err := c.ShowForm(/*m.tables[m.currentTable].GetSelectedRow()*/); err != nil {
-			// 	return m, err
-			//}
-		}
-	}
-	return m, nil
-}
-
-func (m *AppModel) View() string {
-	return m.tables[m.currentTable].View()
+// NewAppModel creates a tabbed screen with one tab per handler and registers an "Open" row action
+// (key o, or the m context menu) on every table, reporting the selected row in the status line.
+// Tabs are switched with alt+left/alt+right or alt+1..alt+9.
+func NewAppModel(titles []string, handlers ...tp.TableDataHandler) *c.TabbedTables {
+	tabs := c.NewTabbedTables(&tp.MultiTableManager{Handlers: handlers, Titles: titles}, nil, nil)
+	tabs.SetOnLoad(func(_ int, table *c.TableRenderer) {
+		_ = table.RegisterRowAction(c.RowAction{
+			Name: "Open",
+			Key:  "o",
+			Run: func(row []string, headers []string) tea.Cmd {
+				fields := make([]string, 0, len(row))
+				for i, value := range row {
+					if i < len(headers) {
+						fields = append(fields, headers[i]+"="+value)
+					}
+				}
+				return func() tea.Msg { return c.ActionResultMsg{Message: "Opened " + strings.Join(fields, ", ")} }
+			},
+		})
+	})
+	return tabs
 }
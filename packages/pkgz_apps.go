@@ -7,6 +7,7 @@ import (
 	"os/exec"
 	"strings"
 
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	gl "github.com/kubex-ecosystem/logz"
 	cmp "github.com/kubex-ecosystem/xtui/components"
@@ -34,6 +35,12 @@ func (h *AppsTableHandler) GetHeaders() []string {
 	return []string{"Name", "Version", "Method", "Status", "Description"}
 }
 
+// GetColumnTypes retorna os tipos das colunas da tabela de aplicativos.
+// A versão é ordenada como versão (semver/dpkg), e não como texto.
+func (h *AppsTableHandler) GetColumnTypes() []t.ColumnType {
+	return []t.ColumnType{t.ColumnString, t.ColumnSemver, t.ColumnString, t.ColumnString, t.ColumnString}
+}
+
 // GetRows retorna as linhas da tabela de aplicativos.
 // Retorna um slice de slices de strings com as linhas da tabela.
 func (h *AppsTableHandler) GetRows() [][]string {
@@ -141,7 +148,7 @@ func InstallApps(args ...string) error {
 // getInstalledAppsHandler obtém os aplicativos instalados filtrados por nome, status ou método de instalação.
 // Recebe o nome, status e método de instalação.
 // Retorna um ponteiro para AppsTableHandler e um erro, se houver.
-func getInstalledAppsHandler(name string, status string, method string) (t.TableDataHandler, error) {
+func getInstalledAppsHandler(name string, status string, method string) (*AppsTableHandler, error) {
 	// Filtra os aplicativos instalados por nome, status ou method de instalação (auto/manual) usando dpkg-query e se preciso grep
 	//nameFilter := ""
 	//if name != "" {
@@ -155,6 +162,16 @@ func getInstalledAppsHandler(name string, status string, method string) (t.Table
 	//if method != "" {
 	//	methodFilter = fmt.Sprintf("| grep -i %s", method)
 	//}
+	apps, err := queryInstalledApps()
+	if err != nil {
+		return nil, err
+	}
+	return &AppsTableHandler{apps: apps}, nil
+}
+
+// queryInstalledApps lista os pacotes conhecidos pelo dpkg.
+// Retorna a lista de aplicativos e um erro, se houver.
+func queryInstalledApps() ([]AppInfo, error) {
 	cmd := exec.Command("bash", "-c", "dpkg-query -W -f='${Package}\\t${Version}\\t${Status}\\t${Description}\\n'") //nolint:gosec
 	output, err := cmd.Output()
 	if err != nil {
@@ -185,12 +202,74 @@ func getInstalledAppsHandler(name string, status string, method string) (t.Table
 			Description: fields[3],
 		})
 	}
+	return apps, nil
+}
+
+// reload consulta o dpkg novamente, para a tabela refletir as remoções e atualizações feitas pelas ações.
+func (h *AppsTableHandler) reload() error {
+	apps, err := queryInstalledApps()
+	if err != nil {
+		return err
+	}
+	h.apps = apps
+	return nil
+}
 
-	return &AppsTableHandler{apps: apps}, nil
+// registerAppActions adiciona à tabela as ações sobre o pacote da linha selecionada:
+// mostrar detalhes (i), atualizar (u) e remover (r).
+func registerAppActions(tbRenderer *cmp.TableRenderer, handler *AppsTableHandler) {
+	installed := func(row []string) bool { return len(row) > 3 && row[3] == "installed" }
+	actions := []cmp.RowAction{{
+		Name: "Show details",
+		Key:  "i",
+		Exec: func(row []string, _ []string) (*exec.Cmd, tea.ExecCallback) {
+			cmd := exec.Command("sh", "-c", `dpkg -s "$1" | ${PAGER:-less}`, "sh", row[0]) //nolint:gosec
+			return cmd, func(err error) tea.Msg {
+				if err != nil {
+					return cmp.ActionResultMsg{Message: "dpkg -s " + row[0], Err: err}
+				}
+				return cmp.ActionResultMsg{}
+			}
+		},
+	}, {
+		Name:    "Upgrade",
+		Key:     "u",
+		Enabled: installed,
+		Exec: func(row []string, _ []string) (*exec.Cmd, tea.ExecCallback) {
+			return handler.aptCmd("Upgraded "+row[0], "install", "--only-upgrade", row[0])
+		},
+	}, {
+		Name:    "Remove",
+		Key:     "r",
+		Confirm: true,
+		Enabled: installed,
+		Exec: func(row []string, _ []string) (*exec.Cmd, tea.ExecCallback) {
+			return handler.aptCmd("Removed "+row[0], "remove", row[0])
+		},
+	}}
+	for _, action := range actions {
+		if err := tbRenderer.RegisterRowAction(action); err != nil {
+			gl.Log("error", "Error registering table action: "+err.Error())
+		}
+	}
+}
+
+// aptCmd prepara o apt-get com sudo, executado no terminal fora da tabela, e o retorno que recarrega a
+// lista ao terminar.
+func (h *AppsTableHandler) aptCmd(message string, args ...string) (*exec.Cmd, tea.ExecCallback) {
+	cmd := exec.Command("sudo", append([]string{"apt-get"}, args...)...) //nolint:gosec
+	return cmd, func(err error) tea.Msg {
+		if err != nil {
+			gl.Log("error", "Error running apt-get: "+err.Error())
+		} else if err = h.reload(); err != nil {
+			gl.Log("error", "Error reloading installed apps: "+err.Error())
+		}
+		return cmp.ActionResultMsg{Message: message, Err: err, Reload: true}
+	}
 }
 
 // ShowInstalledAppsTable exibe a tabela de aplicativos instalados.
-// Recebe uma lista de argumentos.
+// Recebe uma lista de argumentos: nome, status, método e perfil de visualização.
 // Retorna um erro, se houver.
 func ShowInstalledAppsTable(args ...string) error {
 	name := ""
@@ -205,6 +284,10 @@ func ShowInstalledAppsTable(args ...string) error {
 	if len(args) > 2 {
 		method = args[2]
 	}
+	profile := ""
+	if len(args) > 3 {
+		profile = args[3]
+	}
 	handler, err := getInstalledAppsHandler(name, status, method)
 	if err != nil {
 		return err
@@ -214,7 +297,35 @@ func ShowInstalledAppsTable(args ...string) error {
 		"row":    lipgloss.Color("#252"),
 	}
 
-	return cmp.StartTableScreen(handler, customStyles)
+	tbRenderer := cmp.NewTableRenderer(handler, customStyles, nil)
+	tbRenderer.SetTableID("packages.installed")
+	registerAppActions(tbRenderer, handler)
+	if profile != "" {
+		if err := tbRenderer.LoadProfile(profile); err != nil {
+			return err
+		}
+	}
+	if filter := installedAppsFilter(name, status, method); filter != "" || profile == "" {
+		if err := tbRenderer.SetFilter(filter); err != nil {
+			return err
+		}
+	}
+	return cmp.StartTableScreenFromRenderer(tbRenderer)
+}
+
+// installedAppsFilter monta a expressão de filtro da tabela a partir do nome, status e método.
+func installedAppsFilter(name string, status string, method string) string {
+	var terms []string
+	if name != "" {
+		terms = append(terms, fmt.Sprintf("name:%q", name))
+	}
+	if status != "" {
+		terms = append(terms, fmt.Sprintf("status=%q", status))
+	}
+	if method != "" {
+		terms = append(terms, fmt.Sprintf("method=%q", method))
+	}
+	return strings.Join(terms, " and ")
 }
 
 // installGoogleAuthenticator instala o Google Authenticator.
@@ -1,5 +1,6 @@
 package types
 
+// DataExporter writes a table to files in the built-in formats.
 type DataExporter interface {
 	ExportToCSV(filename string) error
 	ExportToYAML(filename string) error
@@ -9,33 +10,35 @@ type DataExporter interface {
 	ExportToPDF(filename string) error
 	ExportToMarkdown(filename string) error
 }
-type dataExporter struct{}
 
-func (e dataExporter) ExportToCSV(filename string) error {
-	// Implementation for exporting to CSV
-	return nil
+// FormatExporter writes a table to files in any registered format (see RegisterExporter).
+type FormatExporter interface {
+	Export(filename, format string) error
 }
-func (e dataExporter) ExportToYAML(filename string) error {
-	// Implementation for exporting to YAML
-	return nil
-}
-func (e dataExporter) ExportToJSON(filename string) error {
-	// Implementation for exporting to JSON
-	return nil
-}
-func (e dataExporter) ExportToXML(filename string) error {
-	// Implementation for exporting to XML
-	return nil
-}
-func (e dataExporter) ExportToExcel(filename string) error {
-	// Implementation for exporting to Excel
-	return nil
+
+type dataExporter struct {
+	table *TableExport
 }
-func (e dataExporter) ExportToPDF(filename string) error {
-	// Implementation for exporting to PDF
-	return nil
+
+// NewDataExporter returns a DataExporter writing the given table.
+func NewDataExporter(table *TableExport) DataExporter {
+	return dataExporter{table: table}
 }
-func (e dataExporter) ExportToMarkdown(filename string) error {
-	// Implementation for exporting to Markdown
-	return nil
+
+// NewFormatExporter returns a FormatExporter writing the given table.
+func NewFormatExporter(table *TableExport) FormatExporter {
+	return dataExporter{table: table}
 }
+
+// Export writes the table to filename with the exporter of format, or of the file extension when
+// format is empty.
+func (e dataExporter) Export(filename, format string) error {
+	return ExportFile(filename, format, e.table)
+}
+func (e dataExporter) ExportToCSV(filename string) error      { return e.Export(filename, "csv") }
+func (e dataExporter) ExportToYAML(filename string) error     { return e.Export(filename, "yaml") }
+func (e dataExporter) ExportToJSON(filename string) error     { return e.Export(filename, "json") }
+func (e dataExporter) ExportToXML(filename string) error      { return e.Export(filename, "xml") }
+func (e dataExporter) ExportToExcel(filename string) error    { return e.Export(filename, "xlsx") }
+func (e dataExporter) ExportToPDF(filename string) error      { return e.Export(filename, "pdf") }
+func (e dataExporter) ExportToMarkdown(filename string) error { return e.Export(filename, "md") }
@@ -0,0 +1,61 @@
+package types
+
+// TableExport is the data handed to the exporters: the headers and column types of the exported
+// columns and an iterator over the rows, so exporters never need every row in memory at once.
+type TableExport struct {
+	Title       string
+	Headers     []string
+	ColumnTypes []ColumnType
+	EachRow     func(fn func(row []string) error) error
+	Footer      func() []string // optional footer row (e.g. totals), read once EachRow is done
+	Options     ExportOptions
+}
+
+// ExportOptions are format-specific settings read by the exporters that support them.
+type ExportOptions struct {
+	XMLRoot   string            // root element of XML exports (DefaultXMLRootElement when empty)
+	XMLRow    string            // row element of XML exports (DefaultXMLRowElement when empty)
+	HTMLTheme string            // CSS embedded in HTML exports; empty exports an unstyled table
+	CSVFooter bool              // write the footer as the last record of CSV and TSV exports
+	PDF       PDFOptions        // page layout, titles and colours of PDF reports
+	Extra     map[string]string // settings of custom exporters
+}
+
+// NewTableExport creates a TableExport over in-memory rows. Missing column types are auto-detected.
+func NewTableExport(headers []string, rows [][]string, columnTypes []ColumnType) *TableExport {
+	detected := DetectColumnTypes(len(headers), rows)
+	for i := range detected {
+		if i < len(columnTypes) && columnTypes[i] != "" {
+			detected[i] = columnTypes[i]
+		}
+	}
+	return &TableExport{
+		Headers:     headers,
+		ColumnTypes: detected,
+		EachRow: func(fn func(row []string) error) error {
+			for _, row := range rows {
+				if err := fn(row); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// FooterRow returns the footer row of t, or nil when it has none. Exporters call it after EachRow,
+// so footers can summarise the rows written.
+func (t *TableExport) FooterRow() []string {
+	if t.Footer == nil {
+		return nil
+	}
+	return t.Footer()
+}
+
+// ColumnType returns the type of column col, falling back to string.
+func (t *TableExport) ColumnType(col int) ColumnType {
+	if col >= 0 && col < len(t.ColumnTypes) && t.ColumnTypes[col] != "" {
+		return t.ColumnTypes[col]
+	}
+	return ColumnString
+}
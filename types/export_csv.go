@@ -0,0 +1,27 @@
+package types
+
+import (
+	"encoding/csv"
+	"io"
+)
+
+// WriteCSV writes t as delimited text with a header row, using comma as the field delimiter. The
+// footer is left out, as readers would take it for data, unless t.Options.CSVFooter asks for it as
+// the last record.
+func WriteCSV(w io.Writer, t *TableExport, comma rune) error {
+	writer := csv.NewWriter(w)
+	writer.Comma = comma
+	if err := writer.Write(t.Headers); err != nil {
+		return err
+	}
+	if err := t.EachRow(writer.Write); err != nil {
+		return err
+	}
+	if footer := t.FooterRow(); footer != nil && t.Options.CSVFooter {
+		if err := writer.Write(footer); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
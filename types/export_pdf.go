@@ -0,0 +1,257 @@
+package types
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/johnfercher/maroto/pkg/color"
+	"github.com/johnfercher/maroto/pkg/consts"
+	"github.com/johnfercher/maroto/pkg/pdf"
+	"github.com/johnfercher/maroto/pkg/props"
+)
+
+// PDFOptions configure the PDF report written by WritePDF.
+type PDFOptions struct {
+	Portrait bool   // portrait pages instead of landscape
+	PageSize string // A3, A4, A5, Letter (default) or Legal
+	Title    string // report title; defaults to the table title
+	Subtitle string
+	Footer   string // text on the left of every page footer; defaults to the generation date
+
+	// Colours as "#RRGGBB" or an ANSI 256 colour number, usually taken from the table theme.
+	HeaderBackground string // header row fill (default "#00432F")
+	HeaderForeground string // header row text (default "#FFFFFF")
+	StripeBackground string // fill of every other row (default: a light tint of the header background)
+}
+
+// PDF layout, in millimetres and points.
+const (
+	pdfFontSize      = 8.0
+	pdfCellPadding   = 1.2
+	pdfLinePadding   = 0.6
+	pdfMinColumnChar = 4
+	pdfMaxColumnChar = 40
+)
+
+var pdfPageSizes = map[string]consts.PageSize{
+	"a3": consts.A3, "a4": consts.A4, "a5": consts.A5, "letter": consts.Letter, "legal": consts.Legal,
+}
+
+// WritePDF writes t as a report: a title block, a header row repeated on every page, columns sized
+// to their content, rows wrapped instead of truncated, zebra striping and a footer with the page number.
+// The footer row of t, if any, closes the table in the header colours.
+func WritePDF(w io.Writer, t *TableExport) error {
+	opts := t.Options.PDF
+	rows, err := collectRows(t)
+	if err != nil {
+		return err
+	}
+	totals := collectFooter(t)
+	headers := make([]string, len(t.Headers))
+	for i, header := range t.Headers {
+		headers[i] = exportCell(header)
+	}
+
+	orientation := consts.Landscape
+	if opts.Portrait {
+		orientation = consts.Portrait
+	}
+	pageSize, ok := pdfPageSizes[strings.ToLower(opts.PageSize)]
+	if !ok {
+		pageSize = consts.Letter
+	}
+	m := pdf.NewMaroto(orientation, pageSize)
+	m.SetPageMargins(10, 10, 10)
+	m.SetAliasNbPages("{nbpages}")
+
+	title := opts.Title
+	if title == "" {
+		title = t.Title
+	}
+	generated := time.Now()
+	m.SetCreationDate(generated)
+	m.SetCreator("xtui", true)
+	if title != "" {
+		m.SetTitle(title, true)
+	}
+	footer := opts.Footer
+	if footer == "" {
+		footer = "Generated " + generated.Format("2006-01-02 15:04")
+	}
+
+	headerBg := pdfColor(opts.HeaderBackground, color.Color{Red: 0x00, Green: 0x43, Blue: 0x2F})
+	headerFg := pdfColor(opts.HeaderForeground, color.NewWhite())
+	stripe := pdfColor(opts.StripeBackground, pdfTint(headerBg, 0.88))
+
+	// Column weights follow the content width; the grid is as wide as their sum, so any number of
+	// columns fits the page.
+	weights := make([]uint, len(headers))
+	var gridSum uint
+	for i, header := range headers {
+		width := utf8.RuneCountInString(header)
+		for _, row := range rows {
+			width = max(width, utf8.RuneCountInString(row[i]))
+		}
+		width = max(width, utf8.RuneCountInString(cellOf(totals, i)))
+		weights[i] = uint(min(max(width, pdfMinColumnChar), pdfMaxColumnChar))
+		gridSum += weights[i]
+	}
+	if gridSum == 0 {
+		gridSum = 1
+	}
+	m.SetMaxGridSum(float64(gridSum))
+	left, _, right, _ := m.GetPageMargins()
+	pageWidth, _ := m.GetPageSize()
+	colWidths := make([]float64, len(weights))
+	for i, weight := range weights {
+		colWidths[i] = (pageWidth - left - right) * float64(weight) / float64(gridSum)
+	}
+
+	alignments := make([]consts.Align, len(headers))
+	for i := range headers {
+		switch columnAlignment(t.ColumnType(i)) {
+		case "right":
+			alignments[i] = consts.Right
+		case "center":
+			alignments[i] = consts.Center
+		default:
+			alignments[i] = consts.Left
+		}
+	}
+
+	cellProps := func(i int, style consts.Style, fg color.Color) props.Text {
+		p := props.Text{
+			Top: pdfCellPadding, Left: pdfCellPadding, Right: pdfCellPadding,
+			Size: pdfFontSize, Style: style, Align: alignments[i],
+			VerticalPadding: pdfLinePadding, Color: fg,
+		}
+		p.MakeValid(consts.Arial)
+		return p
+	}
+	// Rows are as tall as their most wrapped cell, counted with maroto's own line breaking.
+	rowHeight := func(cells []string, style consts.Style) float64 {
+		lines := 1
+		if pm, ok := m.(*pdf.PdfMaroto); ok {
+			for i, cell := range cells {
+				lines = max(lines, pm.TextHelper.GetLinesQuantity(cell, cellProps(i, style, color.Color{}), colWidths[i]-2*pdfCellPadding))
+			}
+		}
+		// Heights are rounded up to whole millimetres: maroto truncates offsets when paginating and
+		// fractional rows would let the footer spill onto a page of its own.
+		return math.Ceil(float64(lines)*(pdfFontSize*25.4/72+pdfLinePadding) + 2*pdfCellPadding)
+	}
+	writeRow := func(cells []string, style consts.Style, fg color.Color) {
+		m.Row(rowHeight(cells, style), func() {
+			for i, cell := range cells {
+				m.Col(weights[i], func() { m.Text(cell, cellProps(i, style, fg)) })
+			}
+		})
+	}
+
+	m.RegisterHeader(func() {
+		if m.GetCurrentPage() == 0 && (title != "" || opts.Subtitle != "") {
+			if title != "" {
+				m.Row(9, func() {
+					m.Col(0, func() { m.Text(title, props.Text{Size: 14, Style: consts.Bold}) })
+				})
+			}
+			if opts.Subtitle != "" {
+				m.Row(6, func() {
+					m.Col(0, func() {
+						m.Text(opts.Subtitle, props.Text{Size: 10, Color: color.Color{Red: 96, Green: 96, Blue: 96}})
+					})
+				})
+			}
+			m.Row(3, func() {})
+		}
+		m.SetBackgroundColor(headerBg)
+		writeRow(headers, consts.Bold, headerFg)
+		m.SetBackgroundColor(color.NewWhite())
+	})
+	m.RegisterFooter(func() {
+		m.Row(8, func() {
+			half := uint(math.Ceil(float64(gridSum) / 2))
+			grey := color.Color{Red: 96, Green: 96, Blue: 96}
+			m.Col(half, func() {
+				m.Text(footer, props.Text{Top: 3, Size: 7, Color: grey})
+			})
+			m.Col(gridSum-half, func() {
+				m.Text(fmt.Sprintf("Page %d of {nbpages}", m.GetCurrentPage()+1), props.Text{Top: 3, Size: 7, Align: consts.Right, Color: grey})
+			})
+		})
+	})
+
+	if len(rows) == 0 {
+		// Make sure the header is printed for empty tables.
+		m.Row(0, func() {})
+	}
+	for i, row := range rows {
+		if i%2 == 1 {
+			m.SetBackgroundColor(stripe)
+		}
+		writeRow(row, consts.Normal, color.Color{})
+		m.SetBackgroundColor(color.NewWhite())
+	}
+	if totals != nil {
+		m.SetBackgroundColor(headerBg)
+		writeRow(totals, consts.Bold, headerFg)
+		m.SetBackgroundColor(color.NewWhite())
+	}
+
+	out, err := m.Output()
+	if err != nil {
+		return err
+	}
+	_, err = out.WriteTo(w)
+	return err
+}
+
+// pdfColor parses "#RRGGBB", "#RGB" or an ANSI 256 colour number, returning fallback when s is empty or invalid.
+func pdfColor(s string, fallback color.Color) color.Color {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "#") {
+		hex := s[1:]
+		if len(hex) == 3 {
+			hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+		}
+		if v, err := strconv.ParseUint(hex, 16, 32); err == nil && len(hex) == 6 {
+			return color.Color{Red: int(v >> 16 & 0xFF), Green: int(v >> 8 & 0xFF), Blue: int(v & 0xFF)}
+		}
+		return fallback
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 0 || n > 255 {
+		return fallback
+	}
+	switch {
+	case n < 16:
+		base := [16][3]int{
+			{0, 0, 0}, {128, 0, 0}, {0, 128, 0}, {128, 128, 0}, {0, 0, 128}, {128, 0, 128}, {0, 128, 128}, {192, 192, 192},
+			{128, 128, 128}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0}, {0, 0, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+		}[n]
+		return color.Color{Red: base[0], Green: base[1], Blue: base[2]}
+	case n < 232:
+		level := func(v int) int {
+			if v == 0 {
+				return 0
+			}
+			return 55 + v*40
+		}
+		n -= 16
+		return color.Color{Red: level(n / 36), Green: level(n / 6 % 6), Blue: level(n % 6)}
+	default:
+		grey := 8 + (n-232)*10
+		return color.Color{Red: grey, Green: grey, Blue: grey}
+	}
+}
+
+// pdfTint mixes c with white; amount 0 keeps c and 1 gives white.
+func pdfTint(c color.Color, amount float64) color.Color {
+	mix := func(v int) int { return v + int(float64(255-v)*amount) }
+	return color.Color{Red: mix(c.Red), Green: mix(c.Green), Blue: mix(c.Blue)}
+}
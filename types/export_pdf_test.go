@@ -0,0 +1,107 @@
+package types
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/johnfercher/maroto/pkg/color"
+)
+
+var pdfStream = regexp.MustCompile(`(?s)/FlateDecode /Length \d+>>\nstream\n(.*?)\nendstream`)
+
+// pdfPages writes t as a PDF and returns its page count and the text drawn on its pages.
+func pdfPages(t *testing.T, table *TableExport) (int, string, []byte) {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := WritePDF(&buf, table); err != nil {
+		t.Fatalf("WritePDF: %v", err)
+	}
+	data := buf.Bytes()
+	var text strings.Builder
+	for _, m := range pdfStream.FindAllSubmatch(data, -1) {
+		r, err := zlib.NewReader(bytes.NewReader(m[1]))
+		if err != nil {
+			continue
+		}
+		content, _ := io.ReadAll(r)
+		text.Write(content)
+	}
+	return bytes.Count(data, []byte("/Type /Page\n")), text.String(), data
+}
+
+func TestWritePDF(t *testing.T) {
+	var rows [][]string
+	for i := range 120 {
+		rows = append(rows, []string{fmt.Sprintf("package-%03d", i), fmt.Sprint(i * 10)})
+	}
+	table := NewTableExport([]string{"Name", "Size"}, rows, []ColumnType{ColumnString, ColumnInt})
+	table.Footer = func() []string { return []string{"total", "71400"} }
+	table.Options.PDF = PDFOptions{Title: "Packages", Subtitle: "Installed on host", Footer: "xtui report", Portrait: true, PageSize: "a4"}
+
+	pages, text, data := pdfPages(t, table)
+	if pages < 2 {
+		t.Fatalf("120 rows fit on %d page, want a paginated report", pages)
+	}
+	if !bytes.Contains(data, []byte("/MediaBox [0 0 595.28 841.89]")) {
+		t.Error("the report is not on portrait A4 pages")
+	}
+	for _, want := range []string{"(Packages)", "(Installed on host)", "(package-000)", "(package-119)", "(total)", "(71400)", "(xtui report)", fmt.Sprintf("(Page %d of %d)", pages, pages)} {
+		if !strings.Contains(text, want) {
+			t.Errorf("the report does not show %s", want)
+		}
+	}
+	if got := strings.Count(text, "(Name)"); got != pages {
+		t.Errorf("the header row is drawn %d times on %d pages", got, pages)
+	}
+}
+
+func TestWritePDFWrapsCells(t *testing.T) {
+	long := strings.Repeat("word ", 30)
+	table := NewTableExport([]string{"Name", "Description"}, [][]string{{"vim", long}}, nil)
+	pages, text, data := pdfPages(t, table)
+	if pages != 1 || !bytes.Contains(data, []byte("/MediaBox [0 0 792.00 612.00]")) {
+		t.Errorf("%d pages, want one landscape Letter page by default", pages)
+	}
+	if got := strings.Count(text, "word"); got != 30 {
+		t.Errorf("the long cell shows %d of its 30 words, want it wrapped rather than truncated", got)
+	}
+}
+
+func TestWritePDFEmpty(t *testing.T) {
+	pages, text, _ := pdfPages(t, NewTableExport([]string{"Name"}, nil, nil))
+	if pages != 1 || !strings.Contains(text, "(Name)") {
+		t.Errorf("an empty table gives %d pages, header shown %v", pages, strings.Contains(text, "(Name)"))
+	}
+}
+
+func TestPDFColor(t *testing.T) {
+	fallback := color.Color{Red: 1, Green: 2, Blue: 3}
+	tests := []struct {
+		in   string
+		want color.Color
+	}{
+		{"#00432F", color.Color{Red: 0x00, Green: 0x43, Blue: 0x2F}},
+		{" #fff ", color.Color{Red: 255, Green: 255, Blue: 255}},
+		{"#12345", fallback},
+		{"#GGGGGG", fallback},
+		{"9", color.Color{Red: 255}},
+		{"196", color.Color{Red: 255}},
+		{"16", color.Color{}},
+		{"232", color.Color{Red: 8, Green: 8, Blue: 8}},
+		{"256", fallback},
+		{"", fallback},
+	}
+	for _, tt := range tests {
+		if got := pdfColor(tt.in, fallback); got != tt.want {
+			t.Errorf("pdfColor(%q) = %+v, want %+v", tt.in, got, tt.want)
+		}
+	}
+	if got, want := pdfTint(color.Color{Red: 255, Green: 0, Blue: 100}, 0.5), (color.Color{Red: 255, Green: 127, Blue: 177}); got != want {
+		t.Errorf("pdfTint() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,497 @@
+package types
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Default element names used by WriteXMLRecords.
+const (
+	DefaultXMLRootElement = "table"
+	DefaultXMLRowElement  = "row"
+)
+
+// RecordKeys returns the keys used for headers in record exports: empty headers become "column_N"
+// and repeated headers get a "_2", "_3", … suffix, so every cell of a row keeps its own key.
+func RecordKeys(headers []string) []string {
+	keys := make([]string, len(headers))
+	seen := make(map[string]int, len(headers))
+	for i, header := range headers {
+		key := strings.TrimSpace(header)
+		if key == "" {
+			key = fmt.Sprintf("column_%d", i+1)
+		}
+		if n := seen[key]; n > 0 {
+			for seen[fmt.Sprintf("%s_%d", key, n+1)] > 0 {
+				n++
+			}
+			seen[key] = n + 1
+			key = fmt.Sprintf("%s_%d", key, n+1)
+		}
+		seen[key]++
+		keys[i] = key
+	}
+	return keys
+}
+
+// WriteJSONRecords writes t as a JSON array with one object per row, keys in column order and every
+// value as a string, so the output reads back unchanged with ReadJSONRecords.
+func WriteJSONRecords(w io.Writer, t *TableExport) error {
+	keys := RecordKeys(t.Headers)
+	quoted := make([]string, len(keys))
+	for i, key := range keys {
+		quoted[i] = jsonString(key)
+	}
+	bw := bufio.NewWriter(w)
+	bw.WriteString("[")
+	first := true
+	err := t.EachRow(func(row []string) error {
+		if !first {
+			bw.WriteString(",")
+		}
+		first = false
+		bw.WriteString("\n  {")
+		for i := range keys {
+			if i > 0 {
+				bw.WriteString(", ")
+			}
+			bw.WriteString(quoted[i] + ": " + jsonString(cellOf(row, i)))
+		}
+		_, err := bw.WriteString("}")
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if !first {
+		bw.WriteString("\n")
+	}
+	bw.WriteString("]\n")
+	return bw.Flush()
+}
+
+// WriteNDJSONRecords writes t as newline-delimited JSON: one compact object per row, keys in column
+// order and every value as a string.
+func WriteNDJSONRecords(w io.Writer, t *TableExport) error {
+	keys := RecordKeys(t.Headers)
+	quoted := make([]string, len(keys))
+	for i, key := range keys {
+		quoted[i] = jsonString(key)
+	}
+	bw := bufio.NewWriter(w)
+	err := t.EachRow(func(row []string) error {
+		bw.WriteString("{")
+		for i := range keys {
+			if i > 0 {
+				bw.WriteString(",")
+			}
+			bw.WriteString(quoted[i] + ":" + jsonString(cellOf(row, i)))
+		}
+		_, err := bw.WriteString("}\n")
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// RecordJSON returns one row as an indented JSON object with the keys of RecordKeys in column order.
+func RecordJSON(headers, row []string) string {
+	keys := RecordKeys(headers)
+	if len(keys) == 0 {
+		return "{}"
+	}
+	var b strings.Builder
+	b.WriteString("{\n")
+	for i, key := range keys {
+		b.WriteString("  " + jsonString(key) + ": " + jsonString(cellOf(row, i)))
+		if i < len(keys)-1 {
+			b.WriteString(",")
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+// RecordYAML returns one row as a YAML mapping with the keys of RecordKeys in column order.
+func RecordYAML(headers, row []string) string {
+	record := &yaml.Node{Kind: yaml.MappingNode}
+	for i, key := range RecordKeys(headers) {
+		record.Content = append(record.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key},
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: cellOf(row, i)},
+		)
+	}
+	if len(record.Content) == 0 {
+		return "{}"
+	}
+	var b bytes.Buffer
+	enc := yaml.NewEncoder(&b)
+	enc.SetIndent(2)
+	if err := enc.Encode(record); err != nil {
+		return err.Error()
+	}
+	_ = enc.Close()
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// jsonString returns s as a JSON string literal, without escaping HTML characters.
+func jsonString(s string) string {
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(s)
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// WriteYAMLRecords writes t as a YAML sequence with one mapping per row, keys in column order and
+// every value as a string.
+func WriteYAMLRecords(w io.Writer, t *TableExport) error {
+	keys := RecordKeys(t.Headers)
+	doc := &yaml.Node{Kind: yaml.SequenceNode}
+	err := t.EachRow(func(row []string) error {
+		record := &yaml.Node{Kind: yaml.MappingNode}
+		for i, key := range keys {
+			record.Content = append(record.Content,
+				&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key},
+				&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: cellOf(row, i)},
+			)
+		}
+		doc.Content = append(doc.Content, record)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if len(doc.Content) == 0 {
+		doc.Style = yaml.FlowStyle
+	}
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// WriteXMLRecords writes t as <root><row><Header>value</Header>…</row>…</root>. root and row default
+// to DefaultXMLRootElement and DefaultXMLRowElement. Headers that are not valid element names are
+// written as sanitised elements carrying the original header in a name attribute.
+func WriteXMLRecords(w io.Writer, t *TableExport, root, row string) error {
+	if root = XMLElementName(root); root == "" {
+		root = DefaultXMLRootElement
+	}
+	if row = XMLElementName(row); row == "" {
+		row = DefaultXMLRowElement
+	}
+	keys := RecordKeys(t.Headers)
+	fields := make([]xml.StartElement, len(keys))
+	for i, key := range keys {
+		name := XMLElementName(key)
+		fields[i] = xml.StartElement{Name: xml.Name{Local: name}}
+		if name != key {
+			fields[i].Attr = []xml.Attr{{Name: xml.Name{Local: "name"}, Value: key}}
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+	bw.WriteString(xml.Header)
+	enc := xml.NewEncoder(bw)
+	enc.Indent("", "  ")
+	rootElement := xml.StartElement{Name: xml.Name{Local: root}}
+	if err := enc.EncodeToken(rootElement); err != nil {
+		return err
+	}
+	err := t.EachRow(func(cells []string) error {
+		rowElement := xml.StartElement{Name: xml.Name{Local: row}}
+		if err := enc.EncodeToken(rowElement); err != nil {
+			return err
+		}
+		for i, field := range fields {
+			if err := enc.EncodeElement(cellOf(cells, i), field); err != nil {
+				return err
+			}
+		}
+		return enc.EncodeToken(rowElement.End())
+	})
+	if err != nil {
+		return err
+	}
+	if err := enc.EncodeToken(rootElement.End()); err != nil {
+		return err
+	}
+	if err := enc.Flush(); err != nil {
+		return err
+	}
+	bw.WriteString("\n")
+	return bw.Flush()
+}
+
+// XMLElementName turns s into a valid XML element name by replacing invalid characters with "_"
+// and prefixing names that do not start with a letter or "_".
+func XMLElementName(s string) string {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return ""
+	}
+	name := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '-' || r == '.' {
+			return r
+		}
+		return '_'
+	}, s)
+	if first := []rune(name)[0]; !unicode.IsLetter(first) && first != '_' {
+		name = "_" + name
+	}
+	return name
+}
+
+// recordSet collects records with possibly different keys into rows sharing one header list, in the
+// order the keys are first seen.
+type recordSet struct {
+	headers []string
+	index   map[string]int
+	rows    [][]string
+}
+
+func (s *recordSet) add(keys, values []string) {
+	if s.index == nil {
+		s.index = make(map[string]int)
+	}
+	row := make([]string, len(s.headers), len(s.headers)+len(keys))
+	for i, key := range keys {
+		col, ok := s.index[key]
+		if !ok {
+			col = len(s.headers)
+			s.index[key] = col
+			s.headers = append(s.headers, key)
+		}
+		for len(row) <= col {
+			row = append(row, "")
+		}
+		row[col] = values[i]
+	}
+	s.rows = append(s.rows, row)
+}
+
+// result pads every row to the final number of headers.
+func (s *recordSet) result() ([]string, [][]string) {
+	for i, row := range s.rows {
+		for len(row) < len(s.headers) {
+			row = append(row, "")
+		}
+		s.rows[i] = row
+	}
+	return s.headers, s.rows
+}
+
+// ReadJSONRecords reads a JSON array of objects, as written by WriteJSONRecords, returning the keys
+// as headers in the order they first appear. Non-string values keep their JSON text and null
+// becomes empty. An array of arrays is read as rows whose first element holds the headers.
+func ReadJSONRecords(r io.Reader) ([]string, [][]string, error) {
+	var items []json.RawMessage
+	if err := json.NewDecoder(r).Decode(&items); err != nil {
+		return nil, nil, err
+	}
+	var set recordSet
+	var arrays [][]string
+	for _, item := range items {
+		item = bytes.TrimSpace(item)
+		switch {
+		case len(item) > 0 && item[0] == '{':
+			keys, values, err := jsonObjectFields(item)
+			if err != nil {
+				return nil, nil, err
+			}
+			set.add(keys, values)
+		case len(item) > 0 && item[0] == '[':
+			var cells []json.RawMessage
+			if err := json.Unmarshal(item, &cells); err != nil {
+				return nil, nil, err
+			}
+			row := make([]string, len(cells))
+			for i, cell := range cells {
+				row[i] = jsonValue(cell)
+			}
+			arrays = append(arrays, row)
+		default:
+			return nil, nil, fmt.Errorf("expected an object or an array, got %s", item)
+		}
+	}
+	if len(arrays) > 0 {
+		if len(set.rows) > 0 {
+			return nil, nil, fmt.Errorf("cannot mix objects and arrays in a JSON table")
+		}
+		return arrays[0], arrays[1:], nil
+	}
+	headers, rows := set.result()
+	return headers, rows, nil
+}
+
+// jsonObjectFields returns the keys and values of a JSON object in document order.
+func jsonObjectFields(data []byte) ([]string, []string, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if _, err := dec.Token(); err != nil {
+		return nil, nil, err
+	}
+	var keys, values []string
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return nil, nil, err
+		}
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, nil, err
+		}
+		keys = append(keys, token.(string))
+		values = append(values, jsonValue(value))
+	}
+	return keys, values, nil
+}
+
+// jsonValue returns a JSON value as a cell: strings unquoted, null empty and anything else as its JSON text.
+func jsonValue(raw json.RawMessage) string {
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+	if text := string(bytes.TrimSpace(raw)); text != "null" {
+		return text
+	}
+	return ""
+}
+
+// ReadYAMLRecords reads a YAML sequence of mappings, as written by WriteYAMLRecords, returning the
+// keys as headers in the order they first appear. A sequence of sequences is read as rows whose first
+// element holds the headers, and a single mapping as Key/Value rows.
+func ReadYAMLRecords(r io.Reader) ([]string, [][]string, error) {
+	var doc yaml.Node
+	if err := yaml.NewDecoder(r).Decode(&doc); err != nil {
+		if err == io.EOF {
+			return nil, nil, nil
+		}
+		return nil, nil, err
+	}
+	root := &doc
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+	switch root.Kind {
+	case yaml.MappingNode:
+		var rows [][]string
+		for i := 0; i+1 < len(root.Content); i += 2 {
+			rows = append(rows, []string{root.Content[i].Value, yamlValue(root.Content[i+1])})
+		}
+		return []string{"Key", "Value"}, rows, nil
+	case yaml.SequenceNode:
+		var set recordSet
+		var arrays [][]string
+		for _, item := range root.Content {
+			switch item.Kind {
+			case yaml.MappingNode:
+				var keys, values []string
+				for i := 0; i+1 < len(item.Content); i += 2 {
+					keys = append(keys, item.Content[i].Value)
+					values = append(values, yamlValue(item.Content[i+1]))
+				}
+				set.add(keys, values)
+			case yaml.SequenceNode:
+				row := make([]string, len(item.Content))
+				for i, cell := range item.Content {
+					row[i] = yamlValue(cell)
+				}
+				arrays = append(arrays, row)
+			default:
+				return nil, nil, fmt.Errorf("line %d: expected a mapping or a sequence", item.Line)
+			}
+		}
+		if len(arrays) > 0 {
+			if len(set.rows) > 0 {
+				return nil, nil, fmt.Errorf("cannot mix mappings and sequences in a YAML table")
+			}
+			return arrays[0], arrays[1:], nil
+		}
+		headers, rows := set.result()
+		return headers, rows, nil
+	}
+	return nil, nil, fmt.Errorf("line %d: expected a sequence of records", root.Line)
+}
+
+// yamlValue returns a YAML node as a cell: scalars as written (null empty) and collections in flow style.
+func yamlValue(node *yaml.Node) string {
+	if node.Kind == yaml.ScalarNode {
+		if node.Tag == "!!null" {
+			return ""
+		}
+		return node.Value
+	}
+	flow := *node
+	flow.Style = yaml.FlowStyle
+	data, err := yaml.Marshal(&flow)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// ReadXMLRecords reads records written by WriteXMLRecords, whatever the root and row element names:
+// every child of the root element is a row and every child of a row a field, named by its name
+// attribute when present and by the element name otherwise.
+func ReadXMLRecords(r io.Reader) ([]string, [][]string, error) {
+	dec := xml.NewDecoder(r)
+	var set recordSet
+	var keys, values []string
+	var text strings.Builder
+	depth := 0
+	for {
+		token, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		switch el := token.(type) {
+		case xml.StartElement:
+			depth++
+			switch depth {
+			case 2:
+				keys, values = nil, nil
+			case 3:
+				key := el.Name.Local
+				for _, attr := range el.Attr {
+					if attr.Name.Local == "name" {
+						key = attr.Value
+					}
+				}
+				keys = append(keys, key)
+				text.Reset()
+			}
+		case xml.CharData:
+			if depth >= 3 {
+				text.Write(el)
+			}
+		case xml.EndElement:
+			switch depth {
+			case 2:
+				set.add(keys, values)
+			case 3:
+				values = append(values, text.String())
+			}
+			depth--
+		}
+	}
+	headers, rows := set.result()
+	return headers, rows, nil
+}
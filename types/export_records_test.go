@@ -0,0 +1,139 @@
+package types
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRecordKeys(t *testing.T) {
+	tests := []struct {
+		headers []string
+		want    []string
+	}{
+		{[]string{"Name", "Size"}, []string{"Name", "Size"}},
+		{[]string{"Name", "Name", "Name"}, []string{"Name", "Name_2", "Name_3"}},
+		{[]string{"Name", "Name_2", "Name"}, []string{"Name", "Name_2", "Name_3"}},
+		{[]string{"", " ", "x"}, []string{"column_1", "column_2", "x"}},
+		{[]string{" Name ", "Name"}, []string{"Name", "Name_2"}},
+	}
+	for _, tt := range tests {
+		if got := RecordKeys(tt.headers); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("RecordKeys(%q) = %q, want %q", tt.headers, got, tt.want)
+		}
+	}
+}
+
+func TestRecordsRoundTrip(t *testing.T) {
+	headers := []string{"Name", "Name", "", "Size (MB)", "1st", "Ünïcode"}
+	rows := [][]string{
+		{"vim", "Vi IMproved", "", "3.5", "yes", "ação"},
+		{`say "hi"`, "line\nbreak", "<a & b>", "null", "- dash", "  padded  "},
+		{"1.0", "true", "~", "0x1F", "{}", "[a, b]"},
+	}
+	formats := []struct {
+		name  string
+		write func(io.Writer, *TableExport) error
+		read  func(io.Reader) ([]string, [][]string, error)
+	}{
+		{"json", WriteJSONRecords, ReadJSONRecords},
+		{"yaml", WriteYAMLRecords, ReadYAMLRecords},
+		{"xml", func(w io.Writer, t *TableExport) error { return WriteXMLRecords(w, t, "packages", "package") }, ReadXMLRecords},
+	}
+	wantHeaders := []string{"Name", "Name_2", "column_3", "Size (MB)", "1st", "Ünïcode"}
+	for _, format := range formats {
+		t.Run(format.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := format.write(&buf, NewTableExport(headers, rows, nil)); err != nil {
+				t.Fatalf("write: %v", err)
+			}
+			gotHeaders, gotRows, err := format.read(&buf)
+			if err != nil {
+				t.Fatalf("read: %v\n%s", err, buf.String())
+			}
+			if !reflect.DeepEqual(gotHeaders, wantHeaders) {
+				t.Errorf("headers = %q, want %q", gotHeaders, wantHeaders)
+			}
+			if !reflect.DeepEqual(gotRows, rows) {
+				t.Errorf("rows = %q, want %q", gotRows, rows)
+			}
+		})
+	}
+}
+
+func TestReadRecordsWithDifferentKeys(t *testing.T) {
+	wantHeaders := []string{"name", "size", "arch"}
+	wantRows := [][]string{{"vim", "3", ""}, {"nano", "", "amd64"}}
+	inputs := []struct {
+		name string
+		read func(io.Reader) ([]string, [][]string, error)
+		doc  string
+	}{
+		{"json", ReadJSONRecords, `[{"name": "vim", "size": 3}, {"arch": "amd64", "name": "nano", "size": null}]`},
+		{"yaml", ReadYAMLRecords, "- name: vim\n  size: 3\n- arch: amd64\n  name: nano\n  size: null\n"},
+		{"xml", ReadXMLRecords, "<t><r><name>vim</name><size>3</size></r><r><arch>amd64</arch><name>nano</name><size/></r></t>"},
+	}
+	for _, input := range inputs {
+		t.Run(input.name, func(t *testing.T) {
+			headers, rows, err := input.read(strings.NewReader(input.doc))
+			if err != nil {
+				t.Fatalf("read: %v", err)
+			}
+			if !reflect.DeepEqual(headers, wantHeaders) || !reflect.DeepEqual(rows, wantRows) {
+				t.Errorf("read %q, %q, want %q, %q", headers, rows, wantHeaders, wantRows)
+			}
+		})
+	}
+}
+
+func TestReadRecordsArrays(t *testing.T) {
+	wantHeaders, wantRows := []string{"name", "size"}, [][]string{{"vim", "3"}}
+	headers, rows, err := ReadJSONRecords(strings.NewReader(`[["name", "size"], ["vim", 3]]`))
+	if err != nil || !reflect.DeepEqual(headers, wantHeaders) || !reflect.DeepEqual(rows, wantRows) {
+		t.Errorf("ReadJSONRecords(arrays) = %q, %q, %v", headers, rows, err)
+	}
+	headers, rows, err = ReadYAMLRecords(strings.NewReader("- [name, size]\n- [vim, 3]\n"))
+	if err != nil || !reflect.DeepEqual(headers, wantHeaders) || !reflect.DeepEqual(rows, wantRows) {
+		t.Errorf("ReadYAMLRecords(sequences) = %q, %q, %v", headers, rows, err)
+	}
+	if _, _, err := ReadJSONRecords(strings.NewReader(`[{"name": "vim"}, ["name"]]`)); err == nil {
+		t.Error("ReadJSONRecords did not reject objects mixed with arrays")
+	}
+	if _, _, err := ReadJSONRecords(strings.NewReader(`["vim"]`)); err == nil {
+		t.Error("ReadJSONRecords did not reject a string record")
+	}
+}
+
+func TestXMLElementName(t *testing.T) {
+	tests := map[string]string{
+		"Name":      "Name",
+		" Size ":    "Size",
+		"Size (MB)": "Size__MB_",
+		"1st":       "_1st",
+		"-x":        "_-x",
+		"":          "",
+	}
+	for in, want := range tests {
+		if got := XMLElementName(in); got != want {
+			t.Errorf("XMLElementName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestRecordJSONAndYAML(t *testing.T) {
+	headers, row := []string{"Name", "Name", "Size"}, []string{"vim", "<Vi IMproved>", "true"}
+	if got, want := RecordJSON(headers, row), "{\n  \"Name\": \"vim\",\n  \"Name_2\": \"<Vi IMproved>\",\n  \"Size\": \"true\"\n}"; got != want {
+		t.Errorf("RecordJSON() = %q, want %q", got, want)
+	}
+	if got, want := RecordYAML(headers, row), "Name: vim\nName_2: <Vi IMproved>\nSize: \"true\""; got != want {
+		t.Errorf("RecordYAML() = %q, want %q", got, want)
+	}
+	if got, want := RecordJSON(headers, row[:1]), "{\n  \"Name\": \"vim\",\n  \"Name_2\": \"\",\n  \"Size\": \"\"\n}"; got != want {
+		t.Errorf("RecordJSON() of a short row = %q, want %q", got, want)
+	}
+	if RecordJSON(nil, nil) != "{}" || RecordYAML(nil, nil) != "{}" {
+		t.Error("a record without headers should be an empty object")
+	}
+}
@@ -0,0 +1,241 @@
+package types
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Exporter describes a table export format. Exporters are registered with RegisterExporter and are
+// then offered by the table export dialog, bound to Key in the table view and accepted by the
+// --output flag of the CLI.
+type Exporter struct {
+	Name       string   // unique identifier, e.g. "csv"
+	Title      string   // display name, e.g. "CSV"; defaults to Name
+	Extensions []string // file extensions without the dot, the first one being the default
+	MIMEType   string   // e.g. "text/csv"
+	Key        string   // optional table view shortcut that opens the export dialog on this format
+	Write      func(w io.Writer, t *TableExport) error
+}
+
+// Extension returns the default file extension of the exporter.
+func (e Exporter) Extension() string {
+	if len(e.Extensions) > 0 {
+		return e.Extensions[0]
+	}
+	return e.Name
+}
+
+var (
+	exportersMu sync.RWMutex
+	exporters   []Exporter
+)
+
+func init() {
+	for _, e := range []Exporter{
+		{Name: "csv", Title: "CSV", Extensions: []string{"csv"}, MIMEType: "text/csv", Key: "ctrl+e",
+			Write: func(w io.Writer, t *TableExport) error { return WriteCSV(w, t, ',') }},
+		{Name: "tsv", Title: "TSV", Extensions: []string{"tsv", "tab"}, MIMEType: "text/tab-separated-values",
+			Write: func(w io.Writer, t *TableExport) error { return WriteCSV(w, t, '\t') }},
+		{Name: "json", Title: "JSON", Extensions: []string{"json"}, MIMEType: "application/json", Key: "ctrl+j",
+			Write: WriteJSONRecords},
+		{Name: "ndjson", Title: "NDJSON", Extensions: []string{"ndjson", "jsonl"}, MIMEType: "application/x-ndjson",
+			Write: WriteNDJSONRecords},
+		{Name: "yaml", Title: "YAML", Extensions: []string{"yaml", "yml"}, MIMEType: "application/yaml", Key: "ctrl+y",
+			Write: WriteYAMLRecords},
+		{Name: "xml", Title: "XML", Extensions: []string{"xml"}, MIMEType: "application/xml", Key: "ctrl+x",
+			Write: func(w io.Writer, t *TableExport) error {
+				return WriteXMLRecords(w, t, t.Options.XMLRoot, t.Options.XMLRow)
+			}},
+		{Name: "xlsx", Title: "Excel", Extensions: []string{"xlsx"}, MIMEType: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", Key: "ctrl+l",
+			Write: WriteXLSX},
+		{Name: "pdf", Title: "PDF", Extensions: []string{"pdf"}, MIMEType: "application/pdf", Key: "ctrl+p",
+			Write: WritePDF},
+		{Name: "md", Title: "Markdown", Extensions: []string{"md", "markdown"}, MIMEType: "text/markdown",
+			Write: WriteMarkdown},
+		{Name: "html", Title: "HTML", Extensions: []string{"html", "htm"}, MIMEType: "text/html",
+			Write: func(w io.Writer, t *TableExport) error { return WriteHTML(w, t, t.Options.HTMLTheme) }},
+		{Name: "adoc", Title: "AsciiDoc", Extensions: []string{"adoc", "asciidoc"}, MIMEType: "text/asciidoc",
+			Write: WriteAsciiDoc},
+	} {
+		_ = RegisterExporter(e)
+	}
+}
+
+// RegisterExporter adds e to the registry, replacing a registered exporter with the same name.
+func RegisterExporter(e Exporter) error {
+	e.Name = strings.ToLower(strings.TrimSpace(e.Name))
+	if e.Name == "" {
+		return fmt.Errorf("exporter name is empty")
+	}
+	if e.Write == nil {
+		return fmt.Errorf("exporter %q has no Write function", e.Name)
+	}
+	if e.Title == "" {
+		e.Title = e.Name
+	}
+	extensions := make([]string, len(e.Extensions))
+	for i, ext := range e.Extensions {
+		extensions[i] = strings.ToLower(strings.TrimPrefix(ext, "."))
+	}
+	e.Extensions = extensions
+	exportersMu.Lock()
+	defer exportersMu.Unlock()
+	for i, existing := range exporters {
+		if existing.Name == e.Name {
+			exporters[i] = e
+			return nil
+		}
+	}
+	exporters = append(exporters, e)
+	return nil
+}
+
+// UnregisterExporter removes the exporter name and reports whether it was registered.
+func UnregisterExporter(name string) bool {
+	exportersMu.Lock()
+	defer exportersMu.Unlock()
+	for i, e := range exporters {
+		if e.Name == strings.ToLower(name) {
+			exporters = append(exporters[:i], exporters[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Exporters returns the registered exporters in registration order.
+func Exporters() []Exporter {
+	exportersMu.RLock()
+	defer exportersMu.RUnlock()
+	return append([]Exporter(nil), exporters...)
+}
+
+// ExporterNames returns the names of the registered exporters in registration order.
+func ExporterNames() []string {
+	var names []string
+	for _, e := range Exporters() {
+		names = append(names, e.Name)
+	}
+	return names
+}
+
+// LookupExporter finds an exporter by name, title, file extension (with or without the dot) or MIME type.
+func LookupExporter(format string) (Exporter, bool) {
+	format = strings.ToLower(strings.TrimSpace(format))
+	ext := strings.TrimPrefix(format, ".")
+	for _, e := range Exporters() {
+		if e.Name == ext || strings.ToLower(e.Title) == format || (e.MIMEType != "" && e.MIMEType == format) {
+			return e, true
+		}
+	}
+	for _, e := range Exporters() {
+		for _, candidate := range e.Extensions {
+			if candidate == ext {
+				return e, true
+			}
+		}
+	}
+	return Exporter{}, false
+}
+
+// ExporterForFile finds the exporter of a file from its extension.
+func ExporterForFile(path string) (Exporter, bool) {
+	ext := filepath.Ext(path)
+	if ext == "" {
+		return Exporter{}, false
+	}
+	return LookupExporter(ext)
+}
+
+// ExporterForKey returns the exporter bound to the table view shortcut key.
+func ExporterForKey(key string) (Exporter, bool) {
+	for _, e := range Exporters() {
+		if e.Key != "" && e.Key == key {
+			return e, true
+		}
+	}
+	return Exporter{}, false
+}
+
+// ResolveExporter returns the exporter for format, or for the extension of path when format is empty.
+func ResolveExporter(path, format string) (Exporter, error) {
+	if format == "" {
+		if e, ok := ExporterForFile(path); ok {
+			return e, nil
+		}
+		return Exporter{}, fmt.Errorf("cannot tell the export format of %q; use one of: %s", path, strings.Join(ExporterNames(), ", "))
+	}
+	if e, ok := LookupExporter(format); ok {
+		return e, nil
+	}
+	return Exporter{}, fmt.Errorf("unsupported export format %q; use one of: %s", format, strings.Join(ExporterNames(), ", "))
+}
+
+// ExportFile writes t to path with the exporter of format, or of the file extension when format is empty.
+func ExportFile(path, format string, t *TableExport) error {
+	e, err := ResolveExporter(path, format)
+	if err != nil {
+		return err
+	}
+	return writeExportFile(path, t, e.Write)
+}
+
+// writeExportFile writes t into filename with write, through WriteFileAtomic.
+func writeExportFile(filename string, t *TableExport, write func(w io.Writer, t *TableExport) error) error {
+	return WriteFileAtomic(filename, func(w io.Writer) error { return write(w, t) })
+}
+
+// WriteFileAtomic writes filename with write through a temporary file of the same directory, renamed
+// over filename only when write succeeds, so a failed export never truncates an existing file. The
+// mode of an existing file is kept. When filename is a symbolic link, the file it points to is
+// written and the link kept.
+func WriteFileAtomic(filename string, write func(w io.Writer) error) error {
+	filename = resolveLink(filename)
+	mode := os.FileMode(0o644)
+	if info, err := os.Stat(filename); err == nil {
+		mode = info.Mode().Perm()
+	}
+	file, err := os.CreateTemp(filepath.Dir(filename), "."+filepath.Base(filename)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	tmp := file.Name()
+	if err := write(file); err != nil {
+		_ = file.Close()
+		_ = os.Remove(tmp)
+		return err
+	}
+	if err := file.Close(); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	if err := os.Chmod(tmp, mode); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	if err := os.Rename(tmp, filename); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+// resolveLink follows the symbolic links at filename, including a dangling last one, and returns the
+// path of the file they point to.
+func resolveLink(filename string) string {
+	for range 40 { // the limit of most systems, against loops
+		target, err := os.Readlink(filename)
+		if err != nil {
+			return filename
+		}
+		if !filepath.IsAbs(target) {
+			target = filepath.Join(filepath.Dir(filename), target)
+		}
+		filename = target
+	}
+	return filename
+}
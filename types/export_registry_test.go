@@ -0,0 +1,276 @@
+package types
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// registryTestTable is a small table written by every built-in exporter.
+func registryTestTable() *TableExport {
+	return NewTableExport([]string{"name", "size"}, [][]string{{"vim", "3 MB"}, {"nano", "512 KiB"}}, nil)
+}
+
+func TestBuiltinExporters(t *testing.T) {
+	tests := []struct {
+		name string
+		want string // text the output starts with or, prefixed by "…", contains
+	}{
+		{"csv", "name,size\nvim,3 MB\nnano,512 KiB\n"},
+		{"tsv", "name\tsize\nvim\t3 MB\n"},
+		{"json", "…\"name\": \"vim\""},
+		{"ndjson", "{\"name\":\"vim\",\"size\":\"3 MB\"}\n"},
+		{"yaml", "…name: nano"},
+		{"xml", "…<name>vim</name>"},
+		{"xlsx", "PK"},
+		{"pdf", "%PDF"},
+		{"md", "| name |    size |\n| ---- | ------: |\n"},
+		{"html", "<!DOCTYPE html>"},
+		{"adoc", "[cols=\"<,>\",options=\"header\"]\n|===\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, ok := LookupExporter(tt.name)
+			if !ok {
+				t.Fatalf("exporter %q is not registered", tt.name)
+			}
+			var buf bytes.Buffer
+			if err := e.Write(&buf, registryTestTable()); err != nil {
+				t.Fatalf("%s: %v", tt.name, err)
+			}
+			got := buf.String()
+			if want, contains := strings.CutPrefix(tt.want, "…"); contains && !strings.Contains(got, want) || !contains && !strings.HasPrefix(got, want) {
+				t.Errorf("%s output %q does not have %q", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteCSVFooter(t *testing.T) {
+	tests := []struct {
+		name   string
+		comma  rune
+		footer bool
+		want   string
+	}{
+		{"csv without footer", ',', false, "name,size\nvim,3 MB\nnano,512 KiB\n"},
+		{"tsv without footer", '\t', false, "name\tsize\nvim\t3 MB\nnano\t512 KiB\n"},
+		{"csv with footer", ',', true, "name,size\nvim,3 MB\nnano,512 KiB\n2,3.5 MB\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := registryTestTable()
+			table.Footer = func() []string { return []string{"2", "3.5 MB"} }
+			table.Options.CSVFooter = tt.footer
+			var buf bytes.Buffer
+			if err := WriteCSV(&buf, table, tt.comma); err != nil {
+				t.Fatalf("WriteCSV: %v", err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("WriteCSV wrote %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLookupExporter(t *testing.T) {
+	tests := []struct {
+		format string
+		want   string
+		ok     bool
+	}{
+		{"csv", "csv", true},
+		{" CSV ", "csv", true},
+		{".csv", "csv", true},
+		{"Excel", "xlsx", true},
+		{"text/markdown", "md", true},
+		{"yml", "yaml", true},
+		{".JSONL", "ndjson", true},
+		{"htm", "html", true},
+		{"asciidoc", "adoc", true},
+		{"docx", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		e, ok := LookupExporter(tt.format)
+		if ok != tt.ok || e.Name != tt.want {
+			t.Errorf("LookupExporter(%q) = %q, %v, want %q, %v", tt.format, e.Name, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestResolveExporter(t *testing.T) {
+	tests := []struct {
+		path, format string
+		want         string
+		err          string
+	}{
+		{"report.xlsx", "", "xlsx", ""},
+		{"report.TSV", "", "tsv", ""},
+		{"report.csv", "json", "json", ""},
+		{"-", "yaml", "yaml", ""},
+		{"report", "", "", "cannot tell the export format"},
+		{"report.docx", "", "", "cannot tell the export format"},
+		{"report.csv", "docx", "", `unsupported export format "docx"`},
+	}
+	for _, tt := range tests {
+		e, err := ResolveExporter(tt.path, tt.format)
+		if tt.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("ResolveExporter(%q, %q) error = %v, want %q", tt.path, tt.format, err, tt.err)
+			}
+			continue
+		}
+		if err != nil || e.Name != tt.want {
+			t.Errorf("ResolveExporter(%q, %q) = %q, %v, want %q", tt.path, tt.format, e.Name, err, tt.want)
+		}
+	}
+}
+
+func TestExporterForKey(t *testing.T) {
+	tests := map[string]string{"ctrl+e": "csv", "ctrl+l": "xlsx", "ctrl+p": "pdf", "x": ""}
+	for key, want := range tests {
+		if e, ok := ExporterForKey(key); e.Name != want || ok != (want != "") {
+			t.Errorf("ExporterForKey(%q) = %q, %v, want %q", key, e.Name, ok, want)
+		}
+	}
+}
+
+func TestRegisterExporter(t *testing.T) {
+	write := func(w io.Writer, t *TableExport) error {
+		_, err := io.WriteString(w, strings.Join(t.Headers, "/"))
+		return err
+	}
+	t.Cleanup(func() { UnregisterExporter("slash") })
+
+	if err := RegisterExporter(Exporter{Name: " "}); err == nil {
+		t.Error("RegisterExporter with an empty name should fail")
+	}
+	if err := RegisterExporter(Exporter{Name: "slash"}); err == nil {
+		t.Error("RegisterExporter without Write should fail")
+	}
+	if err := RegisterExporter(Exporter{Name: " Slash ", Extensions: []string{".SL", "slash"}, Write: write}); err != nil {
+		t.Fatalf("RegisterExporter: %v", err)
+	}
+	e, ok := ExporterForFile("out.sl")
+	if !ok || e.Name != "slash" || e.Title != "slash" || e.Extension() != "sl" {
+		t.Fatalf("ExporterForFile(out.sl) = %+v, %v", e, ok)
+	}
+	names := ExporterNames()
+	if names[len(names)-1] != "slash" {
+		t.Errorf("ExporterNames() = %v, want slash last", names)
+	}
+
+	if err := RegisterExporter(Exporter{Name: "slash", Title: "Slashes", Write: write}); err != nil {
+		t.Fatalf("RegisterExporter replacing slash: %v", err)
+	}
+	if got := len(ExporterNames()); got != len(names) {
+		t.Errorf("replacing an exporter changed the count from %d to %d", len(names), got)
+	}
+	if e, _ := LookupExporter("slashes"); e.Name != "slash" || len(e.Extensions) != 0 || e.Extension() != "slash" {
+		t.Errorf("replaced exporter = %+v", e)
+	}
+
+	if !UnregisterExporter("SLASH") || UnregisterExporter("slash") {
+		t.Error("UnregisterExporter should remove slash exactly once")
+	}
+	if _, ok := LookupExporter("slash"); ok {
+		t.Error("slash is still registered")
+	}
+}
+
+func TestExportFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "report.md")
+	if err := ExportFile(path, "", registryTestTable()); err != nil {
+		t.Fatalf("ExportFile: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil || !strings.HasPrefix(string(data), "| name") {
+		t.Errorf("ExportFile wrote %q, %v", data, err)
+	}
+	if err := ExportFile(filepath.Join(dir, "report"), "", registryTestTable()); err == nil {
+		t.Error("ExportFile without a format or extension should fail")
+	}
+}
+
+func TestWriteFileAtomic(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "out.txt")
+	if err := os.WriteFile(path, []byte("keep"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	failure := errors.New("write failed")
+	err := WriteFileAtomic(path, func(w io.Writer) error {
+		_, _ = io.WriteString(w, "partial")
+		return failure
+	})
+	if !errors.Is(err, failure) {
+		t.Fatalf("WriteFileAtomic error = %v, want %v", err, failure)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "keep" {
+		t.Errorf("a failed write left %q, want the previous content", data)
+	}
+
+	if err := WriteFileAtomic(path, func(w io.Writer) error {
+		_, err := io.WriteString(w, "new")
+		return err
+	}); err != nil {
+		t.Fatalf("WriteFileAtomic: %v", err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "new" || info.Mode().Perm() != 0o600 {
+		t.Errorf("WriteFileAtomic wrote %q with mode %v, want \"new\" with mode 0600", data, info.Mode().Perm())
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Errorf("WriteFileAtomic left %d files in the directory, want 1", len(entries))
+	}
+}
+
+func TestWriteFileAtomicSymlink(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(dir, "reports", "out.csv")
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(target, []byte("old"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	link := filepath.Join(dir, "latest.csv")
+	if err := os.Symlink(filepath.Join("reports", "out.csv"), link); err != nil {
+		t.Skipf("symbolic links are not supported: %v", err)
+	}
+	dangling := filepath.Join(dir, "next.csv")
+	if err := os.Symlink("next-target.csv", dangling); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, path := range []string{link, dangling} {
+		if err := WriteFileAtomic(path, func(w io.Writer) error {
+			_, err := io.WriteString(w, "new")
+			return err
+		}); err != nil {
+			t.Fatalf("WriteFileAtomic(%s): %v", path, err)
+		}
+		if info, err := os.Lstat(path); err != nil || info.Mode()&os.ModeSymlink == 0 {
+			t.Errorf("%s is no longer a symbolic link", path)
+		}
+	}
+	if data, _ := os.ReadFile(target); string(data) != "new" {
+		t.Errorf("the link target holds %q, want the new content", data)
+	}
+	if info, err := os.Stat(target); err != nil || info.Mode().Perm() != 0o600 {
+		t.Errorf("the link target mode changed: %v, %v", info.Mode(), err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, "next-target.csv")); string(data) != "new" {
+		t.Errorf("the dangling link target holds %q, want the new content", data)
+	}
+}
@@ -0,0 +1,241 @@
+package types
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"io"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// DefaultHTMLTheme is the CSS embedded by WriteHTML to match the terminal table palette: light grey
+// text on a dark background, thick dark borders and the green selection colours on hover.
+const DefaultHTMLTheme = `body { background: #1c1c1c; color: #d0d0d0; font-family: ui-monospace, SFMono-Regular, Menlo, Consolas, monospace; margin: 2em; }
+h1 { font-size: 1.2em; color: #01be85; }
+table { border-collapse: collapse; border: 3px solid #444444; }
+th, td { border: 1px solid #444444; padding: 0.25em 0.75em; }
+th { color: #d0d0d0; font-weight: bold; background: #262626; }
+tbody tr:hover td { color: #01be85; background: #00432f; }
+.align-right { text-align: right; }
+.align-center { text-align: center; }
+`
+
+// columnAlignment returns how a column of the given type is aligned: "right" for numeric columns,
+// "center" for booleans and "left" for everything else.
+func columnAlignment(c ColumnType) string {
+	switch c {
+	case ColumnInt, ColumnFloat, ColumnBytes, ColumnDuration:
+		return "right"
+	case ColumnBool:
+		return "center"
+	}
+	return "left"
+}
+
+// exportCell strips terminal escape sequences from a cell and folds line breaks into spaces.
+func exportCell(value string) string {
+	value = ansi.Strip(value)
+	return strings.NewReplacer("\r\n", " ", "\n", " ", "\r", " ").Replace(value)
+}
+
+// collectRows reads every row of t, normalised to the number of headers.
+func collectRows(t *TableExport) ([][]string, error) {
+	var rows [][]string
+	err := t.EachRow(func(row []string) error {
+		cells := make([]string, len(t.Headers))
+		for i := range cells {
+			if i < len(row) {
+				cells[i] = exportCell(row[i])
+			}
+		}
+		rows = append(rows, cells)
+		return nil
+	})
+	return rows, err
+}
+
+// collectFooter returns the footer of t normalised like collectRows, or nil.
+func collectFooter(t *TableExport) []string {
+	footer := t.FooterRow()
+	if footer == nil {
+		return nil
+	}
+	cells := make([]string, len(t.Headers))
+	for i := range cells {
+		cells[i] = exportCell(cellOf(footer, i))
+	}
+	return cells
+}
+
+// WriteMarkdown writes t as a GitHub-flavoured Markdown table. Numeric columns are right-aligned and
+// boolean columns centred; pipes and HTML tags are escaped and columns are padded so the source reads
+// as a table too. The footer, if any, is the last row, in bold.
+func WriteMarkdown(w io.Writer, t *TableExport) error {
+	escape := strings.NewReplacer(`\`, `\\`, "|", `\|`, "<", `\<`)
+	headers := make([]string, len(t.Headers))
+	widths := make([]int, len(t.Headers))
+	for i, header := range t.Headers {
+		headers[i] = escape.Replace(exportCell(header))
+		widths[i] = max(ansi.StringWidth(headers[i]), 3)
+	}
+	rows, err := collectRows(t)
+	if err != nil {
+		return err
+	}
+	if footer := collectFooter(t); footer != nil {
+		for i, cell := range footer {
+			if cell != "" {
+				footer[i] = "**" + cell + "**"
+			}
+		}
+		rows = append(rows, footer)
+	}
+	for _, row := range rows {
+		for i := range row {
+			row[i] = escape.Replace(row[i])
+			widths[i] = max(widths[i], ansi.StringWidth(row[i]))
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+	writeLine := func(cells []string) {
+		bw.WriteString("|")
+		for i, cell := range cells {
+			pad := strings.Repeat(" ", widths[i]-ansi.StringWidth(cell))
+			if columnAlignment(t.ColumnType(i)) == "right" {
+				bw.WriteString(" " + pad + cell + " |")
+			} else {
+				bw.WriteString(" " + cell + pad + " |")
+			}
+		}
+		bw.WriteString("\n")
+	}
+	writeLine(headers)
+	bw.WriteString("|")
+	for i := range headers {
+		switch columnAlignment(t.ColumnType(i)) {
+		case "right":
+			bw.WriteString(" " + strings.Repeat("-", widths[i]-1) + ": |")
+		case "center":
+			bw.WriteString(" :" + strings.Repeat("-", widths[i]-2) + ": |")
+		default:
+			bw.WriteString(" " + strings.Repeat("-", widths[i]) + " |")
+		}
+	}
+	bw.WriteString("\n")
+	for _, row := range rows {
+		writeLine(row)
+	}
+	return bw.Flush()
+}
+
+// WriteHTML writes t as a standalone HTML document. css is embedded in a <style> element; pass
+// DefaultHTMLTheme for the terminal palette or an empty string for an unstyled table. The footer, if
+// any, is written in a <tfoot>.
+func WriteHTML(w io.Writer, t *TableExport, css string) error {
+	bw := bufio.NewWriter(w)
+	title := t.Title
+	if title == "" {
+		title = "Table"
+	}
+	fmt.Fprintf(bw, "<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>%s</title>\n", html.EscapeString(title))
+	if css != "" {
+		fmt.Fprintf(bw, "<style>\n%s</style>\n", css)
+	}
+	bw.WriteString("</head>\n<body>\n")
+	if t.Title != "" {
+		fmt.Fprintf(bw, "<h1>%s</h1>\n", html.EscapeString(t.Title))
+	}
+	class := func(col int) string {
+		if align := columnAlignment(t.ColumnType(col)); align != "left" {
+			return ` class="align-` + align + `"`
+		}
+		return ""
+	}
+	bw.WriteString("<table>\n<thead>\n<tr>")
+	for i, header := range t.Headers {
+		fmt.Fprintf(bw, "<th%s>%s</th>", class(i), html.EscapeString(exportCell(header)))
+	}
+	bw.WriteString("</tr>\n</thead>\n<tbody>\n")
+	err := t.EachRow(func(row []string) error {
+		bw.WriteString("<tr>")
+		for i := range t.Headers {
+			fmt.Fprintf(bw, "<td%s>%s</td>", class(i), html.EscapeString(exportCell(cellOf(row, i))))
+		}
+		_, err := bw.WriteString("</tr>\n")
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	bw.WriteString("</tbody>\n")
+	if footer := collectFooter(t); footer != nil {
+		bw.WriteString("<tfoot>\n<tr>")
+		for i, cell := range footer {
+			fmt.Fprintf(bw, "<th%s>%s</th>", class(i), html.EscapeString(cell))
+		}
+		bw.WriteString("</tr>\n</tfoot>\n")
+	}
+	bw.WriteString("</table>\n</body>\n</html>\n")
+	return bw.Flush()
+}
+
+// WriteAsciiDoc writes t as an AsciiDoc table with a header row, per-column alignment and, when t has
+// one, a footer row.
+func WriteAsciiDoc(w io.Writer, t *TableExport) error {
+	escape := strings.NewReplacer("|", `\|`)
+	bw := bufio.NewWriter(w)
+	cols := make([]string, len(t.Headers))
+	for i := range t.Headers {
+		switch columnAlignment(t.ColumnType(i)) {
+		case "right":
+			cols[i] = ">"
+		case "center":
+			cols[i] = "^"
+		default:
+			cols[i] = "<"
+		}
+	}
+	if t.Title != "" {
+		fmt.Fprintf(bw, ".%s\n", exportCell(t.Title))
+	}
+	options := "header"
+	if t.Footer != nil {
+		options += ",footer" // the footer is only computed once the rows are written
+	}
+	fmt.Fprintf(bw, "[cols=\"%s\",options=\"%s\"]\n|===\n", strings.Join(cols, ","), options)
+	headers := make([]string, len(t.Headers))
+	for i, header := range t.Headers {
+		headers[i] = "|" + escape.Replace(exportCell(header))
+	}
+	bw.WriteString(strings.Join(headers, " ") + "\n")
+	err := t.EachRow(func(row []string) error {
+		bw.WriteString("\n")
+		for i := range t.Headers {
+			bw.WriteString("|" + escape.Replace(exportCell(cellOf(row, i))) + "\n")
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if t.Footer != nil {
+		footer := collectFooter(t)
+		bw.WriteString("\n")
+		for i := range t.Headers {
+			bw.WriteString("|" + escape.Replace(cellOf(footer, i)) + "\n")
+		}
+	}
+	bw.WriteString("|===\n")
+	return bw.Flush()
+}
+
+// cellOf returns row[i], or an empty string when the row is shorter.
+func cellOf(row []string, i int) string {
+	if i < len(row) {
+		return row[i]
+	}
+	return ""
+}
@@ -0,0 +1,144 @@
+package types
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// textTestTable has cells needing escaping in every text format, a numeric and a boolean column, a
+// coloured cell and a multi-line one.
+func textTestTable() *TableExport {
+	return NewTableExport(
+		[]string{"Name", "Size", "Ok"},
+		[][]string{
+			{"a|b <i>", "10", "yes"},
+			{"\x1b[31mred\x1b[0m", "2048", "no"},
+			{"two\nlines", "", "yes"},
+		},
+		[]ColumnType{ColumnString, ColumnInt, ColumnBool},
+	)
+}
+
+func TestWriteMarkdown(t *testing.T) {
+	tests := []struct {
+		name   string
+		footer []string
+		want   string
+	}{
+		{
+			name: "escaping and alignment",
+			want: "| Name      | Size | Ok  |\n" +
+				"| --------- | ---: | :-: |\n" +
+				"| a\\|b \\<i> |   10 | yes |\n" +
+				"| red       | 2048 | no  |\n" +
+				"| two lines |      | yes |\n",
+		},
+		{
+			name:   "bold footer",
+			footer: []string{"Total", "2058"},
+			want: "| Name      |     Size | Ok  |\n" +
+				"| --------- | -------: | :-: |\n" +
+				"| a\\|b \\<i> |       10 | yes |\n" +
+				"| red       |     2048 | no  |\n" +
+				"| two lines |          | yes |\n" +
+				"| **Total** | **2058** |     |\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := textTestTable()
+			if tt.footer != nil {
+				table.Footer = func() []string { return tt.footer }
+			}
+			var buf bytes.Buffer
+			if err := WriteMarkdown(&buf, table); err != nil {
+				t.Fatalf("WriteMarkdown: %v", err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("WriteMarkdown wrote\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteHTML(t *testing.T) {
+	table := textTestTable()
+	table.Title = `Packages & "friends"`
+	table.Footer = func() []string { return []string{"<total>", "2058"} }
+	tests := []struct {
+		name string
+		css  string
+		want []string
+		omit []string
+	}{
+		{
+			name: "escaped cells and title",
+			want: []string{
+				"<title>Packages &amp; &#34;friends&#34;</title>",
+				"<h1>Packages &amp; &#34;friends&#34;</h1>",
+				`<tr><td>a|b &lt;i&gt;</td><td class="align-right">10</td><td class="align-center">yes</td></tr>`,
+				`<tr><td>red</td>`,
+				`<tr><td>two lines</td><td class="align-right"></td>`,
+				`<tfoot>` + "\n" + `<tr><th>&lt;total&gt;</th><th class="align-right">2058</th><th class="align-center"></th></tr>`,
+			},
+			omit: []string{"<style>", "<i>", "\x1b"},
+		},
+		{
+			name: "embedded theme",
+			css:  DefaultHTMLTheme,
+			want: []string{"<style>\n" + DefaultHTMLTheme + "</style>\n"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := WriteHTML(&buf, table, tt.css); err != nil {
+				t.Fatalf("WriteHTML: %v", err)
+			}
+			got := buf.String()
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("WriteHTML output has no %q:\n%s", want, got)
+				}
+			}
+			for _, omit := range tt.omit {
+				if strings.Contains(got, omit) {
+					t.Errorf("WriteHTML output has %q:\n%s", omit, got)
+				}
+			}
+		})
+	}
+}
+
+func TestWriteHTMLUntitled(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteHTML(&buf, textTestTable(), ""); err != nil {
+		t.Fatalf("WriteHTML: %v", err)
+	}
+	if got := buf.String(); !strings.Contains(got, "<title>Table</title>") || strings.Contains(got, "<h1>") || strings.Contains(got, "<tfoot>") {
+		t.Errorf("an untitled table without footer wrote\n%s", got)
+	}
+}
+
+func TestWriteAsciiDoc(t *testing.T) {
+	table := textTestTable()
+	table.Title = "Packages"
+	table.Footer = func() []string { return []string{"Total", "2058"} }
+	var buf bytes.Buffer
+	if err := WriteAsciiDoc(&buf, table); err != nil {
+		t.Fatalf("WriteAsciiDoc: %v", err)
+	}
+	want := ".Packages\n" +
+		"[cols=\"<,>,^\",options=\"header,footer\"]\n" +
+		"|===\n" +
+		"|Name |Size |Ok\n" +
+		"\n|a\\|b <i>\n|10\n|yes\n" +
+		"\n|red\n|2048\n|no\n" +
+		"\n|two lines\n|\n|yes\n" +
+		"\n|Total\n|2058\n|\n" +
+		"|===\n"
+	if got := buf.String(); got != want {
+		t.Errorf("WriteAsciiDoc wrote\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,248 @@
+package types
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Cell styles defined in xlsxStyles, by position in cellXfs.
+const (
+	xlsxStyleDefault = iota
+	xlsxStyleHeader
+	xlsxStyleDateTime
+	xlsxStyleDuration
+	xlsxStyleInteger
+)
+
+// xlsxMaxColumnWidth bounds the automatic column width, in characters.
+const xlsxMaxColumnWidth = 60
+
+// xlsxEpoch is the origin of spreadsheet serial dates (1900 date system).
+var xlsxEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+// WriteXLSX writes t as a single-sheet Office Open XML workbook: bold header row on a coloured fill,
+// frozen below the header, with an autofilter, columns sized to their content and numeric, boolean,
+// date and duration columns written as typed cells. The footer, if any, is a last row in the header style,
+// outside of the autofilter.
+func WriteXLSX(w io.Writer, t *TableExport) error {
+	var sheetData bytes.Buffer
+	widths := make([]int, len(t.Headers))
+	for i, header := range t.Headers {
+		widths[i] = utf8.RuneCountInString(header)
+	}
+
+	rowCount := 1
+	writeXLSXRow(&sheetData, rowCount, t.Headers, func(int, string) (string, string, int) { return "", "", xlsxStyleHeader })
+	err := t.EachRow(func(row []string) error {
+		rowCount++
+		writeXLSXRow(&sheetData, rowCount, row[:min(len(row), len(t.Headers))], func(col int, value string) (string, string, int) {
+			widths[col] = max(widths[col], utf8.RuneCountInString(value))
+			return xlsxTypedValue(t.ColumnType(col), value)
+		})
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	dataRows := rowCount
+	if footer := t.FooterRow(); footer != nil {
+		rowCount++
+		writeXLSXRow(&sheetData, rowCount, footer[:min(len(footer), len(t.Headers))], func(col int, value string) (string, string, int) {
+			widths[col] = max(widths[col], utf8.RuneCountInString(value))
+			cellType, typed, _ := xlsxTypedValue(t.ColumnType(col), value)
+			return cellType, typed, xlsxStyleHeader
+		})
+	}
+
+	sheetName := xlsxSheetName(t.Title)
+	lastCell := fmt.Sprintf("%s%d", xlsxColumnName(max(len(t.Headers)-1, 0)), rowCount)
+	filterCell := fmt.Sprintf("%s%d", xlsxColumnName(max(len(t.Headers)-1, 0)), dataRows)
+
+	var sheet bytes.Buffer
+	sheet.WriteString(xml.Header)
+	sheet.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">`)
+	fmt.Fprintf(&sheet, `<dimension ref="A1:%s"/>`, lastCell)
+	sheet.WriteString(`<sheetViews><sheetView workbookViewId="0"><pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/><selection pane="bottomLeft" activeCell="A2" sqref="A2"/></sheetView></sheetViews>`)
+	sheet.WriteString(`<sheetFormatPr defaultRowHeight="15"/>`)
+	if len(widths) > 0 {
+		sheet.WriteString("<cols>")
+		for i, width := range widths {
+			fmt.Fprintf(&sheet, `<col min="%d" max="%d" width="%d" customWidth="1"/>`, i+1, i+1, min(width+3, xlsxMaxColumnWidth))
+		}
+		sheet.WriteString("</cols>")
+	}
+	sheet.WriteString("<sheetData>")
+	sheet.Write(sheetData.Bytes())
+	sheet.WriteString("</sheetData>")
+	if len(t.Headers) > 0 {
+		fmt.Fprintf(&sheet, `<autoFilter ref="A1:%s"/>`, filterCell)
+	}
+	sheet.WriteString("</worksheet>")
+
+	workbook := xml.Header +
+		`<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="` + xlsxEscape(sheetName) + `" sheetId="1" r:id="rId1"/></sheets>`
+	if len(t.Headers) > 0 {
+		workbook += `<definedNames><definedName name="_xlnm._FilterDatabase" localSheetId="0" hidden="1">` +
+			xlsxEscape(fmt.Sprintf("'%s'!$A$1:$%s$%d", strings.ReplaceAll(sheetName, "'", "''"), xlsxColumnName(len(t.Headers)-1), dataRows)) +
+			`</definedName></definedNames>`
+	}
+	workbook += `</workbook>`
+
+	files := []struct{ name, body string }{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRootRels},
+		{"xl/workbook.xml", workbook},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+		{"xl/styles.xml", xlsxStyles},
+		{"xl/worksheets/sheet1.xml", sheet.String()},
+	}
+	zw := zip.NewWriter(w)
+	for _, f := range files {
+		fw, err := zw.Create(f.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(fw, f.body); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+// writeXLSXRow appends a <row> to buf. cell returns the cell type attribute ("" for numbers), the
+// value and the style index of each cell; an empty type and value writes an inline string.
+func writeXLSXRow(buf *bytes.Buffer, rowNum int, cells []string, cell func(col int, value string) (string, string, int)) {
+	fmt.Fprintf(buf, `<row r="%d">`, rowNum)
+	for col, value := range cells {
+		ref := fmt.Sprintf("%s%d", xlsxColumnName(col), rowNum)
+		cellType, typed, style := cell(col, value)
+		styleAttr := ""
+		if style != xlsxStyleDefault {
+			styleAttr = fmt.Sprintf(` s="%d"`, style)
+		}
+		switch {
+		case cellType == "" && typed == "":
+			if value == "" && style == xlsxStyleDefault {
+				continue
+			}
+			fmt.Fprintf(buf, `<c r="%s"%s t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, ref, styleAttr, xlsxEscape(value))
+		case cellType == "":
+			fmt.Fprintf(buf, `<c r="%s"%s><v>%s</v></c>`, ref, styleAttr, typed)
+		default:
+			fmt.Fprintf(buf, `<c r="%s"%s t="%s"><v>%s</v></c>`, ref, styleAttr, cellType, typed)
+		}
+	}
+	buf.WriteString("</row>")
+}
+
+// xlsxTypedValue converts a cell to its spreadsheet representation according to the column type.
+// Values that do not parse are written as text.
+func xlsxTypedValue(columnType ColumnType, value string) (string, string, int) {
+	if strings.TrimSpace(value) == "" {
+		return "", "", xlsxStyleDefault
+	}
+	parsed, ok := columnType.Parse(value)
+	if !ok {
+		return "", "", xlsxStyleDefault
+	}
+	switch v := parsed.(type) {
+	case int64:
+		return "", strconv.FormatInt(v, 10), xlsxStyleInteger
+	case float64:
+		if columnType == ColumnBytes {
+			return "", strconv.FormatFloat(v, 'f', -1, 64), xlsxStyleInteger
+		}
+		return "", strconv.FormatFloat(v, 'f', -1, 64), xlsxStyleDefault
+	case bool:
+		if v {
+			return "b", "1", xlsxStyleDefault
+		}
+		return "b", "0", xlsxStyleDefault
+	case time.Time:
+		serial := v.Sub(xlsxEpoch).Hours() / 24
+		if v.Year() == 0 {
+			// Time-only values: keep the fraction of the day.
+			serial = float64(v.Hour()*3600+v.Minute()*60+v.Second()) / 86400
+		}
+		return "", strconv.FormatFloat(serial, 'f', -1, 64), xlsxStyleDateTime
+	case time.Duration:
+		return "", strconv.FormatFloat(v.Hours()/24, 'f', -1, 64), xlsxStyleDuration
+	}
+	return "", "", xlsxStyleDefault
+}
+
+// xlsxColumnName returns the spreadsheet column name of the 0-based index col (A, B, …, Z, AA, …).
+func xlsxColumnName(col int) string {
+	name := ""
+	for col++; col > 0; col = (col - 1) / 26 {
+		name = string(rune('A'+(col-1)%26)) + name
+	}
+	return name
+}
+
+// xlsxSheetName returns a valid sheet name: at most 31 characters, without []:*?/\.
+func xlsxSheetName(title string) string {
+	name := strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]:*?/\`, r) {
+			return '_'
+		}
+		return r
+	}, strings.TrimSpace(title))
+	if name == "" {
+		name = "Table"
+	}
+	if runes := []rune(name); len(runes) > 31 {
+		name = string(runes[:31])
+	}
+	return name
+}
+
+func xlsxEscape(s string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+const xlsxContentTypes = xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+	`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+	`<Default Extension="xml" ContentType="application/xml"/>` +
+	`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+	`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+	`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>` +
+	`</Types>`
+
+const xlsxRootRels = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+	`</Relationships>`
+
+const xlsxWorkbookRels = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+	`<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>` +
+	`</Relationships>`
+
+// xlsxStyles declares, in cellXfs order: default, header (bold white on the table's selection green),
+// date-time, duration and integer with thousands separator.
+const xlsxStyles = xml.Header + `<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+	`<numFmts count="2"><numFmt numFmtId="164" formatCode="yyyy-mm-dd hh:mm:ss"/><numFmt numFmtId="165" formatCode="[h]:mm:ss"/></numFmts>` +
+	`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><color rgb="FFFFFFFF"/><name val="Calibri"/></font></fonts>` +
+	`<fills count="3"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill>` +
+	`<fill><patternFill patternType="solid"><fgColor rgb="FF00432F"/><bgColor indexed="64"/></patternFill></fill></fills>` +
+	`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+	`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+	`<cellXfs count="5">` +
+	`<xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+	`<xf numFmtId="0" fontId="1" fillId="2" borderId="0" xfId="0" applyFont="1" applyFill="1"/>` +
+	`<xf numFmtId="164" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+	`<xf numFmtId="165" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+	`<xf numFmtId="3" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+	`</cellXfs>` +
+	`<cellStyles count="1"><cellStyle name="Normal" xfId="0" builtinId="0"/></cellStyles>` +
+	`</styleSheet>`
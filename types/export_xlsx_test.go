@@ -0,0 +1,159 @@
+package types
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+)
+
+// readXLSX writes t with WriteXLSX and returns the files of the workbook by name.
+func readXLSX(t *testing.T, table *TableExport) map[string]string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := WriteXLSX(&buf, table); err != nil {
+		t.Fatalf("WriteXLSX: %v", err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("WriteXLSX did not write a zip archive: %v", err)
+	}
+	files := map[string]string{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := io.ReadAll(rc)
+		_ = rc.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := xml.Unmarshal(data, new(struct{})); err != nil {
+			t.Errorf("%s is not well-formed XML: %v", f.Name, err)
+		}
+		files[f.Name] = string(data)
+	}
+	return files
+}
+
+func TestWriteXLSX(t *testing.T) {
+	table := NewTableExport(
+		[]string{"Name", "Count", "Ratio", "Size", "Enabled", "Updated", "Uptime", "Version"},
+		[][]string{
+			{"<vim & co>", "1200", "0.5", "2 KiB", "yes", "2024-01-02", "1h30m", "1.10.0"},
+			{"nano", "", "n/a", "512", "no", "2024-01-02 12:00:00", "90s", "2.0"},
+		},
+		[]ColumnType{ColumnString, ColumnInt, ColumnFloat, ColumnBytes, ColumnBool, ColumnTime, ColumnDuration, ColumnSemver},
+	)
+	table.Title = "Packages: [all]"
+	table.Footer = func() []string { return []string{"Total", "1200"} }
+	files := readXLSX(t, table)
+
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/styles.xml", "xl/worksheets/sheet1.xml"} {
+		if _, ok := files[name]; !ok {
+			t.Errorf("workbook has no %s", name)
+		}
+	}
+	sheet := files["xl/worksheets/sheet1.xml"]
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"dimension includes the footer", `<dimension ref="A1:H4"/>`},
+		{"frozen header", `<pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/>`},
+		{"autofilter excludes the footer", `<autoFilter ref="A1:H3"/>`},
+		{"header in the header style", `<c r="A1" s="1" t="inlineStr"><is><t xml:space="preserve">Name</t></is></c>`},
+		{"escaped text", `<c r="A2" t="inlineStr"><is><t xml:space="preserve">&lt;vim &amp; co&gt;</t></is></c>`},
+		{"integer", `<c r="B2" s="4"><v>1200</v></c>`},
+		{"float", `<c r="C2"><v>0.5</v></c>`},
+		{"bytes as a number", `<c r="D2" s="4"><v>2048</v></c>`},
+		{"boolean true", `<c r="E2" t="b"><v>1</v></c>`},
+		{"boolean false", `<c r="E3" t="b"><v>0</v></c>`},
+		{"date serial", `<c r="F2" s="2"><v>45293</v></c>`},
+		{"date time serial", `<c r="F3" s="2"><v>45293.5</v></c>`},
+		{"duration in days", `<c r="G2" s="3"><v>0.0625</v></c>`},
+		{"version as text", `<c r="H2" t="inlineStr"><is><t xml:space="preserve">1.10.0</t></is></c>`},
+		{"unparsable value as text", `<c r="C3" t="inlineStr"><is><t xml:space="preserve">n/a</t></is></c>`},
+		{"footer in the header style", `<c r="A4" s="1" t="inlineStr"><is><t xml:space="preserve">Total</t></is></c><c r="B4" s="1"><v>1200</v></c>`},
+		{"column sized to its content", `<col min="1" max="1" width="13" customWidth="1"/>`},
+	}
+	for _, tt := range tests {
+		if !strings.Contains(sheet, tt.want) {
+			t.Errorf("%s: sheet has no %s", tt.name, tt.want)
+		}
+	}
+	if strings.Contains(sheet, `r="B3"`) {
+		t.Error("the empty cell B3 should not be written")
+	}
+	workbook := files["xl/workbook.xml"]
+	if !strings.Contains(workbook, `<sheet name="Packages_ _all_" sheetId="1" r:id="rId1"/>`) {
+		t.Errorf("workbook has no sanitised sheet name: %s", workbook)
+	}
+	if !strings.Contains(workbook, `&#39;Packages_ _all_&#39;!$A$1:$H$3`) {
+		t.Errorf("workbook has no filter range: %s", workbook)
+	}
+}
+
+func TestWriteXLSXWithoutHeaders(t *testing.T) {
+	files := readXLSX(t, NewTableExport(nil, nil, nil))
+	sheet := files["xl/worksheets/sheet1.xml"]
+	if strings.Contains(sheet, "<autoFilter") || strings.Contains(files["xl/workbook.xml"], "<definedNames>") {
+		t.Error("a table without headers should have no autofilter")
+	}
+	if !strings.Contains(files["xl/workbook.xml"], `<sheet name="Table"`) {
+		t.Error("an untitled table should be on a sheet named Table")
+	}
+}
+
+func TestXLSXColumnName(t *testing.T) {
+	tests := map[int]string{0: "A", 25: "Z", 26: "AA", 51: "AZ", 52: "BA", 701: "ZZ", 702: "AAA"}
+	for col, want := range tests {
+		if got := xlsxColumnName(col); got != want {
+			t.Errorf("xlsxColumnName(%d) = %q, want %q", col, got, want)
+		}
+	}
+}
+
+func TestXLSXSheetName(t *testing.T) {
+	tests := map[string]string{
+		"":                                     "Table",
+		"  Packages  ":                         "Packages",
+		`a/b\c?d*e:f[g]`:                       "a_b_c_d_e_f_g_",
+		"a title longer than thirty-one runes": "a title longer than thirty-one ",
+		"ééééééééééééééééééééééééééééééééé": "ééééééééééééééééééééééééééééééé",
+	}
+	for title, want := range tests {
+		if got := xlsxSheetName(title); got != want {
+			t.Errorf("xlsxSheetName(%q) = %q, want %q", title, got, want)
+		}
+	}
+}
+
+func TestXLSXTypedValue(t *testing.T) {
+	tests := []struct {
+		columnType ColumnType
+		value      string
+		cellType   string
+		typed      string
+		style      int
+	}{
+		{ColumnInt, "-7", "", "-7", xlsxStyleInteger},
+		{ColumnFloat, "1e3", "", "1000", xlsxStyleDefault},
+		{ColumnBytes, "1 MB", "", "1000000", xlsxStyleInteger},
+		{ColumnBool, "off", "b", "0", xlsxStyleDefault},
+		{ColumnTime, "12:00:00", "", "0.5", xlsxStyleDateTime},
+		{ColumnDuration, "36h", "", "1.5", xlsxStyleDuration},
+		{ColumnInt, "  ", "", "", xlsxStyleDefault},
+		{ColumnInt, "many", "", "", xlsxStyleDefault},
+		{ColumnString, "42", "", "", xlsxStyleDefault},
+	}
+	for _, tt := range tests {
+		cellType, typed, style := xlsxTypedValue(tt.columnType, tt.value)
+		if cellType != tt.cellType || typed != tt.typed || style != tt.style {
+			t.Errorf("xlsxTypedValue(%s, %q) = %q, %q, %d, want %q, %q, %d", tt.columnType, tt.value, cellType, typed, style, tt.cellType, tt.typed, tt.style)
+		}
+	}
+}
@@ -1,14 +1,56 @@
 package types
 
+import "fmt"
+
+// MultiTableManager holds the handlers of a multi-table screen and which one is shown. Titles name
+// the tables; missing titles default to "Table N".
 type MultiTableManager struct {
 	Handlers []TableDataHandler
+	Titles   []string
 	Current  int
 }
 
+// Add appends a table and returns its index. An empty title keeps the one already in Titles, if any.
+func (m *MultiTableManager) Add(title string, handler TableDataHandler) int {
+	m.Handlers = append(m.Handlers, handler)
+	i := len(m.Handlers) - 1
+	for len(m.Titles) <= i {
+		m.Titles = append(m.Titles, "")
+	}
+	if title != "" {
+		m.Titles[i] = title
+	}
+	return i
+}
+
+// Len returns the number of tables.
+func (m *MultiTableManager) Len() int { return len(m.Handlers) }
+
+// Title returns the title of table i.
+func (m *MultiTableManager) Title(i int) string {
+	if i >= 0 && i < len(m.Titles) && m.Titles[i] != "" {
+		return m.Titles[i]
+	}
+	return fmt.Sprintf("Table %d", i+1)
+}
+
+// Select makes table i the current one, ignoring indexes out of range.
+func (m *MultiTableManager) Select(i int) {
+	if i >= 0 && i < len(m.Handlers) {
+		m.Current = i
+	}
+}
+
 func (m *MultiTableManager) Next() {
+	if len(m.Handlers) == 0 {
+		return
+	}
 	m.Current = (m.Current + 1) % len(m.Handlers)
 }
 func (m *MultiTableManager) Previous() {
+	if len(m.Handlers) == 0 {
+		return
+	}
 	m.Current = (m.Current - 1 + len(m.Handlers)) % len(m.Handlers)
 }
 func (m *MultiTableManager) GetCurrentHandler() TableDataHandler {
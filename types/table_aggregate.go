@@ -0,0 +1,192 @@
+package types
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+// AggregateFunc summarises the values of a column in table footers and group headers.
+type AggregateFunc string
+
+const (
+	AggregateSum      AggregateFunc = "sum"
+	AggregateAvg      AggregateFunc = "avg"
+	AggregateMin      AggregateFunc = "min"
+	AggregateMax      AggregateFunc = "max"
+	AggregateCount    AggregateFunc = "count"
+	AggregateDistinct AggregateFunc = "distinct"
+)
+
+func (f AggregateFunc) Description() string {
+	switch f {
+	case AggregateSum:
+		return "Sum of the values"
+	case AggregateAvg:
+		return "Average of the values"
+	case AggregateMin:
+		return "Smallest value"
+	case AggregateMax:
+		return "Largest value"
+	case AggregateCount:
+		return "Number of non-empty values"
+	case AggregateDistinct:
+		return "Number of distinct non-empty values"
+	default:
+		return "Unknown aggregate"
+	}
+}
+func (f AggregateFunc) String() string { return string(f) }
+
+// AggregateFuncs lists the aggregates in the order the table view cycles through them.
+var AggregateFuncs = []AggregateFunc{AggregateSum, AggregateAvg, AggregateMin, AggregateMax, AggregateCount, AggregateDistinct}
+
+// AppliesTo reports whether f can summarise a column of type c: sum and avg need numeric columns
+// (int, float, bytes or duration), the other aggregates work on any column.
+func (f AggregateFunc) AppliesTo(c ColumnType) bool {
+	switch f {
+	case AggregateSum, AggregateAvg:
+		return c.IsNumeric()
+	case AggregateMin, AggregateMax, AggregateCount, AggregateDistinct:
+		return true
+	}
+	return false
+}
+
+// IsNumeric reports whether values of the column can be added up.
+func (c ColumnType) IsNumeric() bool {
+	switch c {
+	case ColumnInt, ColumnFloat, ColumnBytes, ColumnDuration:
+		return true
+	}
+	return false
+}
+
+// Aggregator computes an aggregate over the values of one column, one value at a time. Empty values
+// are skipped, and so are values that do not parse for sum and avg.
+type Aggregator struct {
+	Func       AggregateFunc
+	ColumnType ColumnType
+	count      int
+	numbers    int
+	sum        float64
+	min, max   string
+	distinct   map[string]struct{}
+}
+
+// NewAggregator creates an aggregator of fn over a column of the given type.
+func NewAggregator(fn AggregateFunc, columnType ColumnType) *Aggregator {
+	return &Aggregator{Func: fn, ColumnType: columnType}
+}
+
+// Add adds a value to the aggregate.
+func (a *Aggregator) Add(value string) {
+	if value == "" {
+		return
+	}
+	a.count++
+	switch a.Func {
+	case AggregateSum, AggregateAvg:
+		if n, ok := a.number(value); ok {
+			a.sum += n
+			a.numbers++
+		}
+	case AggregateMin:
+		if a.count == 1 || a.ColumnType.Compare(value, a.min) < 0 {
+			a.min = value
+		}
+	case AggregateMax:
+		if a.count == 1 || a.ColumnType.Compare(value, a.max) > 0 {
+			a.max = value
+		}
+	case AggregateDistinct:
+		if a.distinct == nil {
+			a.distinct = make(map[string]struct{})
+		}
+		a.distinct[value] = struct{}{}
+	}
+}
+
+// number returns value as a number of the column type: durations in nanoseconds, bytes in bytes.
+func (a *Aggregator) number(value string) (float64, bool) {
+	parsed, ok := a.ColumnType.Parse(value)
+	if !ok {
+		return 0, false
+	}
+	switch n := parsed.(type) {
+	case int64:
+		return float64(n), true
+	case float64:
+		return n, true
+	case time.Duration:
+		return float64(n), true
+	}
+	return 0, false
+}
+
+// Result returns the aggregate formatted like the values of the column, or an empty string when
+// there is nothing to summarise.
+func (a *Aggregator) Result() string {
+	switch a.Func {
+	case AggregateSum:
+		if a.numbers == 0 || !a.ColumnType.IsNumeric() {
+			return ""
+		}
+		return a.format(a.sum)
+	case AggregateAvg:
+		if a.numbers == 0 || !a.ColumnType.IsNumeric() {
+			return ""
+		}
+		avg := a.sum / float64(a.numbers)
+		if a.ColumnType == ColumnInt || a.ColumnType == ColumnFloat {
+			return strconv.FormatFloat(math.Round(avg*100)/100, 'f', -1, 64)
+		}
+		return a.format(avg)
+	case AggregateMin:
+		return a.min
+	case AggregateMax:
+		return a.max
+	case AggregateCount:
+		return strconv.Itoa(a.count)
+	case AggregateDistinct:
+		return strconv.Itoa(len(a.distinct))
+	}
+	return ""
+}
+
+// format formats n like the values of the column.
+func (a *Aggregator) format(n float64) string {
+	switch a.ColumnType {
+	case ColumnInt:
+		return strconv.FormatFloat(math.Round(n), 'f', -1, 64)
+	case ColumnDuration:
+		return time.Duration(n).Round(time.Millisecond).String()
+	case ColumnBytes:
+		return FormatBytes(n)
+	}
+	return strconv.FormatFloat(n, 'f', -1, 64)
+}
+
+// FormatBytes formats a number of bytes with a binary unit, e.g. 1536 as "1.5KiB".
+func FormatBytes(n float64) string {
+	units := []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
+	i := 0
+	for math.Abs(n) >= 1024 && i < len(units)-1 {
+		n /= 1024
+		i++
+	}
+	if i == 0 {
+		return fmt.Sprintf("%gB", math.Round(n))
+	}
+	return strconv.FormatFloat(math.Round(n*10)/10, 'f', -1, 64) + units[i]
+}
+
+// Aggregate computes fn over values of a column of the given type.
+func Aggregate(fn AggregateFunc, columnType ColumnType, values ...string) string {
+	a := NewAggregator(fn, columnType)
+	for _, value := range values {
+		a.Add(value)
+	}
+	return a.Result()
+}
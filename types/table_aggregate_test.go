@@ -0,0 +1,96 @@
+package types
+
+import "testing"
+
+func TestAggregatorResult(t *testing.T) {
+	tests := []struct {
+		name       string
+		fn         AggregateFunc
+		columnType ColumnType
+		values     []string
+		want       string
+	}{
+		{"int sum", AggregateSum, ColumnInt, []string{"1", "2", "", "40"}, "43"},
+		{"int sum skips unparsable", AggregateSum, ColumnInt, []string{"1", "n/a", "2"}, "3"},
+		{"float sum", AggregateSum, ColumnFloat, []string{"0.5", "1.25"}, "1.75"},
+		{"bytes sum", AggregateSum, ColumnBytes, []string{"1 KiB", "512", "1 MiB"}, "1MiB"},
+		{"small bytes sum", AggregateSum, ColumnBytes, []string{"100", "20"}, "120B"},
+		{"duration sum", AggregateSum, ColumnDuration, []string{"1h", "30m", "1.5s"}, "1h30m1.5s"},
+		{"sum of text", AggregateSum, ColumnString, []string{"1", "2"}, ""},
+		{"sum without numbers", AggregateSum, ColumnInt, []string{"", "n/a"}, ""},
+		{"sum of nothing", AggregateSum, ColumnInt, nil, ""},
+		{"int avg rounded to cents", AggregateAvg, ColumnInt, []string{"1", "2", "2"}, "1.67"},
+		{"float avg", AggregateAvg, ColumnFloat, []string{"1", "2"}, "1.5"},
+		{"bytes avg", AggregateAvg, ColumnBytes, []string{"1 KiB", "2 KiB"}, "1.5KiB"},
+		{"duration avg", AggregateAvg, ColumnDuration, []string{"1m", "2m"}, "1m30s"},
+		{"avg skips empty cells", AggregateAvg, ColumnInt, []string{"4", "", "2"}, "3"},
+		{"avg of text", AggregateAvg, ColumnSemver, []string{"1.0", "2.0"}, ""},
+		{"int min", AggregateMin, ColumnInt, []string{"9", "10", "-1"}, "-1"},
+		{"int max by value", AggregateMax, ColumnInt, []string{"9", "10", ""}, "10"},
+		{"semver max", AggregateMax, ColumnSemver, []string{"1.9.0", "1.10.0", "1.10.0-rc1"}, "1.10.0"},
+		{"semver min", AggregateMin, ColumnSemver, []string{"1.9.0", "1.10.0", "1.10.0-rc1"}, "1.9.0"},
+		{"text min", AggregateMin, ColumnString, []string{"nano", "git", "vim"}, "git"},
+		{"bytes max keeps the cell", AggregateMax, ColumnBytes, []string{"2 MB", "512 KiB"}, "2 MB"},
+		{"min of nothing", AggregateMin, ColumnInt, []string{""}, ""},
+		{"count skips empty cells", AggregateCount, ColumnString, []string{"a", "", "b", "a"}, "3"},
+		{"count of nothing", AggregateCount, ColumnString, nil, "0"},
+		{"distinct", AggregateDistinct, ColumnString, []string{"a", "", "b", "a"}, "2"},
+		{"distinct of nothing", AggregateDistinct, ColumnString, nil, "0"},
+		{"unknown aggregate", AggregateFunc("median"), ColumnInt, []string{"1"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := NewAggregator(tt.fn, tt.columnType)
+			for _, value := range tt.values {
+				a.Add(value)
+			}
+			if got := a.Result(); got != tt.want {
+				t.Errorf("%s over %s %q = %q, want %q", tt.fn, tt.columnType, tt.values, got, tt.want)
+			}
+			if got := Aggregate(tt.fn, tt.columnType, tt.values...); got != tt.want {
+				t.Errorf("Aggregate(%s, %s, %q) = %q, want %q", tt.fn, tt.columnType, tt.values, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAggregateFuncAppliesTo(t *testing.T) {
+	tests := []struct {
+		fn         AggregateFunc
+		columnType ColumnType
+		want       bool
+	}{
+		{AggregateSum, ColumnInt, true},
+		{AggregateSum, ColumnBytes, true},
+		{AggregateAvg, ColumnDuration, true},
+		{AggregateSum, ColumnString, false},
+		{AggregateAvg, ColumnTime, false},
+		{AggregateMin, ColumnString, true},
+		{AggregateMax, ColumnSemver, true},
+		{AggregateCount, ColumnBool, true},
+		{AggregateDistinct, ColumnString, true},
+		{AggregateFunc("median"), ColumnInt, false},
+	}
+	for _, tt := range tests {
+		if got := tt.fn.AppliesTo(tt.columnType); got != tt.want {
+			t.Errorf("%s.AppliesTo(%s) = %v, want %v", tt.fn, tt.columnType, got, tt.want)
+		}
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := map[float64]string{
+		0:                "0B",
+		1023:             "1023B",
+		1024:             "1KiB",
+		1536:             "1.5KiB",
+		1 << 20:          "1MiB",
+		5.25 * (1 << 30): "5.3GiB",
+		-2048:            "-2KiB",
+	}
+	for n, want := range tests {
+		if got := FormatBytes(n); got != want {
+			t.Errorf("FormatBytes(%g) = %q, want %q", n, got, want)
+		}
+	}
+}
@@ -0,0 +1,313 @@
+package types
+
+import (
+	"cmp"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Table Column Types
+
+type ColumnType string
+
+const (
+	ColumnString   ColumnType = "string"
+	ColumnInt      ColumnType = "int"
+	ColumnFloat    ColumnType = "float"
+	ColumnBool     ColumnType = "bool"
+	ColumnTime     ColumnType = "time"
+	ColumnDuration ColumnType = "duration"
+	ColumnBytes    ColumnType = "bytes"
+	ColumnSemver   ColumnType = "semver"
+)
+
+func (c ColumnType) Description() string { return "Column Type " + string(c) }
+func (c ColumnType) String() string      { return string(c) }
+
+// TableSchemaProvider is implemented by handlers and sources that know the type of their columns.
+// A ColumnType left empty ("") is auto-detected.
+type TableSchemaProvider interface {
+	GetColumnTypes() []ColumnType
+}
+
+// detectOrder is the order in which types are tried by DetectColumnType, from the most to the least specific.
+var detectOrder = []ColumnType{ColumnInt, ColumnFloat, ColumnBool, ColumnTime, ColumnDuration, ColumnBytes, ColumnSemver}
+
+var (
+	bytesPattern   = regexp.MustCompile(`^(?i)([0-9]+(?:\.[0-9]+)?)\s*([kmgtpe]i?b|b|[kmgtpe])$`)
+	semverPattern  = regexp.MustCompile(`^(?:[0-9]+:)?v?[0-9]+(?:\.[0-9]+)*(?:[-+~][0-9A-Za-z.+~:-]*)?$`)
+	dottedPattern  = regexp.MustCompile(`^(?:[0-9]+:)?v?[0-9]+(?:\.[0-9]+)+`)
+	twoPartPattern = regexp.MustCompile(`^[0-9]+\.([0-9]+)$`)
+	timeLayouts    = []string{
+		time.RFC3339Nano,
+		time.RFC3339,
+		time.DateTime,
+		"2006-01-02T15:04:05",
+		"2006-01-02 15:04",
+		time.DateOnly,
+		time.RFC1123Z,
+		time.RFC1123,
+		time.RFC822,
+		time.UnixDate,
+		time.ANSIC,
+		time.Stamp,
+		time.TimeOnly,
+	}
+	byteUnits = map[string]float64{
+		"": 1, "b": 1,
+		"k": 1e3, "kb": 1e3, "kib": 1 << 10,
+		"m": 1e6, "mb": 1e6, "mib": 1 << 20,
+		"g": 1e9, "gb": 1e9, "gib": 1 << 30,
+		"t": 1e12, "tb": 1e12, "tib": 1 << 40,
+		"p": 1e15, "pb": 1e15, "pib": 1 << 50,
+		"e": 1e18, "eb": 1e18, "eib": 1 << 60,
+	}
+)
+
+// DetectColumnType returns the most specific type every non-empty value parses as. At most the first
+// 200 non-empty values are inspected. Versions may have a single component ("10" among "9.2.1"), but
+// at least one of them needs two. Two-part numbers are versions rather than floats when their second
+// parts differ in length without leading zeros ("1.9" next to "1.10"); use a TableSchemaProvider for
+// decimal columns like "1.5" and "2.25".
+func DetectColumnType(values []string) ColumnType {
+	candidates := append([]ColumnType(nil), detectOrder...)
+	seen, dotted := 0, false
+	minorLen, mixedMinors, decimals := 0, false, false
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+		seen++
+		dotted = dotted || dottedPattern.MatchString(v)
+		if m := twoPartPattern.FindStringSubmatch(v); m != nil {
+			switch minor := m[1]; {
+			case len(minor) > 1 && minor[0] == '0':
+				decimals = true // "1.05" is no version
+			case minorLen == 0:
+				minorLen = len(minor)
+			case len(minor) != minorLen:
+				mixedMinors = true
+			}
+		}
+		kept := candidates[:0]
+		for _, c := range candidates {
+			if c.matches(v) {
+				kept = append(kept, c)
+			}
+		}
+		candidates = kept
+		if len(candidates) == 0 || seen >= 200 {
+			break
+		}
+	}
+	if seen == 0 || len(candidates) == 0 || candidates[0] == ColumnSemver && !dotted {
+		return ColumnString
+	}
+	if candidates[0] == ColumnFloat && mixedMinors && !decimals && slices.Contains(candidates, ColumnSemver) {
+		return ColumnSemver
+	}
+	return candidates[0]
+}
+
+// DetectColumnTypes detects the type of every column of rows.
+func DetectColumnTypes(columns int, rows [][]string) []ColumnType {
+	types := make([]ColumnType, columns)
+	values := make([]string, 0, len(rows))
+	for col := range types {
+		values = values[:0]
+		for _, row := range rows {
+			if col < len(row) {
+				values = append(values, row[col])
+			}
+		}
+		types[col] = DetectColumnType(values)
+	}
+	return types
+}
+
+// matches reports whether v can be detected as this type. It is stricter than Parse: bytes need a
+// unit, booleans are spelled out, and semver needs numeric components (see DetectColumnType for the
+// dotted one).
+func (c ColumnType) matches(v string) bool {
+	switch c {
+	case ColumnBool:
+		_, ok := c.Parse(v)
+		return ok && len(v) > 1 // "y" or "t" alone is more likely a code than a flag
+	case ColumnBytes:
+		return bytesPattern.MatchString(v)
+	case ColumnSemver:
+		return semverPattern.MatchString(v)
+	case ColumnDuration:
+		_, err := time.ParseDuration(v)
+		return err == nil && strings.IndexFunc(v, func(r rune) bool { return r >= 'a' && r <= 'z' || r == 'µ' }) >= 0
+	default:
+		_, ok := c.Parse(v)
+		return ok
+	}
+}
+
+// Parse converts a cell value to its typed representation: int64, float64, bool, time.Time,
+// time.Duration, float64 (bytes), or string (string and semver columns).
+func (c ColumnType) Parse(v string) (any, bool) {
+	v = strings.TrimSpace(v)
+	switch c {
+	case ColumnInt:
+		i, err := strconv.ParseInt(v, 10, 64)
+		return i, err == nil
+	case ColumnFloat:
+		f, err := strconv.ParseFloat(v, 64)
+		return f, err == nil && !math.IsNaN(f)
+	case ColumnBool:
+		switch strings.ToLower(v) {
+		case "true", "t", "yes", "y", "on":
+			return true, true
+		case "false", "f", "no", "n", "off":
+			return false, true
+		}
+		return false, false
+	case ColumnTime:
+		for _, layout := range timeLayouts {
+			if t, err := time.Parse(layout, v); err == nil {
+				return t, true
+			}
+		}
+		return time.Time{}, false
+	case ColumnDuration:
+		d, err := time.ParseDuration(v)
+		return d, err == nil
+	case ColumnBytes:
+		m := bytesPattern.FindStringSubmatch(v)
+		if m == nil {
+			f, err := strconv.ParseFloat(v, 64)
+			return f, err == nil
+		}
+		f, err := strconv.ParseFloat(m[1], 64)
+		return f * byteUnits[strings.ToLower(m[2])], err == nil
+	case ColumnSemver:
+		return v, semverPattern.MatchString(v)
+	default:
+		return v, true
+	}
+}
+
+// CompareOrdered compares two cell values like Compare, reversed when asc is false. Empty cells sort
+// last in both directions.
+func (c ColumnType) CompareOrdered(a, b string, asc bool) int {
+	if emptyA, emptyB := strings.TrimSpace(a) == "", strings.TrimSpace(b) == ""; emptyA || emptyB {
+		return boolOrder(emptyA, emptyB)
+	}
+	if asc {
+		return c.Compare(a, b)
+	}
+	return c.Compare(b, a)
+}
+
+// Compare compares two cell values according to the column type and returns -1, 0 or +1.
+// Empty cells sort last, and values that do not parse sort after the ones that do.
+func (c ColumnType) Compare(a, b string) int {
+	a, b = strings.TrimSpace(a), strings.TrimSpace(b)
+	if a == "" || b == "" {
+		return boolOrder(a == "", b == "")
+	}
+	switch c {
+	case ColumnSemver:
+		return CompareVersions(a, b)
+	case ColumnString, "":
+		return strings.Compare(a, b)
+	}
+	pa, okA := c.Parse(a)
+	pb, okB := c.Parse(b)
+	if !okA || !okB {
+		if okA == okB {
+			return strings.Compare(a, b)
+		}
+		return boolOrder(!okA, !okB)
+	}
+	switch va := pa.(type) {
+	case int64:
+		return cmp.Compare(va, pb.(int64))
+	case float64:
+		return cmp.Compare(va, pb.(float64))
+	case time.Duration:
+		return cmp.Compare(va, pb.(time.Duration))
+	case time.Time:
+		return va.Compare(pb.(time.Time))
+	case bool:
+		return boolOrder(va, pb.(bool))
+	}
+	return strings.Compare(a, b)
+}
+
+// CompareVersions compares version strings such as "1.10.0", "v2.0.0-rc1" or "1:2.34-1ubuntu1".
+// Numeric components are compared as numbers and a pre-release sorts before its release.
+func CompareVersions(a, b string) int {
+	epochA, restA := splitEpoch(a)
+	epochB, restB := splitEpoch(b)
+	if r := cmp.Compare(epochA, epochB); r != 0 {
+		return r
+	}
+	coreA, preA, _ := strings.Cut(strings.TrimPrefix(restA, "v"), "-")
+	coreB, preB, _ := strings.Cut(strings.TrimPrefix(restB, "v"), "-")
+	if r := compareNatural(coreA, coreB); r != 0 {
+		return r
+	}
+	if preA == "" || preB == "" {
+		return boolOrder(preA == "", preB == "")
+	}
+	return compareNatural(preA, preB)
+}
+
+func splitEpoch(v string) (int, string) {
+	if i := strings.IndexByte(v, ':'); i > 0 {
+		if epoch, err := strconv.Atoi(v[:i]); err == nil {
+			return epoch, v[i+1:]
+		}
+	}
+	return 0, v
+}
+
+// compareNatural compares strings chunk by chunk, treating runs of digits as numbers.
+func compareNatural(a, b string) int {
+	for a != "" && b != "" {
+		ca, restA := nextChunk(a)
+		cb, restB := nextChunk(b)
+		na, errA := strconv.ParseUint(ca, 10, 64)
+		nb, errB := strconv.ParseUint(cb, 10, 64)
+		var r int
+		if errA == nil && errB == nil {
+			r = cmp.Compare(na, nb)
+		} else {
+			r = strings.Compare(ca, cb)
+		}
+		if r != 0 {
+			return r
+		}
+		a, b = restA, restB
+	}
+	return cmp.Compare(len(a), len(b))
+}
+
+func nextChunk(s string) (string, string) {
+	digit := s[0] >= '0' && s[0] <= '9'
+	i := 1
+	for i < len(s) && (s[i] >= '0' && s[i] <= '9') == digit {
+		i++
+	}
+	return s[:i], s[i:]
+}
+
+// boolOrder orders false before true.
+func boolOrder(a, b bool) int {
+	if a == b {
+		return 0
+	}
+	if a {
+		return 1
+	}
+	return -1
+}
@@ -0,0 +1,150 @@
+package types
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDetectColumnType(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []string
+		want   ColumnType
+	}{
+		{"no values", nil, ColumnString},
+		{"only empty cells", []string{"", "  "}, ColumnString},
+		{"integers", []string{"9", "10", "-3"}, ColumnInt},
+		{"integers with empty cells", []string{"9", "", "10"}, ColumnInt},
+		{"floats", []string{"1.5", "2", "3e2"}, ColumnFloat},
+		{"booleans", []string{"yes", "no", "true"}, ColumnBool},
+		{"dates", []string{"2024-01-02", "2023-12-31"}, ColumnTime},
+		{"date times", []string{"2024-01-02 15:04:05", "2024-01-02T15:04:05Z"}, ColumnTime},
+		{"durations", []string{"1h30m", "250ms"}, ColumnDuration},
+		{"bare numbers are not durations", []string{"0", "1"}, ColumnInt},
+		{"sizes", []string{"512 KiB", "2 MB", "10b"}, ColumnBytes},
+		{"versions", []string{"1.10.0", "1.9.2", "v2.0.0-rc1"}, ColumnSemver},
+		{"debian versions", []string{"1:2.34.1-1ubuntu1", "2.35-0ubuntu3"}, ColumnSemver},
+		{"mixed major-only versions", []string{"10", "2", "9.2.1"}, ColumnSemver},
+		{"major-only versions with suffixes", []string{"2-1", "3.1-4"}, ColumnSemver},
+		{"major-only values are not versions", []string{"v2", "v3"}, ColumnString},
+		{"two-part versions", []string{"1.2", "1.10"}, ColumnSemver},
+		{"two-part versions with a zero", []string{"1.9", "1.10", "2.0"}, ColumnSemver},
+		{"two-part versions among major-only ones", []string{"2", "1.9", "1.10"}, ColumnSemver},
+		{"two-part numbers of one length are floats", []string{"1.2", "1.5", "10.3"}, ColumnFloat},
+		{"decimals with leading zeros are floats", []string{"1.05", "1.5", "2.25"}, ColumnFloat},
+		{"negative two-part numbers are floats", []string{"-1.2", "1.10"}, ColumnFloat},
+		{"single letters are not booleans", []string{"y", "n", "y"}, ColumnString},
+		{"one-letter codes are not booleans", []string{"t", "f"}, ColumnString},
+		{"short boolean words", []string{"on", "off", "no"}, ColumnBool},
+		{"text", []string{"installed", "1.2.3"}, ColumnString},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DetectColumnType(tt.values); got != tt.want {
+				t.Errorf("DetectColumnType(%q) = %s, want %s", tt.values, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDetectColumnTypes(t *testing.T) {
+	rows := [][]string{
+		{"vim", "2:8.2.3995", "3.5 MB", "12"},
+		{"nano", "6.2-1", "800 KiB"},
+	}
+	want := []ColumnType{ColumnString, ColumnSemver, ColumnBytes, ColumnInt}
+	if got := DetectColumnTypes(4, rows); !reflect.DeepEqual(got, want) {
+		t.Errorf("DetectColumnTypes() = %v, want %v", got, want)
+	}
+}
+
+func TestColumnTypeCompare(t *testing.T) {
+	tests := []struct {
+		name       string
+		columnType ColumnType
+		a, b       string
+		want       int
+	}{
+		{"strings", ColumnString, "apple", "banana", -1},
+		{"untyped strings", "", "b", "a", 1},
+		{"strings ignore surrounding spaces", ColumnString, " a ", "a", 0},
+		{"integers by value", ColumnInt, "9", "10", -1},
+		{"negative integers", ColumnInt, "-10", "-9", -1},
+		{"floats by value", ColumnFloat, "2.5", "10", -1},
+		{"equal floats", ColumnFloat, "1.50", "1.5", 0},
+		{"booleans false first", ColumnBool, "yes", "no", 1},
+		{"times", ColumnTime, "2024-01-02", "2023-12-31", 1},
+		{"durations", ColumnDuration, "90s", "1h", -1},
+		{"bytes by size", ColumnBytes, "512 KiB", "2 MB", -1},
+		{"binary and decimal units", ColumnBytes, "1 KiB", "1000 B", 1},
+		{"bytes without unit", ColumnBytes, "2048", "1 KiB", 1},
+		{"semver numeric components", ColumnSemver, "1.9.0", "1.10.0", -1},
+		{"semver pre-release first", ColumnSemver, "1.10.0-rc1", "1.10.0", -1},
+		{"semver v prefix", ColumnSemver, "v2.0.0", "1.99", 1},
+		{"semver epoch", ColumnSemver, "1:1.0", "9.9", 1},
+		{"semver major only", ColumnSemver, "10", "9.2.1", 1},
+		{"empty cell last", ColumnInt, "", "1", 1},
+		{"empty cells equal", ColumnInt, "", " ", 0},
+		{"unparsable after parsable", ColumnInt, "n/a", "5", 1},
+		{"unparsable by text", ColumnInt, "b", "a", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.columnType.Compare(tt.a, tt.b); got != tt.want {
+				t.Errorf("%s.Compare(%q, %q) = %d, want %d", tt.columnType, tt.a, tt.b, got, tt.want)
+			}
+			if got := tt.columnType.Compare(tt.b, tt.a); got != -tt.want {
+				t.Errorf("%s.Compare(%q, %q) = %d, want %d", tt.columnType, tt.b, tt.a, got, -tt.want)
+			}
+		})
+	}
+}
+
+func TestColumnTypeCompareOrdered(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		asc  bool
+		want int
+	}{
+		{"ascending", "9", "10", true, -1},
+		{"descending", "9", "10", false, 1},
+		{"empty last ascending", "", "10", true, 1},
+		{"empty last descending", "", "10", false, 1},
+		{"value before empty descending", "10", "", false, -1},
+		{"empty cells equal", "", "", false, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ColumnInt.CompareOrdered(tt.a, tt.b, tt.asc); got != tt.want {
+				t.Errorf("CompareOrdered(%q, %q, %v) = %d, want %d", tt.a, tt.b, tt.asc, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestColumnTypeParse(t *testing.T) {
+	tests := []struct {
+		columnType ColumnType
+		value      string
+		want       any
+		ok         bool
+	}{
+		{ColumnInt, " 42 ", int64(42), true},
+		{ColumnInt, "4.2", int64(0), false},
+		{ColumnFloat, "NaN", nil, false},
+		{ColumnBool, "Off", false, true},
+		{ColumnBool, "maybe", false, false},
+		{ColumnBytes, "1.5 KiB", float64(1536), true},
+		{ColumnBytes, "3 MB", float64(3e6), true},
+		{ColumnSemver, "1.2.3-rc1", "1.2.3-rc1", true},
+		{ColumnSemver, "latest", "latest", false},
+		{ColumnString, "anything", "anything", true},
+	}
+	for _, tt := range tests {
+		got, ok := tt.columnType.Parse(tt.value)
+		if ok != tt.ok || tt.want != nil && got != tt.want {
+			t.Errorf("%s.Parse(%q) = %v, %v, want %v, %v", tt.columnType, tt.value, got, ok, tt.want, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,23 @@
+package types
+
+// TableRowChange is a row changed in edit mode: its values before and after the edits, and the
+// indexes of the columns that differ.
+type TableRowChange struct {
+	Before  []string `json:"before" yaml:"before"`
+	After   []string `json:"after" yaml:"after"`
+	Columns []int    `json:"columns" yaml:"columns"`
+}
+
+// TableDiff lists the changes made to a table in edit mode, in table order. Rows added and then
+// deleted in the same session are not reported.
+type TableDiff struct {
+	Headers []string         `json:"headers" yaml:"headers"`
+	Changed []TableRowChange `json:"changed,omitempty" yaml:"changed,omitempty"`
+	Added   [][]string       `json:"added,omitempty" yaml:"added,omitempty"`
+	Deleted [][]string       `json:"deleted,omitempty" yaml:"deleted,omitempty"`
+}
+
+// IsEmpty reports whether the diff has no changes.
+func (d *TableDiff) IsEmpty() bool {
+	return len(d.Changed) == 0 && len(d.Added) == 0 && len(d.Deleted) == 0
+}
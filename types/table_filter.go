@@ -0,0 +1,406 @@
+package types
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// TableFilter is a parsed filter expression evaluated against table rows.
+//
+// The language is a list of terms joined by "and" (or juxtaposition), "or" and "not", with parentheses
+// for grouping. A term is either a bare word, matched case-insensitively as a substring of any cell, or
+// a column comparison such as:
+//
+//	status=installed      equal (case-insensitive for text columns)
+//	status!=residual      not equal
+//	version>=1.2          greater/lower than (>, >=, <, <=), compared by the column type
+//	name~^lib             regular expression (case-insensitive)
+//	name!~^lib            negated regular expression
+//	description:editor    substring of the column
+//
+// Columns are referenced by header (case-insensitive) or by 1-based position ("#2"). Values and
+// headers containing spaces or operators can be quoted with "double" or 'single' quotes.
+type TableFilter struct {
+	expr string
+	root filterNode
+}
+
+// TableFilterError is returned by ParseTableFilter with the position (in bytes) of the offending token.
+type TableFilterError struct {
+	Pos     int
+	Message string
+}
+
+func (e *TableFilterError) Error() string {
+	return fmt.Sprintf("%s (at %d)", e.Message, e.Pos+1)
+}
+
+// ParseTableFilter parses expr for a table with the given headers and column types. An empty
+// expression returns a nil filter, which matches every row.
+func ParseTableFilter(expr string, headers []string, columnTypes []ColumnType) (*TableFilter, error) {
+	tokens, err := tokenizeFilter(expr)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, nil
+	}
+	p := &filterParser{tokens: tokens, headers: headers, columnTypes: columnTypes, end: len(expr)}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if !p.done() {
+		return nil, &TableFilterError{Pos: p.peek().pos, Message: fmt.Sprintf("unexpected %q", p.peek().text)}
+	}
+	return &TableFilter{expr: expr, root: root}, nil
+}
+
+// Match reports whether row satisfies the filter. A nil filter matches every row.
+func (f *TableFilter) Match(row []string) bool {
+	if f == nil {
+		return true
+	}
+	return f.root.match(row)
+}
+
+// String returns the source expression.
+func (f *TableFilter) String() string {
+	if f == nil {
+		return ""
+	}
+	return f.expr
+}
+
+// Columns returns the indexes of the columns the expression refers to explicitly.
+func (f *TableFilter) Columns() []int {
+	if f == nil {
+		return nil
+	}
+	var cols []int
+	f.root.columns(&cols)
+	return cols
+}
+
+// Filter nodes
+
+type filterNode interface {
+	match(row []string) bool
+	columns(cols *[]int)
+}
+
+type andNode struct{ left, right filterNode }
+type orNode struct{ left, right filterNode }
+type notNode struct{ node filterNode }
+type anyCellNode struct{ needle string }
+type compareNode struct {
+	col        int
+	op         string
+	value      string
+	lowerValue string
+	columnType ColumnType
+	re         *regexp.Regexp
+}
+
+func (n andNode) match(row []string) bool { return n.left.match(row) && n.right.match(row) }
+func (n orNode) match(row []string) bool  { return n.left.match(row) || n.right.match(row) }
+func (n notNode) match(row []string) bool { return !n.node.match(row) }
+func (n anyCellNode) match(row []string) bool {
+	for _, cell := range row {
+		if strings.Contains(strings.ToLower(cell), n.needle) {
+			return true
+		}
+	}
+	return false
+}
+func (n compareNode) match(row []string) bool {
+	cell := ""
+	if n.col < len(row) {
+		cell = row[n.col]
+	}
+	switch n.op {
+	case "~":
+		return n.re.MatchString(cell)
+	case "!~":
+		return !n.re.MatchString(cell)
+	case ":":
+		return strings.Contains(strings.ToLower(cell), n.lowerValue)
+	}
+	var r int
+	if n.columnType == ColumnString || n.columnType == "" {
+		r = strings.Compare(strings.ToLower(strings.TrimSpace(cell)), n.lowerValue)
+	} else {
+		if strings.TrimSpace(cell) == "" && n.value != "" {
+			return n.op == "!="
+		}
+		r = n.columnType.Compare(cell, n.value)
+	}
+	switch n.op {
+	case "=", "==":
+		return r == 0
+	case "!=":
+		return r != 0
+	case ">":
+		return r > 0
+	case ">=":
+		return r >= 0
+	case "<":
+		return r < 0
+	case "<=":
+		return r <= 0
+	}
+	return false
+}
+
+func (n andNode) columns(cols *[]int)     { n.left.columns(cols); n.right.columns(cols) }
+func (n orNode) columns(cols *[]int)      { n.left.columns(cols); n.right.columns(cols) }
+func (n notNode) columns(cols *[]int)     { n.node.columns(cols) }
+func (n anyCellNode) columns(_ *[]int)    {}
+func (n compareNode) columns(cols *[]int) { *cols = append(*cols, n.col) }
+
+// Tokenizer
+
+type filterTokenKind int
+
+const (
+	tokWord filterTokenKind = iota
+	tokQuoted
+	tokOp
+	tokLParen
+	tokRParen
+	tokNot
+)
+
+type filterToken struct {
+	kind filterTokenKind
+	text string
+	pos  int
+}
+
+var filterOps = []string{"==", "!=", ">=", "<=", "!~", "=", ">", "<", "~", ":"}
+
+func isFilterOpStart(r rune) bool { return strings.ContainsRune("=!<>~:", r) }
+
+func tokenizeFilter(expr string) ([]filterToken, error) {
+	var tokens []filterToken
+	runes := []rune(expr)
+	pos := func(i int) int { return len(string(runes[:i])) }
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, filterToken{kind: tokLParen, text: "(", pos: pos(i)})
+			i++
+		case r == ')':
+			tokens = append(tokens, filterToken{kind: tokRParen, text: ")", pos: pos(i)})
+			i++
+		case r == '"' || r == '\'':
+			start := i
+			var b strings.Builder
+			i++
+			closed := false
+			for i < len(runes) {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					b.WriteRune(runes[i+1])
+					i += 2
+					continue
+				}
+				if runes[i] == r {
+					closed = true
+					i++
+					break
+				}
+				b.WriteRune(runes[i])
+				i++
+			}
+			if !closed {
+				return nil, &TableFilterError{Pos: pos(start), Message: "unterminated quoted string"}
+			}
+			tokens = append(tokens, filterToken{kind: tokQuoted, text: b.String(), pos: pos(start)})
+		case isFilterOpStart(r):
+			rest := string(runes[i:])
+			matched := ""
+			for _, op := range filterOps {
+				if strings.HasPrefix(rest, op) {
+					matched = op
+					break
+				}
+			}
+			if matched == "" {
+				// A lone "!" negates the following term.
+				tokens = append(tokens, filterToken{kind: tokNot, text: "!", pos: pos(i)})
+				i++
+				continue
+			}
+			tokens = append(tokens, filterToken{kind: tokOp, text: matched, pos: pos(i)})
+			i += len([]rune(matched))
+		default:
+			start := i
+			for i < len(runes) && !unicode.IsSpace(runes[i]) && runes[i] != '(' && runes[i] != ')' &&
+				runes[i] != '"' && runes[i] != '\'' && !isFilterOpStart(runes[i]) {
+				i++
+			}
+			tokens = append(tokens, filterToken{kind: tokWord, text: string(runes[start:i]), pos: pos(start)})
+		}
+	}
+	return tokens, nil
+}
+
+// Parser
+
+type filterParser struct {
+	tokens      []filterToken
+	i           int
+	end         int
+	headers     []string
+	columnTypes []ColumnType
+}
+
+func (p *filterParser) done() bool { return p.i >= len(p.tokens) }
+func (p *filterParser) peek() filterToken {
+	if p.done() {
+		return filterToken{pos: p.end}
+	}
+	return p.tokens[p.i]
+}
+func (p *filterParser) isKeyword(t filterToken, words ...string) bool {
+	if t.kind != tokWord {
+		return false
+	}
+	for _, w := range words {
+		if strings.EqualFold(t.text, w) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *filterParser) parseOr() (filterNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for !p.done() && (p.isKeyword(p.peek(), "or") || p.peek().text == "||") {
+		p.i++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orNode{left, right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseAnd() (filterNode, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for !p.done() {
+		t := p.peek()
+		if t.kind == tokRParen || p.isKeyword(t, "or") || t.text == "||" {
+			break
+		}
+		if p.isKeyword(t, "and") || t.text == "&&" {
+			p.i++
+		}
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = andNode{left, right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseNot() (filterNode, error) {
+	if t := p.peek(); t.kind == tokNot || p.isKeyword(t, "not") {
+		p.i++
+		node, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{node}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *filterParser) parsePrimary() (filterNode, error) {
+	if p.done() {
+		return nil, &TableFilterError{Pos: p.end, Message: "expected a term"}
+	}
+	t := p.tokens[p.i]
+	switch t.kind {
+	case tokLParen:
+		p.i++
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek().kind != tokRParen || p.done() {
+			return nil, &TableFilterError{Pos: p.peek().pos, Message: "missing closing parenthesis"}
+		}
+		p.i++
+		return node, nil
+	case tokWord, tokQuoted:
+		p.i++
+		if next := p.peek(); !p.done() && next.kind == tokOp {
+			return p.parseComparison(t)
+		}
+		return anyCellNode{needle: strings.ToLower(t.text)}, nil
+	}
+	return nil, &TableFilterError{Pos: t.pos, Message: fmt.Sprintf("unexpected %q", t.text)}
+}
+
+func (p *filterParser) parseComparison(column filterToken) (filterNode, error) {
+	col := p.resolveColumn(column.text)
+	if col < 0 {
+		return nil, &TableFilterError{Pos: column.pos, Message: fmt.Sprintf("unknown column %q", column.text)}
+	}
+	op := p.tokens[p.i]
+	p.i++
+	value := p.peek()
+	if p.done() || (value.kind != tokWord && value.kind != tokQuoted) {
+		return nil, &TableFilterError{Pos: value.pos, Message: fmt.Sprintf("expected a value after %q", op.text)}
+	}
+	p.i++
+	node := compareNode{col: col, op: op.text, value: value.text, lowerValue: strings.ToLower(value.text), columnType: ColumnString}
+	if col < len(p.columnTypes) && p.columnTypes[col] != "" {
+		node.columnType = p.columnTypes[col]
+	}
+	switch op.text {
+	case "~", "!~":
+		re, err := regexp.Compile("(?i)" + value.text)
+		if err != nil {
+			return nil, &TableFilterError{Pos: value.pos, Message: "invalid regular expression: " + err.Error()}
+		}
+		node.re = re
+	case ">", ">=", "<", "<=":
+		if node.columnType != ColumnString && node.columnType != ColumnSemver {
+			if _, ok := node.columnType.Parse(value.text); !ok {
+				return nil, &TableFilterError{Pos: value.pos, Message: fmt.Sprintf("%q is not a valid %s", value.text, node.columnType)}
+			}
+		}
+	}
+	return node, nil
+}
+
+// resolveColumn maps a header name (case-insensitive) or a "#N" position to a column index.
+func (p *filterParser) resolveColumn(name string) int {
+	for i, header := range p.headers {
+		if strings.EqualFold(header, name) {
+			return i
+		}
+	}
+	if strings.HasPrefix(name, "#") {
+		if n, err := strconv.Atoi(name[1:]); err == nil && n >= 1 && n <= len(p.headers) {
+			return n - 1
+		}
+	}
+	return -1
+}
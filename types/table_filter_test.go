@@ -0,0 +1,139 @@
+package types
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var (
+	filterHeaders = []string{"Name", "Status", "Version", "Size", "Description"}
+	filterTypes   = []ColumnType{ColumnString, ColumnString, ColumnSemver, ColumnBytes, ColumnString}
+	filterRows    = [][]string{
+		{"libc6", "installed", "2.35-0ubuntu3", "12 MB", "GNU C Library"},
+		{"vim", "installed", "2:8.2.3995", "3.5 MB", "Vi IMproved, a text editor"},
+		{"nano", "residual", "6.2-1", "", "small, friendly text editor"},
+		{"libssl3", "Installed", "3.0.2-0ubuntu1", "5 MB", "Secure Sockets Layer toolkit"},
+		{"git", "removed", "1:2.34.1-1ubuntu1", "800 KiB", "fast, scalable, distributed revision control"},
+	}
+)
+
+func TestParseTableFilterMatch(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+		want []string
+	}{
+		{"empty", "", []string{"libc6", "vim", "nano", "libssl3", "git"}},
+		{"bare word in any cell", "editor", []string{"vim", "nano"}},
+		{"bare word ignores case", "GNU", []string{"libc6"}},
+		{"equal ignores case of text", "status=installed", []string{"libc6", "vim", "libssl3"}},
+		{"double equal", "status==residual", []string{"nano"}},
+		{"not equal", "status!=installed", []string{"nano", "git"}},
+		{"semver ordering", "version<3", []string{"libc6"}},
+		{"epoch and pre-release", "version>2.35", []string{"vim", "nano", "libssl3", "git"}},
+		{"bytes ordering", "size<4MB", []string{"vim", "git"}},
+		{"empty cell fails ordering", "size>=0", []string{"libc6", "vim", "libssl3", "git"}},
+		{"empty cell passes not equal", "size!=5MB", []string{"libc6", "vim", "nano", "git"}},
+		{"regular expression", "name~^lib", []string{"libc6", "libssl3"}},
+		{"negated regular expression", "name!~^LIB", []string{"vim", "nano", "git"}},
+		{"column contains", "description:TEXT", []string{"vim", "nano"}},
+		{"and keyword", "status=installed and name~^lib", []string{"libc6", "libssl3"}},
+		{"juxtaposition is and", "editor status=installed", []string{"vim"}},
+		{"or keyword", "name=vim or name=git", []string{"vim", "git"}},
+		{"and binds tighter than or", "name=git or name~^lib and size>10MB", []string{"libc6", "git"}},
+		{"parentheses", "(name=git or name~^lib) and size>1MB", []string{"libc6", "libssl3"}},
+		{"not keyword", "not status=installed", []string{"nano", "git"}},
+		{"bang negates a term", "!editor", []string{"libc6", "libssl3", "git"}},
+		{"column by position", "#2=removed", []string{"git"}},
+		{"header ignores case", "NAME=nano", []string{"nano"}},
+		{"quoted value", `description:"a text editor"`, []string{"vim"}},
+		{"single quoted value", "description:'C Library'", []string{"libc6"}},
+		{"escaped quote", `name="v\im"`, []string{"vim"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := ParseTableFilter(tt.expr, filterHeaders, filterTypes)
+			if err != nil {
+				t.Fatalf("ParseTableFilter(%q): %v", tt.expr, err)
+			}
+			var got []string
+			for _, row := range filterRows {
+				if f.Match(row) {
+					got = append(got, row[0])
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseTableFilter(%q) matched %v, want %v", tt.expr, got, tt.want)
+			}
+			if f.String() != tt.expr {
+				t.Errorf("String() = %q, want %q", f.String(), tt.expr)
+			}
+		})
+	}
+}
+
+func TestParseTableFilterErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		expr    string
+		pos     int
+		message string
+	}{
+		{"unknown column", "owner=root", 0, "unknown column"},
+		{"position out of range", "#9=x", 0, "unknown column"},
+		{"missing value", "status=", 7, "expected a value"},
+		{"operator instead of value", "status==>", 8, "expected a value"},
+		{"invalid regular expression", `name~"(lib"`, 5, "invalid regular expression"},
+		{"invalid typed value", "size>lots", 5, `"lots" is not a valid bytes`},
+		{"unterminated quote", `name="vim`, 5, "unterminated quoted string"},
+		{"missing closing parenthesis", "(name=vim", 9, "missing closing parenthesis"},
+		{"unexpected closing parenthesis", "name=vim)", 8, `unexpected ")"`},
+		{"dangling and", "vim and", 7, "expected a term"},
+		{"dangling not", "not", 3, "expected a term"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := ParseTableFilter(tt.expr, filterHeaders, filterTypes)
+			if err == nil {
+				t.Fatalf("ParseTableFilter(%q) = %v, want an error", tt.expr, f)
+			}
+			var filterErr *TableFilterError
+			if !errors.As(err, &filterErr) {
+				t.Fatalf("ParseTableFilter(%q) error %T, want *TableFilterError", tt.expr, err)
+			}
+			if filterErr.Pos != tt.pos || !strings.Contains(filterErr.Message, tt.message) {
+				t.Errorf("ParseTableFilter(%q) error at %d %q, want at %d %q", tt.expr, filterErr.Pos, filterErr.Message, tt.pos, tt.message)
+			}
+		})
+	}
+}
+
+func TestTableFilterColumns(t *testing.T) {
+	tests := []struct {
+		expr string
+		want []int
+	}{
+		{"", nil},
+		{"editor", nil},
+		{"status=installed", []int{1}},
+		{"not (name~^lib or #4>1MB) and editor", []int{0, 3}},
+	}
+	for _, tt := range tests {
+		f, err := ParseTableFilter(tt.expr, filterHeaders, filterTypes)
+		if err != nil {
+			t.Fatalf("ParseTableFilter(%q): %v", tt.expr, err)
+		}
+		if got := f.Columns(); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseTableFilter(%q).Columns() = %v, want %v", tt.expr, got, tt.want)
+		}
+	}
+}
+
+func TestNilTableFilter(t *testing.T) {
+	var f *TableFilter
+	if !f.Match([]string{"anything"}) || f.String() != "" || f.Columns() != nil {
+		t.Error("a nil filter should match every row and have no expression or columns")
+	}
+}
@@ -0,0 +1,173 @@
+package types
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// FormatOperator is the test a conditional formatting rule applies to a cell.
+type FormatOperator string
+
+const (
+	FormatEquals    FormatOperator = "equals"
+	FormatNotEquals FormatOperator = "not_equals"
+	FormatContains  FormatOperator = "contains"
+	FormatRegex     FormatOperator = "regex"
+	FormatRange     FormatOperator = "range"
+)
+
+func (o FormatOperator) Description() string {
+	switch o {
+	case FormatEquals:
+		return "The cell equals the value"
+	case FormatNotEquals:
+		return "The cell does not equal the value"
+	case FormatContains:
+		return "The cell contains the value"
+	case FormatRegex:
+		return "The cell matches the regular expression"
+	case FormatRange:
+		return "The cell is between min and max, by column type"
+	default:
+		return "Unknown format operator"
+	}
+}
+func (o FormatOperator) String() string { return string(o) }
+
+// TableFormatStyle is the style a conditional formatting rule gives to the cells it matches. Colours
+// are hex ("#FF7698") or ANSI ("238") values.
+type TableFormatStyle struct {
+	Foreground string `json:"foreground,omitempty" yaml:"foreground,omitempty" toml:"foreground,omitempty"`
+	Background string `json:"background,omitempty" yaml:"background,omitempty" toml:"background,omitempty"`
+	Bold       bool   `json:"bold,omitempty" yaml:"bold,omitempty" toml:"bold,omitempty"`
+	Italic     bool   `json:"italic,omitempty" yaml:"italic,omitempty" toml:"italic,omitempty"`
+	Underline  bool   `json:"underline,omitempty" yaml:"underline,omitempty" toml:"underline,omitempty"`
+	Faint      bool   `json:"faint,omitempty" yaml:"faint,omitempty" toml:"faint,omitempty"`
+}
+
+// TableFormatRule styles the cells of a column, or of every column when Column is empty, that pass a
+// test. Column is a header (case-insensitive) or a 1-based position ("#2"), as in filters. Value is
+// compared by equals, not_equals, contains and regex; range compares cells with Min and Max (both
+// included, either one may be empty) using the column type. With Row set, the rule styles the whole
+// row of a matching cell.
+type TableFormatRule struct {
+	Column     string           `json:"column,omitempty" yaml:"column,omitempty" toml:"column,omitempty"`
+	Operator   FormatOperator   `json:"op" yaml:"op" toml:"op"`
+	Value      string           `json:"value,omitempty" yaml:"value,omitempty" toml:"value,omitempty"`
+	Min        string           `json:"min,omitempty" yaml:"min,omitempty" toml:"min,omitempty"`
+	Max        string           `json:"max,omitempty" yaml:"max,omitempty" toml:"max,omitempty"`
+	IgnoreCase bool             `json:"ignore_case,omitempty" yaml:"ignore_case,omitempty" toml:"ignore_case,omitempty"`
+	Row        bool             `json:"row,omitempty" yaml:"row,omitempty" toml:"row,omitempty"`
+	Style      TableFormatStyle `json:"style" yaml:"style" toml:"style"`
+}
+
+// TableFormatFile is a file of conditional formatting rules.
+type TableFormatFile struct {
+	Rules []TableFormatRule `json:"rules" yaml:"rules" toml:"rules"`
+}
+
+// LoadTableFormatRules reads the rules of a .json, .yaml or .toml file.
+func LoadTableFormatRules(path string) ([]TableFormatRule, error) {
+	format, err := profileFormat(path)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(strings.TrimSpace(string(data))) == 0 {
+		return nil, nil
+	}
+	file := &TableFormatFile{}
+	if _, err := NewMapperPtr(file, path).Deserialize(data, format); err != nil {
+		return nil, err
+	}
+	return file.Rules, nil
+}
+
+// TableFormatMatcher is a rule compiled against the headers of a table.
+type TableFormatMatcher struct {
+	Rule   TableFormatRule
+	Column int // source column tested, or -1 for every column
+	re     *regexp.Regexp
+}
+
+// CompileTableFormatRule checks rule and resolves its column among headers.
+func CompileTableFormatRule(rule TableFormatRule, headers []string) (*TableFormatMatcher, error) {
+	m := &TableFormatMatcher{Rule: rule, Column: -1}
+	if rule.Column != "" {
+		m.Column = headerColumn(rule.Column, headers)
+		if m.Column < 0 {
+			return nil, fmt.Errorf("format rule: unknown column %q", rule.Column)
+		}
+	} else if rule.Row {
+		return nil, fmt.Errorf("format rule: row rules need a column")
+	}
+	switch rule.Operator {
+	case FormatEquals, FormatNotEquals, FormatContains:
+	case FormatRegex:
+		pattern := rule.Value
+		if rule.IgnoreCase {
+			pattern = "(?i)" + pattern
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("format rule: %w", err)
+		}
+		m.re = re
+	case FormatRange:
+		if rule.Min == "" && rule.Max == "" {
+			return nil, fmt.Errorf("format rule: range needs min or max")
+		}
+	default:
+		return nil, fmt.Errorf("format rule: unknown operator %q", rule.Operator)
+	}
+	return m, nil
+}
+
+// headerColumn maps a header name (case-insensitive) or a "#N" position to a column index, or -1.
+func headerColumn(name string, headers []string) int {
+	for i, header := range headers {
+		if strings.EqualFold(header, name) {
+			return i
+		}
+	}
+	if strings.HasPrefix(name, "#") {
+		if n, err := strconv.Atoi(name[1:]); err == nil && n >= 1 && n <= len(headers) {
+			return n - 1
+		}
+	}
+	return -1
+}
+
+// Match reports whether value, a cell of a column of the given type, passes the rule.
+func (m *TableFormatMatcher) Match(value string, columnType ColumnType) bool {
+	rule := m.Rule
+	switch rule.Operator {
+	case FormatEquals, FormatNotEquals:
+		equal := value == rule.Value || rule.IgnoreCase && strings.EqualFold(value, rule.Value)
+		if !equal && columnType != ColumnString && columnType != "" {
+			_, ok := columnType.Parse(value)
+			equal = ok && columnType.Compare(value, rule.Value) == 0
+		}
+		return equal == (rule.Operator == FormatEquals)
+	case FormatContains:
+		if rule.IgnoreCase {
+			return strings.Contains(strings.ToLower(value), strings.ToLower(rule.Value))
+		}
+		return strings.Contains(value, rule.Value)
+	case FormatRegex:
+		return m.re.MatchString(value)
+	case FormatRange:
+		if _, ok := columnType.Parse(value); !ok || strings.TrimSpace(value) == "" {
+			return false
+		}
+		return (rule.Min == "" || columnType.Compare(value, rule.Min) >= 0) &&
+			(rule.Max == "" || columnType.Compare(value, rule.Max) <= 0)
+	}
+	return false
+}
@@ -0,0 +1,100 @@
+package types
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTableFormatMatch(t *testing.T) {
+	tests := []struct {
+		name       string
+		rule       TableFormatRule
+		columnType ColumnType
+		value      string
+		want       bool
+	}{
+		{"equals", TableFormatRule{Operator: FormatEquals, Value: "Error"}, ColumnString, "Error", true},
+		{"equals is case-sensitive", TableFormatRule{Operator: FormatEquals, Value: "Error"}, ColumnString, "error", false},
+		{"equals ignoring case", TableFormatRule{Operator: FormatEquals, Value: "Error", IgnoreCase: true}, ColumnString, "ERROR", true},
+		{"equals by type", TableFormatRule{Operator: FormatEquals, Value: "1024"}, ColumnBytes, "1 KiB", true},
+		{"equals by type needs a valid cell", TableFormatRule{Operator: FormatEquals, Value: "0"}, ColumnInt, "", false},
+		{"not equals", TableFormatRule{Operator: FormatNotEquals, Value: "installed"}, ColumnString, "removed", true},
+		{"not equals by type", TableFormatRule{Operator: FormatNotEquals, Value: "1.50"}, ColumnFloat, "1.5", false},
+		{"contains", TableFormatRule{Operator: FormatContains, Value: "lib"}, ColumnString, "libssl3", true},
+		{"contains ignoring case", TableFormatRule{Operator: FormatContains, Value: "LIB", IgnoreCase: true}, ColumnString, "libssl3", true},
+		{"regex", TableFormatRule{Operator: FormatRegex, Value: `^lib.*3$`}, ColumnString, "libssl3", true},
+		{"regex ignoring case", TableFormatRule{Operator: FormatRegex, Value: `^LIB`, IgnoreCase: true}, ColumnString, "libc6", true},
+		{"range inside", TableFormatRule{Operator: FormatRange, Min: "1 MB", Max: "10 MB"}, ColumnBytes, "3.5 MB", true},
+		{"range bounds included", TableFormatRule{Operator: FormatRange, Min: "1.2", Max: "1.10"}, ColumnSemver, "1.10", true},
+		{"range above", TableFormatRule{Operator: FormatRange, Max: "10"}, ColumnInt, "11", false},
+		{"range open above", TableFormatRule{Operator: FormatRange, Min: "10"}, ColumnInt, "1000", true},
+		{"range skips empty cells", TableFormatRule{Operator: FormatRange, Max: "10"}, ColumnInt, "", false},
+		{"range skips invalid cells", TableFormatRule{Operator: FormatRange, Max: "10"}, ColumnInt, "n/a", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := CompileTableFormatRule(tt.rule, nil)
+			if err != nil {
+				t.Fatalf("CompileTableFormatRule: %v", err)
+			}
+			if got := m.Match(tt.value, tt.columnType); got != tt.want {
+				t.Errorf("Match(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompileTableFormatRule(t *testing.T) {
+	headers := []string{"Name", "Status", "Size"}
+	tests := []struct {
+		rule    TableFormatRule
+		column  int
+		message string
+	}{
+		{TableFormatRule{Column: "status", Operator: FormatEquals}, 1, ""},
+		{TableFormatRule{Column: "#3", Operator: FormatEquals}, 2, ""},
+		{TableFormatRule{Operator: FormatContains}, -1, ""},
+		{TableFormatRule{Column: "Owner", Operator: FormatEquals}, 0, "unknown column"},
+		{TableFormatRule{Column: "#4", Operator: FormatEquals}, 0, "unknown column"},
+		{TableFormatRule{Operator: FormatEquals, Row: true}, 0, "row rules need a column"},
+		{TableFormatRule{Operator: FormatRegex, Value: "(lib"}, 0, "missing closing )"},
+		{TableFormatRule{Operator: FormatRange}, 0, "range needs min or max"},
+		{TableFormatRule{Operator: "like"}, 0, `unknown operator "like"`},
+	}
+	for _, tt := range tests {
+		m, err := CompileTableFormatRule(tt.rule, headers)
+		if tt.message != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.message) {
+				t.Errorf("CompileTableFormatRule(%+v) error %v, want %q", tt.rule, err, tt.message)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("CompileTableFormatRule(%+v): %v", tt.rule, err)
+		} else if m.Column != tt.column {
+			t.Errorf("CompileTableFormatRule(%+v) column %d, want %d", tt.rule, m.Column, tt.column)
+		}
+	}
+}
+
+func TestLoadTableFormatRules(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.yaml")
+	doc := "rules:\n  - column: size\n    op: range\n    min: 10 MB\n    row: true\n    style:\n      background: \"52\"\n      bold: true\n"
+	if err := os.WriteFile(path, []byte(doc), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	rules, err := LoadTableFormatRules(path)
+	if err != nil {
+		t.Fatalf("LoadTableFormatRules: %v", err)
+	}
+	want := []TableFormatRule{{Column: "size", Operator: FormatRange, Min: "10 MB", Row: true, Style: TableFormatStyle{Background: "52", Bold: true}}}
+	if !reflect.DeepEqual(rules, want) {
+		t.Errorf("LoadTableFormatRules() = %+v, want %+v", rules, want)
+	}
+	if _, err := LoadTableFormatRules(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("LoadTableFormatRules(missing file) did not fail")
+	}
+}
@@ -71,9 +71,14 @@ func (h *TableHandler) GetHashMap() map[string]string {
 	return m
 }
 func (h *TableHandler) GetObjectMap() []map[string]string {
+	keys := RecordKeys(h.Headers)
 	var m []map[string]string
 	for _, row := range h.Rows {
-		m = append(m, map[string]string{row[0]: row[1]})
+		record := make(map[string]string, len(keys))
+		for i, key := range keys {
+			record[key] = cellOf(row, i)
+		}
+		m = append(m, record)
 	}
 	return m
 }
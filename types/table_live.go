@@ -0,0 +1,41 @@
+package types
+
+// TableUpdateKind is the kind of change pushed to a live table.
+type TableUpdateKind string
+
+const (
+	TableRowInsert TableUpdateKind = "insert"
+	TableRowUpdate TableUpdateKind = "update"
+	TableRowDelete TableUpdateKind = "delete"
+)
+
+func (k TableUpdateKind) Description() string {
+	switch k {
+	case TableRowInsert:
+		return "Add a row"
+	case TableRowUpdate:
+		return "Replace a row, adding it when unknown"
+	case TableRowDelete:
+		return "Remove a row"
+	default:
+		return "Unknown table update"
+	}
+}
+func (k TableUpdateKind) String() string { return string(k) }
+
+// TableUpdate is one row change pushed to a live table. Key identifies the row to update or delete,
+// as returned by the row key of the renderer. It may only be left empty when the renderer has a row
+// key set (see SetRowKey), which then computes it from Row: the default key is the whole row, so it
+// cannot find the previous version of an updated row. Updates and deletes without a key are
+// otherwise ignored.
+type TableUpdate struct {
+	Kind TableUpdateKind
+	Key  string
+	Row  []string
+}
+
+// TableSourceStreamer is implemented by sources that push row changes as they happen. Renderers
+// apply every update received until the channel is closed.
+type TableSourceStreamer interface {
+	Updates() <-chan TableUpdate
+}
@@ -0,0 +1,164 @@
+package types
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// TableColumnProfile is the saved layout of one column, referenced by header so profiles survive
+// columns being added or moved in the source.
+type TableColumnProfile struct {
+	Header   string `json:"header" yaml:"header" toml:"header"`
+	Hidden   bool   `json:"hidden,omitempty" yaml:"hidden,omitempty" toml:"hidden,omitempty"`
+	Width    int    `json:"width,omitempty" yaml:"width,omitempty" toml:"width,omitempty"`
+	MinWidth int    `json:"min_width,omitempty" yaml:"min_width,omitempty" toml:"min_width,omitempty"`
+	MaxWidth int    `json:"max_width,omitempty" yaml:"max_width,omitempty" toml:"max_width,omitempty"`
+}
+
+// TableSortProfile is one saved sort key, referenced by header.
+type TableSortProfile struct {
+	Header string `json:"header" yaml:"header" toml:"header"`
+	Asc    bool   `json:"asc" yaml:"asc" toml:"asc"`
+}
+
+// TableAggregateProfile is one saved footer aggregate, referenced by header.
+type TableAggregateProfile struct {
+	Header string        `json:"header" yaml:"header" toml:"header"`
+	Func   AggregateFunc `json:"func" yaml:"func" toml:"func"`
+}
+
+// TableViewProfile is a named view of a table: column layout, frozen columns, sort stack, filter, page
+// size, grouping and footer aggregates.
+type TableViewProfile struct {
+	Name       string                  `json:"name" yaml:"name" toml:"name"`
+	Columns    []TableColumnProfile    `json:"columns,omitempty" yaml:"columns,omitempty" toml:"columns,omitempty"`
+	Sort       []TableSortProfile      `json:"sort,omitempty" yaml:"sort,omitempty" toml:"sort,omitempty"`
+	Filter     string                  `json:"filter,omitempty" yaml:"filter,omitempty" toml:"filter,omitempty"`
+	PageSize   int                     `json:"page_size,omitempty" yaml:"page_size,omitempty" toml:"page_size,omitempty"`
+	Frozen     int                     `json:"frozen,omitempty" yaml:"frozen,omitempty" toml:"frozen,omitempty"`
+	GroupBy    string                  `json:"group_by,omitempty" yaml:"group_by,omitempty" toml:"group_by,omitempty"`
+	Aggregates []TableAggregateProfile `json:"aggregates,omitempty" yaml:"aggregates,omitempty" toml:"aggregates,omitempty"`
+}
+
+// TableProfileStore holds the view profiles of every table, keyed by table identity.
+type TableProfileStore struct {
+	Tables map[string][]TableViewProfile `json:"tables" yaml:"tables" toml:"tables"`
+}
+
+// DefaultTableProfilesPath returns the profiles file path: $XTUI_TABLE_PROFILES when set, otherwise
+// xtui/table_profiles.yaml under the user config directory.
+func DefaultTableProfilesPath() (string, error) {
+	if path := os.Getenv("XTUI_TABLE_PROFILES"); path != "" {
+		return path, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "xtui", "table_profiles.yaml"), nil
+}
+
+// profileFormat returns the Mapper format matching the extension of path (json, yaml or toml).
+func profileFormat(path string) (string, error) {
+	switch ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(path), ".")); ext {
+	case "json":
+		return "json", nil
+	case "yaml", "yml":
+		return "yaml", nil
+	case "toml", "tml":
+		return "toml", nil
+	default:
+		return "", fmt.Errorf("unsupported profiles file format: %q", ext)
+	}
+}
+
+// LoadTableProfiles reads the profiles file at path. A missing file yields an empty store.
+func LoadTableProfiles(path string) (*TableProfileStore, error) {
+	store := &TableProfileStore{Tables: make(map[string][]TableViewProfile)}
+	format, err := profileFormat(path)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return store, nil
+		}
+		return nil, err
+	}
+	if len(strings.TrimSpace(string(data))) == 0 {
+		return store, nil
+	}
+	if _, err := NewMapperPtr(store, path).Deserialize(data, format); err != nil {
+		return nil, err
+	}
+	if store.Tables == nil {
+		store.Tables = make(map[string][]TableViewProfile)
+	}
+	return store, nil
+}
+
+// Save writes the store to path, creating its directory when needed.
+func (s *TableProfileStore) Save(path string) error {
+	format, err := profileFormat(path)
+	if err != nil {
+		return err
+	}
+	data, err := NewMapperPtr(s, path).Serialize(format)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// Profiles returns the profiles of tableID sorted by name.
+func (s *TableProfileStore) Profiles(tableID string) []TableViewProfile {
+	profiles := append([]TableViewProfile(nil), s.Tables[tableID]...)
+	sort.Slice(profiles, func(i, j int) bool { return profiles[i].Name < profiles[j].Name })
+	return profiles
+}
+
+// Profile returns the profile name of tableID.
+func (s *TableProfileStore) Profile(tableID, name string) (TableViewProfile, bool) {
+	for _, profile := range s.Tables[tableID] {
+		if profile.Name == name {
+			return profile, true
+		}
+	}
+	return TableViewProfile{}, false
+}
+
+// SetProfile adds profile to tableID, replacing any profile with the same name.
+func (s *TableProfileStore) SetProfile(tableID string, profile TableViewProfile) {
+	if s.Tables == nil {
+		s.Tables = make(map[string][]TableViewProfile)
+	}
+	for i, existing := range s.Tables[tableID] {
+		if existing.Name == profile.Name {
+			s.Tables[tableID][i] = profile
+			return
+		}
+	}
+	s.Tables[tableID] = append(s.Tables[tableID], profile)
+}
+
+// DeleteProfile removes the profile name of tableID and reports whether it existed.
+func (s *TableProfileStore) DeleteProfile(tableID, name string) bool {
+	profiles := s.Tables[tableID]
+	for i, profile := range profiles {
+		if profile.Name == name {
+			s.Tables[tableID] = append(profiles[:i], profiles[i+1:]...)
+			if len(s.Tables[tableID]) == 0 {
+				delete(s.Tables, tableID)
+			}
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,83 @@
+package types
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestTableProfileStoreRoundTrip(t *testing.T) {
+	profile := TableViewProfile{
+		Name:       "wide",
+		Columns:    []TableColumnProfile{{Header: "Size", Width: 8}, {Header: "Name", Hidden: true, MinWidth: 4, MaxWidth: 20}},
+		Sort:       []TableSortProfile{{Header: "Size"}, {Header: "Name", Asc: true}},
+		Filter:     `arch=amd64 and name~"^z"`,
+		PageSize:   15,
+		Frozen:     1,
+		GroupBy:    "Arch",
+		Aggregates: []TableAggregateProfile{{Header: "Size", Func: AggregateSum}},
+	}
+	for _, ext := range []string{"json", "yaml", "yml", "toml"} {
+		t.Run(ext, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "xtui", "profiles."+ext)
+			store := &TableProfileStore{}
+			store.SetProfile("packages", TableViewProfile{Name: "narrow", Filter: "size>1MB"})
+			store.SetProfile("packages", profile)
+			store.SetProfile("services", TableViewProfile{Name: "all"})
+			if err := store.Save(path); err != nil {
+				t.Fatalf("Save: %v", err)
+			}
+			loaded, err := LoadTableProfiles(path)
+			if err != nil {
+				t.Fatalf("LoadTableProfiles: %v", err)
+			}
+			if got, ok := loaded.Profile("packages", "wide"); !ok || !reflect.DeepEqual(got, profile) {
+				t.Errorf("Profile(packages, wide) = %+v, %v, want %+v", got, ok, profile)
+			}
+			var names []string
+			for _, p := range loaded.Profiles("packages") {
+				names = append(names, p.Name)
+			}
+			if want := []string{"narrow", "wide"}; !reflect.DeepEqual(names, want) {
+				t.Errorf("Profiles(packages) = %q, want %q", names, want)
+			}
+			if _, ok := loaded.Profile("services", "all"); !ok {
+				t.Error("the profile of another table was lost")
+			}
+		})
+	}
+}
+
+func TestLoadTableProfiles(t *testing.T) {
+	dir := t.TempDir()
+	store, err := LoadTableProfiles(filepath.Join(dir, "missing.yaml"))
+	if err != nil || store == nil || len(store.Tables) != 0 {
+		t.Errorf("LoadTableProfiles(missing file) = %+v, %v, want an empty store", store, err)
+	}
+	empty := filepath.Join(dir, "empty.json")
+	if err := os.WriteFile(empty, []byte("\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if store, err := LoadTableProfiles(empty); err != nil || len(store.Tables) != 0 {
+		t.Errorf("LoadTableProfiles(empty file) = %+v, %v, want an empty store", store, err)
+	}
+	if _, err := LoadTableProfiles(filepath.Join(dir, "profiles.ini")); err == nil {
+		t.Error("LoadTableProfiles(profiles.ini) did not fail")
+	}
+}
+
+func TestTableProfileStoreDelete(t *testing.T) {
+	store := &TableProfileStore{}
+	store.SetProfile("packages", TableViewProfile{Name: "wide"})
+	store.SetProfile("packages", TableViewProfile{Name: "wide", PageSize: 10})
+	if got := store.Profiles("packages"); len(got) != 1 || got[0].PageSize != 10 {
+		t.Errorf("Profiles() after replacing = %+v, want the second wide profile only", got)
+	}
+	if !store.DeleteProfile("packages", "wide") || store.DeleteProfile("packages", "wide") {
+		t.Error("DeleteProfile should report true once, then false")
+	}
+	if _, ok := store.Tables["packages"]; ok {
+		t.Error("a table without profiles was kept in the store")
+	}
+}
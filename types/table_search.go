@@ -0,0 +1,29 @@
+package types
+
+import "strings"
+
+// FuzzyMatch scores how well value matches query, ignoring case. A value containing query scores 0;
+// otherwise the score is the smallest Levenshtein distance between query and a part of value of about
+// its length, and parts further than the tolerance of IsEqual (a quarter of the query length) do not
+// match. Lower scores are better.
+func FuzzyMatch(query, value string) (int, bool) {
+	query, value = strings.ToLower(query), strings.ToLower(value)
+	if query == "" {
+		return 0, false
+	}
+	if strings.Contains(value, query) {
+		return 0, true
+	}
+	q, v := []rune(query), []rune(value)
+	threshold := len(q) / 4
+	if threshold == 0 || len(v) < len(q)-threshold {
+		return 0, false
+	}
+	best := threshold + 1
+	for size := len(q) - threshold; size <= min(len(q)+threshold, len(v)) && best > 1; size++ {
+		for start := 0; start+size <= len(v) && best > 1; start++ {
+			best = min(best, levenshtein(query, string(v[start:start+size])))
+		}
+	}
+	return best, best <= threshold
+}
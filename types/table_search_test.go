@@ -0,0 +1,27 @@
+package types
+
+import "testing"
+
+func TestFuzzyMatch(t *testing.T) {
+	tests := []struct {
+		query, value string
+		score        int
+		ok           bool
+	}{
+		{"", "vim", 0, false},
+		{"VIM", "vim editor", 0, true},
+		{"editor", "Text Editor", 0, true},
+		{"vin", "vim", 0, false},
+		{"pakage", "package manager", 1, true},
+		{"editro", "a text editor", 1, true},
+		{"pakkkge", "package", 0, false},
+		{"xyzxyz", "package", 0, false},
+		{"a long query", "short", 0, false},
+	}
+	for _, tt := range tests {
+		score, ok := FuzzyMatch(tt.query, tt.value)
+		if ok != tt.ok || (ok && score != tt.score) {
+			t.Errorf("FuzzyMatch(%q, %q) = %d, %v, want %d, %v", tt.query, tt.value, score, ok, tt.score, tt.ok)
+		}
+	}
+}
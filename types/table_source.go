@@ -0,0 +1,179 @@
+package types
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// TableDataSource is a paged table data provider. Unlike TableDataHandler it never has to hand
+// over every row at once: renderers ask only for the window they are about to display.
+type TableDataSource interface {
+	GetHeaders() []string
+	RowCount() int
+	FetchRows(offset, limit int) ([][]string, error)
+}
+
+// TableSourceFilterer is implemented by sources that filter rows on their side (database, index, etc.).
+// After SetFilter, RowCount and FetchRows must reflect the filtered view.
+type TableSourceFilterer interface {
+	SetFilter(filter string) error
+}
+
+// SortKey is one level of a multi-column sort: the column index and its direction.
+type SortKey struct {
+	Column int  `json:"column" yaml:"column"`
+	Asc    bool `json:"asc" yaml:"asc"`
+}
+
+// TableSourceSorter is implemented by sources that sort rows on their side.
+// After SetSort, FetchRows must return rows ordered by keys, primary key first. An empty slice
+// restores the natural order.
+type TableSourceSorter interface {
+	SetSort(keys []SortKey) error
+}
+
+// TableRowsProvider is implemented by sources whose rows are already in memory. Renderers use it to
+// keep filtering and sorting on the client side.
+type TableRowsProvider interface {
+	GetRows() [][]string
+}
+
+// TableSourceReloader is implemented by sources that cache their rows. Reload drops the cache so the
+// next read returns the current data.
+type TableSourceReloader interface {
+	Reload() error
+}
+
+// TableHandlerSource adapts a TableDataHandler to the TableDataSource interface.
+type TableHandlerSource struct {
+	Handler TableDataHandler
+	rows    [][]string
+	loaded  bool
+}
+
+// NewTableSourceFromHandler wraps a TableDataHandler so it can be driven as a TableDataSource.
+func NewTableSourceFromHandler(handler TableDataHandler) *TableHandlerSource {
+	return &TableHandlerSource{Handler: handler}
+}
+
+func (s *TableHandlerSource) GetHeaders() []string { return s.Handler.GetHeaders() }
+func (s *TableHandlerSource) GetRows() [][]string {
+	if !s.loaded {
+		s.rows = s.Handler.GetRows()
+		s.loaded = true
+	}
+	return s.rows
+}
+func (s *TableHandlerSource) RowCount() int { return len(s.GetRows()) }
+
+// Reload makes the next read ask the handler for its rows again.
+func (s *TableHandlerSource) Reload() error {
+	s.rows, s.loaded = nil, false
+	return nil
+}
+func (s *TableHandlerSource) FetchRows(offset, limit int) ([][]string, error) {
+	return sliceWindow(s.GetRows(), offset, limit), nil
+}
+
+// CSVFileSource is a TableDataSource backed by a CSV file on disk. It indexes the byte offset of
+// every record once and then reads only the requested windows, so files far bigger than the
+// available memory can be browsed.
+type CSVFileSource struct {
+	mu        sync.Mutex
+	file      *os.File
+	delimiter rune
+	comment   rune
+	headers   []string
+	offsets   []int64
+}
+
+// NewCSVFileSource opens path and indexes its records. The first record is used as headers.
+func NewCSVFileSource(path string, delimiter, comment rune) (*CSVFileSource, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	s := &CSVFileSource{file: file, delimiter: delimiter, comment: comment}
+	if err := s.index(); err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *CSVFileSource) newReader(r io.Reader) *csv.Reader {
+	reader := csv.NewReader(r)
+	reader.Comma = s.delimiter
+	reader.Comment = s.comment
+	reader.LazyQuotes = true
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = false
+	return reader
+}
+
+func (s *CSVFileSource) index() error {
+	reader := s.newReader(s.file)
+	headers, err := reader.Read()
+	if err != nil {
+		return fmt.Errorf("error reading CSV headers: %v", err)
+	}
+	s.headers = headers
+	for {
+		offset := reader.InputOffset()
+		if _, err := reader.Read(); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return fmt.Errorf("error indexing CSV record %d: %v", len(s.offsets)+1, err)
+		}
+		s.offsets = append(s.offsets, offset)
+	}
+	return nil
+}
+
+func (s *CSVFileSource) GetHeaders() []string { return s.headers }
+func (s *CSVFileSource) RowCount() int        { return len(s.offsets) }
+func (s *CSVFileSource) FetchRows(offset, limit int) ([][]string, error) {
+	if offset < 0 || offset >= len(s.offsets) || limit <= 0 {
+		return nil, nil
+	}
+	if offset+limit > len(s.offsets) {
+		limit = len(s.offsets) - offset
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.file.Seek(s.offsets[offset], io.SeekStart); err != nil {
+		return nil, err
+	}
+	reader := s.newReader(s.file)
+	rows := make([][]string, 0, limit)
+	for len(rows) < limit {
+		record, err := reader.Read()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return rows, err
+		}
+		rows = append(rows, record)
+	}
+	return rows, nil
+}
+
+// Close releases the underlying file.
+func (s *CSVFileSource) Close() error { return s.file.Close() }
+
+func sliceWindow(rows [][]string, offset, limit int) [][]string {
+	if offset < 0 || offset >= len(rows) || limit <= 0 {
+		return nil
+	}
+	end := offset + limit
+	if end > len(rows) {
+		end = len(rows)
+	}
+	return rows[offset:end]
+}
@@ -0,0 +1,137 @@
+package types
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const sourceCSV = `name,version,description
+vim,2:8.2.3995,"Vi IMproved, a text editor"
+# a comment line
+nano,6.2-1,"small editor
+spanning two lines"
+git,1:2.34.1-1ubuntu1,"quoted ""name"" inside"
+curl,7.81.0-1ubuntu1,  leading space
+`
+
+// newTestCSVSource writes content to a temporary file and opens it as a CSV file source.
+func newTestCSVSource(t *testing.T, content string, delimiter rune) *CSVFileSource {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "source.csv")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	source, err := NewCSVFileSource(path, delimiter, '#')
+	if err != nil {
+		t.Fatalf("NewCSVFileSource: %v", err)
+	}
+	t.Cleanup(func() { _ = source.Close() })
+	return source
+}
+
+func TestCSVFileSourceIndex(t *testing.T) {
+	source := newTestCSVSource(t, sourceCSV, ',')
+	if want := []string{"name", "version", "description"}; !reflect.DeepEqual(source.GetHeaders(), want) {
+		t.Errorf("GetHeaders() = %q, want %q", source.GetHeaders(), want)
+	}
+	if got := source.RowCount(); got != 4 {
+		t.Errorf("RowCount() = %d, want 4", got)
+	}
+}
+
+func TestCSVFileSourceFetchRows(t *testing.T) {
+	source := newTestCSVSource(t, sourceCSV, ',')
+	vim := []string{"vim", "2:8.2.3995", "Vi IMproved, a text editor"}
+	nano := []string{"nano", "6.2-1", "small editor\nspanning two lines"}
+	git := []string{"git", "1:2.34.1-1ubuntu1", `quoted "name" inside`}
+	curl := []string{"curl", "7.81.0-1ubuntu1", "leading space"}
+	tests := []struct {
+		name          string
+		offset, limit int
+		want          [][]string
+	}{
+		{"first page", 0, 2, [][]string{vim, nano}},
+		{"after a multi-line record", 2, 2, [][]string{git, curl}},
+		{"single row in the middle", 1, 1, [][]string{nano}},
+		{"limit past the end", 3, 10, [][]string{curl}},
+		{"every row", 0, 4, [][]string{vim, nano, git, curl}},
+		{"offset past the end", 4, 1, nil},
+		{"negative offset", -1, 2, nil},
+		{"zero limit", 0, 0, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := source.FetchRows(tt.offset, tt.limit)
+			if err != nil {
+				t.Fatalf("FetchRows(%d, %d): %v", tt.offset, tt.limit, err)
+			}
+			if len(got) != len(tt.want) || len(got) > 0 && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FetchRows(%d, %d) = %q, want %q", tt.offset, tt.limit, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCSVFileSourceRandomAccess(t *testing.T) {
+	var b strings.Builder
+	b.WriteString("id;value\n")
+	for i := range 1000 {
+		b.WriteString(strings.Repeat("x", i%7))
+		b.WriteString(";")
+		b.WriteString(string(rune('a' + i%26)))
+		b.WriteString("\n")
+	}
+	source := newTestCSVSource(t, b.String(), ';')
+	if got := source.RowCount(); got != 1000 {
+		t.Fatalf("RowCount() = %d, want 1000", got)
+	}
+	for _, offset := range []int{999, 0, 513, 26, 512} {
+		rows, err := source.FetchRows(offset, 1)
+		if err != nil || len(rows) != 1 {
+			t.Fatalf("FetchRows(%d, 1) = %q, %v", offset, rows, err)
+		}
+		want := []string{strings.Repeat("x", offset%7), string(rune('a' + offset%26))}
+		if !reflect.DeepEqual(rows[0], want) {
+			t.Errorf("FetchRows(%d, 1) = %q, want %q", offset, rows[0], want)
+		}
+	}
+}
+
+func TestNewCSVFileSourceErrors(t *testing.T) {
+	if _, err := NewCSVFileSource(filepath.Join(t.TempDir(), "missing.csv"), ',', '#'); err == nil {
+		t.Error("NewCSVFileSource on a missing file should fail")
+	}
+	path := filepath.Join(t.TempDir(), "empty.csv")
+	if err := os.WriteFile(path, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewCSVFileSource(path, ',', '#'); err == nil || !strings.Contains(err.Error(), "headers") {
+		t.Errorf("NewCSVFileSource on an empty file = %v, want a headers error", err)
+	}
+}
+
+func TestTableHandlerSourceFetchRows(t *testing.T) {
+	rows := [][]string{{"a"}, {"b"}, {"c"}}
+	source := NewTableSourceFromHandler(NewTableHandler([]string{"letter"}, rows))
+	if got := source.RowCount(); got != 3 {
+		t.Errorf("RowCount() = %d, want 3", got)
+	}
+	tests := []struct {
+		offset, limit int
+		want          [][]string
+	}{
+		{0, 2, rows[:2]},
+		{2, 5, rows[2:]},
+		{3, 1, nil},
+		{-1, 1, nil},
+		{0, 0, nil},
+	}
+	for _, tt := range tests {
+		if got, _ := source.FetchRows(tt.offset, tt.limit); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("FetchRows(%d, %d) = %q, want %q", tt.offset, tt.limit, got, tt.want)
+		}
+	}
+}
@@ -15,6 +15,31 @@ type InputField[T any] struct{ *t.InputObject[T] }
 func LogViewer(args ...string) error {
 	return c.StartTableScreen(nil, nil)
 }
+
+// TabbedTables is a screen showing several tables under a tab bar (see components.TabbedTables).
+type TabbedTables = c.TabbedTables
+
+// NewTabbedTables creates a tabbed table screen with one tab per handler, named by titles. Each
+// handler is asked for its rows the first time its tab is shown.
+func NewTabbedTables(titles []string, handlers ...t.TableDataHandler) *TabbedTables {
+	return c.NewTabbedTables(&t.MultiTableManager{Handlers: handlers, Titles: titles}, nil, nil)
+}
+
+// StartTabbedTables runs a tabbed table screen with one tab per handler, named by titles.
+func StartTabbedTables(titles []string, handlers ...t.TableDataHandler) error {
+	return c.StartTabbedTablesFromModel(NewTabbedTables(titles, handlers...))
+}
+
+// LinkedTables is a master table shown with the detail table of its current row (see
+// components.LinkedTables).
+type LinkedTables = c.LinkedTables
+
+// StartLinkedTables runs a master table with the detail table that loadDetail builds for the master
+// row under the cursor.
+func StartLinkedTables(master t.TableDataHandler, loadDetail c.LinkedDetailFunc) error {
+	return c.StartLinkedTables(master, loadDetail, nil)
+}
+
 func ShowForm(form Config) (map[string]string, error) {
 	return c.ShowForm(form.FormConfig)
 }